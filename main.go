@@ -30,9 +30,23 @@ func main() {
 	filterPath := ""
 	flaggy.String(&filterPath, "f", "filter", "Path to filter on in `git log -- <path>`. When in filter mode, the commits, reflog, and stash are filtered based on the given path, and some operations are restricted")
 
-	dump := ""
-	flaggy.AddPositionalValue(&dump, "gitargs", 1, false, "Todo file")
-	flaggy.DefaultParser.PositionalFlags[0].Hidden = true
+	execCommand := flaggy.NewSubcommand("exec")
+	execCommand.Description = "Run an operation without the gui and print the result as JSON"
+	execOperation := ""
+	execCommand.AddPositionalValue(&execOperation, "operation", 1, true, "One of: "+app.ExecUsage())
+	execArgs := make([]string, 2)
+	execCommand.AddPositionalValue(&execArgs[0], "arg1", 2, false, "The operation's first argument")
+	execCommand.AddPositionalValue(&execArgs[1], "arg2", 3, false, "The operation's second argument")
+
+	// when git runs us as its editor in the middle of a rebase we're passed the
+	// file to edit, which can't share its position with the exec subcommand
+	if os.Getenv("LAZYGIT_CLIENT_COMMAND") == "" {
+		flaggy.AttachSubcommand(execCommand, 1)
+	} else {
+		dump := ""
+		flaggy.AddPositionalValue(&dump, "gitargs", 1, false, "Todo file")
+		flaggy.DefaultParser.PositionalFlags[0].Hidden = true
+	}
 
 	versionFlag := false
 	flaggy.Bool(&versionFlag, "v", "version", "Print the current version")
@@ -97,6 +111,16 @@ func main() {
 		log.Fatal(err.Error())
 	}
 
+	if execCommand.Used {
+		args := []string{}
+		for _, arg := range execArgs {
+			if arg != "" {
+				args = append(args, arg)
+			}
+		}
+		os.Exit(app.Exec(appConfig, execOperation, args))
+	}
+
 	app, err := app.NewApp(appConfig, filterPath)
 
 	if err == nil {
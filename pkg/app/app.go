@@ -223,6 +223,10 @@ func (app *App) Run() error {
 		os.Exit(0)
 	}
 
+	if app.ClientContext == "ASKPASS" {
+		return app.Askpass()
+	}
+
 	err := app.Gui.RunWithSubprocesses()
 	return err
 }
@@ -256,6 +260,21 @@ func (app *App) Rebase() error {
 	return nil
 }
 
+// Askpass is for when git or ssh have run us to ask for a credential, with the
+// prompt as our argument. We pass the prompt on to the lazygit that ran the
+// command and print the answer for git or ssh to read
+func (app *App) Askpass() error {
+	app.Log.Info("Lazygit invoked as askpass demon")
+
+	answer, err := oscommands.SendAskpassPrompt(strings.Join(os.Args[1:], " "))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(answer)
+	return nil
+}
+
 // Close closes any resources
 func (app *App) Close() error {
 	for _, closer := range app.closers {
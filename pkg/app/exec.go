@@ -0,0 +1,320 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+)
+
+// exec mode runs one of our higher level operations without the gui, so that
+// scripts and editor plugins can make use of things like squashing fixup
+// commits. The result is printed to stdout as JSON.
+
+// ExecResult is what we print after running an operation
+type ExecResult struct {
+	Operation string `json:"operation"`
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+	// Head is the sha HEAD points to once we're done
+	Head string `json:"head,omitempty"`
+	// WorkingTreeState is one of 'normal', 'rebasing', 'applying' and
+	// 'merging'. If a rebase stopped because of conflicts it'll be 'rebasing',
+	// and if applying patches with git am did, it'll be 'applying'
+	WorkingTreeState string `json:"workingTreeState,omitempty"`
+	// Commands are the commands we ran on the repo in order to do the operation
+	Commands []string `json:"commands"`
+}
+
+type execOperation struct {
+	// argNames are the names of the operation's arguments for the usage message.
+	// Optional arguments are in square brackets
+	argNames []string
+	run      func(e *execContext, args []string) error
+}
+
+// execContext has what an operation needs to run. Commands which change the
+// repo should go through actionGitCommand so that they're reported in the result
+type execContext struct {
+	gitCommand       *commands.GitCommand
+	actionGitCommand *commands.GitCommand
+	osCommand        *oscommands.OSCommand
+	tr               *i18n.Localizer
+}
+
+var execOperations = map[string]*execOperation{
+	"squash-fixups": {
+		argNames: []string{"sha"},
+		run: func(e *execContext, args []string) error {
+			if err := e.validateNormalWorkingTreeState(); err != nil {
+				return err
+			}
+			return e.actionGitCommand.SquashAllAboveFixupCommits(args[0])
+		},
+	},
+	"amend-to": {
+		argNames: []string{"sha"},
+		run: func(e *execContext, args []string) error {
+			if err := e.validateNormalWorkingTreeState(); err != nil {
+				return err
+			}
+			return e.actionGitCommand.AmendTo(args[0])
+		},
+	},
+	"move-commit": {
+		argNames: []string{"sha", "up|down"},
+		run: func(e *execContext, args []string) error {
+			if err := e.validateNormalWorkingTreeState(); err != nil {
+				return err
+			}
+			return e.moveCommit(args[0], args[1])
+		},
+	},
+	"stash-staged": {
+		argNames: []string{"[message]"},
+		run: func(e *execContext, args []string) error {
+			message := ""
+			if len(args) > 0 {
+				message = args[0]
+			}
+			return e.actionGitCommand.StashSaveStagedChanges(message)
+		},
+	},
+	"undo": {
+		run: func(e *execContext, args []string) error {
+			return e.undoOrRedo(false)
+		},
+	},
+	"redo": {
+		run: func(e *execContext, args []string) error {
+			return e.undoOrRedo(true)
+		},
+	},
+}
+
+// ExecUsage describes the operations you can run in exec mode
+func ExecUsage() string {
+	names := make([]string, 0, len(execOperations))
+	for name := range execOperations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	usages := make([]string, len(names))
+	for i, name := range names {
+		usages[i] = strings.Join(append([]string{name}, execOperations[name].argNames...), " ")
+	}
+	return strings.Join(usages, ", ")
+}
+
+// getExecOperation returns the operation with the given name, if it's been
+// given the right number of arguments
+func getExecOperation(tr *i18n.Localizer, name string, args []string) (*execOperation, error) {
+	operation, ok := execOperations[name]
+	if !ok {
+		return nil, errors.New(tr.TemplateLocalize("ExecUnknownOperation", i18n.Teml{"operation": name, "operations": ExecUsage()}))
+	}
+
+	required := 0
+	for _, argName := range operation.argNames {
+		if !strings.HasPrefix(argName, "[") {
+			required++
+		}
+	}
+	if len(args) < required || len(args) > len(operation.argNames) {
+		usage := strings.Join(append([]string{name}, operation.argNames...), " ")
+		return nil, errors.New(tr.TemplateLocalize("ExecWrongArguments", i18n.Teml{"usage": usage}))
+	}
+
+	return operation, nil
+}
+
+// Exec runs an operation against the repo in the current directory and prints
+// the result as JSON, returning the exit code
+func Exec(config config.AppConfigurer, operationName string, args []string) int {
+	result := &ExecResult{Operation: operationName, Commands: []string{}}
+
+	if err := runExecOperation(config, operationName, args, result); err != nil {
+		result.Error = strings.TrimSpace(err.Error())
+	} else {
+		result.Success = true
+	}
+
+	output, _ := json.MarshalIndent(result, "", "  ")
+	fmt.Println(string(output))
+
+	if !result.Success {
+		return 1
+	}
+	return 0
+}
+
+func runExecOperation(config config.AppConfigurer, operationName string, args []string, result *ExecResult) error {
+	app := &App{Config: config}
+	app.Log = newLogger(config)
+	app.Tr = i18n.NewLocalizer(app.Log)
+
+	operation, err := getExecOperation(app.Tr, operationName, args)
+	if err != nil {
+		return err
+	}
+
+	app.OSCommand = oscommands.NewOSCommand(app.Log, config)
+	if err := app.validateGitVersion(); err != nil {
+		return err
+	}
+
+	app.GitCommand, err = commands.NewGitCommand(app.Log, app.OSCommand, app.Tr, app.Config)
+	if err != nil {
+		if message, known := app.KnownError(err); known {
+			return errors.New(message)
+		}
+		return err
+	}
+
+	e := &execContext{
+		gitCommand:       app.GitCommand,
+		actionGitCommand: app.GitCommand.WithAction(operationName),
+		osCommand:        app.OSCommand,
+		tr:               app.Tr,
+	}
+
+	err = operation.run(e, args)
+
+	for _, action := range app.OSCommand.CmdLog.Actions() {
+		if action.Background {
+			continue
+		}
+		for _, entry := range action.Entries {
+			result.Commands = append(result.Commands, entry.Command)
+		}
+	}
+	result.WorkingTreeState = app.GitCommand.WorkingTreeState()
+	if head, headErr := app.OSCommand.RunCommandWithOutput("git rev-parse HEAD"); headErr == nil {
+		result.Head = strings.TrimSpace(head)
+	}
+
+	return err
+}
+
+func (e *execContext) validateNormalWorkingTreeState() error {
+	if e.gitCommand.WorkingTreeState() != "normal" {
+		return errors.New(e.tr.SLocalize("ExecNotInNormalState"))
+	}
+	return nil
+}
+
+// moveCommit moves a commit up (i.e. towards HEAD) or down one place, as we do
+// with ctrl+k and ctrl+j in the commits panel
+func (e *execContext) moveCommit(sha string, direction string) error {
+	builder := commands.NewCommitListBuilder(e.gitCommand.Log, e.gitCommand, e.osCommand, e.tr)
+	commits, err := builder.GetCommits(commands.GetCommitsOptions{Limit: true, RefName: "HEAD"})
+	if err != nil {
+		return err
+	}
+
+	index := indexOfCommit(commits, sha)
+	if index == -1 {
+		return errors.New(e.tr.TemplateLocalize("ExecCommitNotFound", i18n.Teml{"sha": sha}))
+	}
+
+	switch direction {
+	case "down":
+		return e.actionGitCommand.MoveCommitDown(commits, index)
+	case "up":
+		if index == 0 {
+			return errors.New(e.tr.SLocalize("NoRoom"))
+		}
+		return e.actionGitCommand.MoveCommitDown(commits, index-1)
+	default:
+		return errors.New(e.tr.TemplateLocalize("ExecWrongArguments", i18n.Teml{"usage": "move-commit sha up|down"}))
+	}
+}
+
+// indexOfCommit allows for abbreviated shas
+func indexOfCommit(commits []*models.Commit, sha string) int {
+	for i, commit := range commits {
+		if sha != "" && strings.HasPrefix(commit.Sha, sha) {
+			return i
+		}
+	}
+	return -1
+}
+
+// undoOrRedo does what undo and redo do in the gui, except that rather than
+// offering to stash your changes before a hard reset, we refuse to do it
+func (e *execContext) undoOrRedo(redo bool) error {
+	if state := e.gitCommand.WorkingTreeState(); state == "rebasing" || state == "applying" {
+		if redo {
+			return errors.New(e.tr.SLocalize("cantRedoWhileRebasing"))
+		}
+		return errors.New(e.tr.SLocalize("cantUndoWhileRebasing"))
+	}
+
+	reflogCommits, _, err := e.gitCommand.GetReflogCommits(nil, "")
+	if err != nil {
+		return err
+	}
+
+	envVars := []string{commands.UNDO_REFLOG_ACTION}
+	if redo {
+		envVars = []string{commands.REDO_REFLOG_ACTION}
+	}
+
+	done := false
+	err = commands.ParseReflogForActions(reflogCommits, func(counter int, action commands.ReflogAction) (bool, error) {
+		target := action.From
+		if redo {
+			if counter == 0 {
+				return true, nil
+			} else if counter > 1 {
+				return false, nil
+			}
+			target = action.To
+		} else if counter != 0 {
+			return false, nil
+		}
+
+		switch action.Kind {
+		case commands.REFLOG_COMMIT, commands.REFLOG_REBASE:
+			if e.hasDirtyTrackedFiles() {
+				return true, errors.New(e.tr.SLocalize("ExecDirtyWorkingTree"))
+			}
+			done = true
+			return true, e.actionGitCommand.ResetToCommit(target, "hard", oscommands.RunCommandOptions{EnvVars: envVars})
+		case commands.REFLOG_CHECKOUT:
+			done = true
+			return true, e.actionGitCommand.Checkout(target, commands.CheckoutOptions{EnvVars: envVars})
+		}
+
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if !done {
+		if redo {
+			return errors.New(e.tr.SLocalize("ExecNothingToRedo"))
+		}
+		return errors.New(e.tr.SLocalize("ExecNothingToUndo"))
+	}
+
+	return nil
+}
+
+func (e *execContext) hasDirtyTrackedFiles() bool {
+	for _, file := range e.gitCommand.GetStatusFiles(commands.GetStatusFileOptions{}) {
+		if file.Tracked {
+			return true
+		}
+	}
+	return false
+}
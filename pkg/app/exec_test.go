@@ -0,0 +1,87 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetExecOperation(t *testing.T) {
+	type scenario struct {
+		testName      string
+		operation     string
+		args          []string
+		expectedError string
+	}
+
+	scenarios := []scenario{
+		{
+			"Operation without arguments",
+			"undo",
+			[]string{},
+			"",
+		},
+		{
+			"Operation with its arguments",
+			"move-commit",
+			[]string{"abc123", "up"},
+			"",
+		},
+		{
+			"Optional argument left out",
+			"stash-staged",
+			[]string{},
+			"",
+		},
+		{
+			"Optional argument given",
+			"stash-staged",
+			[]string{"my stash"},
+			"",
+		},
+		{
+			"Missing argument",
+			"squash-fixups",
+			[]string{},
+			"Usage: lazygit exec squash-fixups sha",
+		},
+		{
+			"Too many arguments",
+			"undo",
+			[]string{"abc123"},
+			"Usage: lazygit exec undo",
+		},
+		{
+			"Unknown operation",
+			"squash",
+			[]string{},
+			"Unknown operation 'squash'. Operations: amend-to sha, move-commit sha up|down, redo, squash-fixups sha, stash-staged [message], undo",
+		},
+	}
+
+	tr := i18n.NewLocalizer(utils.NewDummyLog())
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			operation, err := getExecOperation(tr, s.operation, s.args)
+			if s.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, operation)
+			} else {
+				assert.EqualError(t, err, s.expectedError)
+			}
+		})
+	}
+}
+
+func TestIndexOfCommit(t *testing.T) {
+	commits := []*models.Commit{{Sha: "abc123"}, {Sha: "def456"}}
+
+	assert.EqualValues(t, 1, indexOfCommit(commits, "def"))
+	assert.EqualValues(t, 0, indexOfCommit(commits, "abc123"))
+	assert.EqualValues(t, -1, indexOfCommit(commits, "123"))
+	assert.EqualValues(t, -1, indexOfCommit(commits, ""))
+}
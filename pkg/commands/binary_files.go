@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// registering the image formats we can read the dimensions of
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// git can't show us a diff of a binary file, so instead we summarise how the
+// file has changed.
+
+// the extensions of files we'll show dimensions for, even if we can't decode
+// the image ourselves
+var imageExtensions = []string{".png", ".jpg", ".jpeg", ".gif", ".bmp", ".webp", ".ico", ".tiff"}
+
+// BinaryFileVersion is the file before or after the change. Size is -1 if the
+// file didn't exist
+type BinaryFileVersion struct {
+	Size int64
+	// Dimensions is something like '640x480', or empty if we couldn't decode the
+	// image
+	Dimensions string
+}
+
+// BinaryFileSummary describes a change to a binary file
+type BinaryFileSummary struct {
+	Name    string
+	IsImage bool
+	Before  BinaryFileVersion
+	After   BinaryFileVersion
+}
+
+// WorktreeFileIsBinary tells us whether git treats the changes to a file as
+// binary, in which case there are no lines to stage
+func (c *GitCommand) WorktreeFileIsBinary(file *models.File, cached bool) bool {
+	// git diff --no-index exits with an error when there's a difference, so we
+	// go by the output alone
+	output, _ := c.OSCommand.RunCommandWithOutput("git diff --no-ext-diff --numstat %s", c.worktreeFileDiffArgs(file, cached))
+	return isBinaryNumstat(output)
+}
+
+// for binary files, numstat shows a dash in place of the added and deleted line counts
+func isBinaryNumstat(output string) bool {
+	return strings.HasPrefix(output, "-\t-\t")
+}
+
+// GetBinaryFileSummary summarises the file's unstaged changes, or its staged
+// changes if cached is true
+func (c *GitCommand) GetBinaryFileSummary(file *models.File, cached bool) *BinaryFileSummary {
+	oldName := file.Names()[0]
+	newName := file.Name
+
+	summary := &BinaryFileSummary{
+		Name:    newName,
+		IsImage: isImageFile(newName),
+	}
+
+	if cached {
+		summary.Before = c.binaryBlobVersion("HEAD:"+oldName, summary.IsImage)
+		summary.After = c.binaryBlobVersion(":"+newName, summary.IsImage)
+	} else {
+		summary.Before = BinaryFileVersion{Size: -1}
+		if file.Tracked || file.HasStagedChanges {
+			summary.Before = c.binaryBlobVersion(":"+newName, summary.IsImage)
+		}
+		summary.After = binaryWorktreeVersion(newName, summary.IsImage)
+	}
+
+	return summary
+}
+
+func (c *GitCommand) binaryBlobVersion(object string, isImage bool) BinaryFileVersion {
+	quotedObject := c.OSCommand.Quote(object)
+	output, err := c.OSCommand.RunCommandWithOutput("git cat-file -s %s", quotedObject)
+	if err != nil {
+		return BinaryFileVersion{Size: -1}
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(output), 10, 64)
+	if err != nil {
+		return BinaryFileVersion{Size: -1}
+	}
+
+	version := BinaryFileVersion{Size: size}
+	if isImage {
+		content, err := c.OSCommand.RunCommandWithOutput("git cat-file blob %s", quotedObject)
+		if err == nil {
+			version.Dimensions = imageDimensions(strings.NewReader(content))
+		}
+	}
+	return version
+}
+
+func binaryWorktreeVersion(path string, isImage bool) BinaryFileVersion {
+	info, err := os.Stat(path)
+	if err != nil {
+		return BinaryFileVersion{Size: -1}
+	}
+
+	version := BinaryFileVersion{Size: info.Size()}
+	if isImage {
+		if f, err := os.Open(path); err == nil {
+			version.Dimensions = imageDimensions(f)
+			f.Close()
+		}
+	}
+	return version
+}
+
+func imageDimensions(r io.Reader) string {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", config.Width, config.Height)
+}
+
+func isImageFile(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	for _, imageExtension := range imageExtensions {
+		if extension == imageExtension {
+			return true
+		}
+	}
+	return false
+}
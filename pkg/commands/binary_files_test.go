@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandWorktreeFileIsBinary is a function.
+func TestGitCommandWorktreeFileIsBinary(t *testing.T) {
+	type scenario struct {
+		testName     string
+		file         *models.File
+		expectedArgs []string
+		output       string
+		expected     bool
+	}
+
+	scenarios := []scenario{
+		{
+			"binary file",
+			&models.File{Name: "image.png", Tracked: true},
+			[]string{"diff", "--no-ext-diff", "--numstat", "--", "image.png"},
+			"-\t-\timage.png",
+			true,
+		},
+		{
+			"text file",
+			&models.File{Name: "test.txt", Tracked: true},
+			[]string{"diff", "--no-ext-diff", "--numstat", "--", "test.txt"},
+			"1\t2\ttest.txt",
+			false,
+		},
+		{
+			"untracked file",
+			&models.File{Name: "image.png", Tracked: false},
+			[]string{"diff", "--no-ext-diff", "--numstat", "--no-index", "/dev/null", "image.png"},
+			"-\t-\timage.png",
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return exec.Command("printf", s.output)
+			}
+
+			assert.EqualValues(t, s.expected, gitCmd.WorktreeFileIsBinary(s.file, false))
+		})
+	}
+}
+
+// TestIsImageFile is a function.
+func TestIsImageFile(t *testing.T) {
+	assert.True(t, isImageFile("assets/logo.PNG"))
+	assert.True(t, isImageFile("photo.jpeg"))
+	assert.False(t, isImageFile("archive.zip"))
+	assert.False(t, isImageFile("png"))
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetBisectInfo returns the state of the current bisect. Git keeps this in a
+// handful of files in the .git dir and under refs/bisect/ e.g.
+// refs/bisect/bad
+// refs/bisect/good-9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae
+// refs/bisect/skip-2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f
+func (c *GitCommand) GetBisectInfo() (*models.BisectInfo, error) {
+	info := &models.BisectInfo{
+		NewTerm:     "bad",
+		OldTerm:     "good",
+		OldShas:     map[string]bool{},
+		SkippedShas: map[string]bool{},
+		Candidates:  map[string]bool{},
+	}
+
+	startContent, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "BISECT_START"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return info, nil
+		}
+		return nil, err
+	}
+	info.Start = strings.TrimSpace(string(startContent))
+
+	// this file only exists if custom terms were given when starting the bisect
+	termsContent, err := ioutil.ReadFile(filepath.Join(c.DotGitDir, "BISECT_TERMS"))
+	if err == nil {
+		terms := utils.SplitLines(string(termsContent))
+		if len(terms) == 2 {
+			info.NewTerm = terms[0]
+			info.OldTerm = terms[1]
+		}
+	}
+
+	current, err := c.OSCommand.RunCommandWithOutput("git rev-parse HEAD")
+	if err != nil {
+		return nil, err
+	}
+	info.Current = strings.TrimSpace(current)
+
+	refsCmdStr := "git for-each-ref --format='%(refname) %(objectname)' refs/bisect/"
+	refsStr, err := c.OSCommand.RunCommandWithOutput(refsCmdStr)
+	if err != nil {
+		return nil, err
+	}
+
+	newRef := "refs/bisect/" + info.NewTerm
+	oldRefPrefix := "refs/bisect/" + info.OldTerm + "-"
+	skipRefPrefix := "refs/bisect/skip-"
+	for _, line := range utils.SplitLines(refsStr) {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		refName, sha := fields[0], fields[1]
+
+		switch {
+		case refName == newRef:
+			info.NewSha = sha
+		case strings.HasPrefix(refName, oldRefPrefix):
+			info.OldShas[sha] = true
+		case strings.HasPrefix(refName, skipRefPrefix):
+			info.SkippedShas[sha] = true
+		}
+	}
+
+	if info.NewSha == "" || len(info.OldShas) == 0 {
+		return info, nil
+	}
+
+	// the candidates are whatever is reachable from the new commit but not from any of the old ones
+	oldShas := make([]string, 0, len(info.OldShas))
+	for sha := range info.OldShas {
+		oldShas = append(oldShas, sha)
+	}
+	candidatesStr, err := c.OSCommand.RunCommandWithOutput("git rev-list %s --not %s", info.NewSha, strings.Join(oldShas, " "))
+	if err != nil {
+		return nil, err
+	}
+	for _, sha := range utils.SplitLines(candidatesStr) {
+		info.Candidates[sha] = true
+	}
+
+	return info, nil
+}
+
+func (c *GitCommand) BisectStart() error {
+	return c.OSCommand.RunCommand("git bisect start")
+}
+
+// BisectMark marks a commit with the given term, which is either one of the
+// bisect's new/old terms or 'skip'. Git will then check out the next commit to test
+func (c *GitCommand) BisectMark(ref string, term string) error {
+	return c.OSCommand.RunCommand("git bisect %s %s", term, ref)
+}
+
+func (c *GitCommand) BisectReset() error {
+	return c.OSCommand.RunCommand("git bisect reset")
+}
+
+// BisectRunCmdStr returns the command which has git run the given script against
+// each candidate, marking commits based on the script's exit code
+func (c *GitCommand) BisectRunCmdStr(script string) string {
+	return fmt.Sprintf("git bisect run %s", script)
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// BlameCmdStr returns the command for blaming a file at the given ref. If the ref
+// is blank we blame the file as it is in the working tree
+func (c *GitCommand) BlameCmdStr(ref string, path string) string {
+	refArg := ""
+	if ref != "" {
+		refArg = " " + ref
+	}
+
+	return fmt.Sprintf("git blame --porcelain%s -- %s", refArg, c.OSCommand.Quote(path))
+}
+
+// e.g. '9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae 12 14 3' where the numbers are
+// the line number in the original commit, the line number in the blamed file,
+// and (for the first line of a group) how many lines follow from the same commit
+var blameHeaderRegexp = regexp.MustCompile(`^([0-9a-f]{40}) (\d+) (\d+)( \d+)?$`)
+
+// BlameParser parses the output of `git blame --porcelain` one line at a time,
+// so that we can render a big file while it's still being blamed. Each line of
+// the file is given as a header line, followed by details of its commit (only
+// the first time we see that commit) and then the line's content prefixed with a tab
+type BlameParser struct {
+	commits map[string]*models.BlameCommit
+	// current is the line whose header we're part-way through reading
+	current *models.BlameLine
+}
+
+func NewBlameParser() *BlameParser {
+	return &BlameParser{commits: map[string]*models.BlameCommit{}}
+}
+
+// ParseLine returns the blamed line once its content has been reached. The bool
+// is false if the line isn't part of git's porcelain output e.g. if git complained
+// that the file doesn't exist
+func (p *BlameParser) ParseLine(line string) (*models.BlameLine, bool) {
+	if p.current == nil {
+		match := blameHeaderRegexp.FindStringSubmatch(line)
+		if match == nil {
+			return nil, false
+		}
+
+		sha := match[1]
+		commit, ok := p.commits[sha]
+		if !ok {
+			commit = &models.BlameCommit{Sha: sha}
+			p.commits[sha] = commit
+		}
+		originalLineNumber, _ := strconv.Atoi(match[2])
+		lineNumber, _ := strconv.Atoi(match[3])
+
+		p.current = &models.BlameLine{
+			Commit:             commit,
+			LineNumber:         lineNumber,
+			OriginalLineNumber: originalLineNumber,
+		}
+		return nil, true
+	}
+
+	if strings.HasPrefix(line, "\t") {
+		blameLine := p.current
+		blameLine.Content = line[1:]
+		p.current = nil
+		return blameLine, true
+	}
+
+	split := strings.SplitN(line, " ", 2)
+	key := split[0]
+	value := ""
+	if len(split) > 1 {
+		value = split[1]
+	}
+
+	commit := p.current.Commit
+	switch key {
+	case "author":
+		commit.Author = value
+	case "author-time":
+		commit.AuthorTime, _ = strconv.ParseInt(value, 10, 64)
+	case "summary":
+		commit.Summary = value
+	case "boundary":
+		commit.Boundary = true
+	case "previous":
+		previous := strings.SplitN(value, " ", 2)
+		if len(previous) == 2 {
+			commit.PreviousSha = previous[0]
+			commit.PreviousPath = previous[1]
+		}
+	}
+
+	return nil, true
+}
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandBlameCmdStr is a function.
+func TestGitCommandBlameCmdStr(t *testing.T) {
+	type scenario struct {
+		testName string
+		ref      string
+		path     string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"Working tree",
+			"",
+			"README.md",
+			"git blame --porcelain -- 'README.md'",
+		},
+		{
+			"At a commit",
+			"9a6f5bd5",
+			"pkg/app.go",
+			"git blame --porcelain 9a6f5bd5 -- 'pkg/app.go'",
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, gitCmd.BlameCmdStr(s.ref, s.path))
+		})
+	}
+}
+
+// TestBlameParserParseLine is a function.
+func TestBlameParserParseLine(t *testing.T) {
+	type scenario struct {
+		testName string
+		output   string
+		test     func([]*models.BlameLine, []string)
+	}
+
+	scenarios := []scenario{
+		{
+			"Lines from two commits",
+			`9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae 1 1 2
+author Jesse Duffield
+author-mail <jesse@example.com>
+author-time 1600000000
+author-tz +1000
+committer Jesse Duffield
+committer-mail <jesse@example.com>
+committer-time 1600000000
+committer-tz +1000
+summary add some stuff
+previous 2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f old.go
+filename new.go
+	package main
+9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae 2 2
+filename new.go
+	
+2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f 1 3 1
+author Someone Else
+author-mail <someone@example.com>
+author-time 1500000000
+author-tz +0000
+committer Someone Else
+committer-mail <someone@example.com>
+committer-time 1500000000
+committer-tz +0000
+summary initial commit
+boundary
+filename old.go
+	func main() {}`,
+			func(lines []*models.BlameLine, unparsed []string) {
+				assert.Len(t, unparsed, 0)
+				assert.Len(t, lines, 3)
+
+				newCommit := &models.BlameCommit{
+					Sha:          "9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae",
+					Author:       "Jesse Duffield",
+					AuthorTime:   1600000000,
+					Summary:      "add some stuff",
+					PreviousSha:  "2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f",
+					PreviousPath: "old.go",
+				}
+				oldCommit := &models.BlameCommit{
+					Sha:        "2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f",
+					Author:     "Someone Else",
+					AuthorTime: 1500000000,
+					Summary:    "initial commit",
+					Boundary:   true,
+				}
+
+				assert.EqualValues(t, &models.BlameLine{Commit: newCommit, LineNumber: 1, OriginalLineNumber: 1, Content: "package main"}, lines[0])
+				assert.EqualValues(t, &models.BlameLine{Commit: newCommit, LineNumber: 2, OriginalLineNumber: 2, Content: ""}, lines[1])
+				assert.EqualValues(t, &models.BlameLine{Commit: oldCommit, LineNumber: 3, OriginalLineNumber: 1, Content: "func main() {}"}, lines[2])
+
+				// lines from the same commit should share it
+				assert.True(t, lines[0].Commit == lines[1].Commit)
+			},
+		},
+		{
+			"Error from git",
+			"fatal: no such path 'nope.go' in HEAD",
+			func(lines []*models.BlameLine, unparsed []string) {
+				assert.Len(t, lines, 0)
+				assert.EqualValues(t, []string{"fatal: no such path 'nope.go' in HEAD"}, unparsed)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			parser := NewBlameParser()
+			lines := []*models.BlameLine{}
+			unparsed := []string{}
+			for _, line := range utils.SplitLines(s.output) {
+				blameLine, ok := parser.ParseLine(line)
+				if !ok {
+					unparsed = append(unparsed, line)
+					continue
+				}
+				if blameLine != nil {
+					lines = append(lines, blameLine)
+				}
+			}
+			s.test(lines, unparsed)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package commands
 import (
 	"fmt"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/go-errors/errors"
@@ -18,9 +17,13 @@ func (c *GitCommand) CatFile(fileName string) (string, error) {
 
 // StageFile stages a file
 func (c *GitCommand) StageFile(fileName string) error {
-	// renamed files look like "file1 -> file2"
-	fileNames := strings.Split(fileName, " -> ")
-	return c.OSCommand.RunCommand("git add %s", c.OSCommand.Quote(fileNames[len(fileNames)-1]))
+	return c.OSCommand.RunCommand("git add %s", c.OSCommand.Quote(fileName))
+}
+
+// AddIntentToAdd records that an untracked file will be added, without staging
+// its content, so that we can then stage its lines one at a time
+func (c *GitCommand) AddIntentToAdd(fileName string) error {
+	return c.OSCommand.RunCommand("git add --intent-to-add -- %s", c.OSCommand.Quote(fileName))
 }
 
 // StageAll stages all files
@@ -33,15 +36,14 @@ func (c *GitCommand) UnstageAll() error {
 	return c.OSCommand.RunCommand("git reset")
 }
 
-// UnStageFile unstages a file
-func (c *GitCommand) UnStageFile(fileName string, tracked bool) error {
+// UnStageFile unstages a file, which for a rename means both the before and
+// after paths
+func (c *GitCommand) UnStageFile(fileNames []string, tracked bool) error {
 	command := "git rm --cached %s"
 	if tracked {
 		command = "git reset HEAD %s"
 	}
 
-	// renamed files look like "file1 -> file2"
-	fileNames := strings.Split(fileName, " -> ")
 	for _, name := range fileNames {
 		if err := c.OSCommand.RunCommand(command, c.OSCommand.Quote(name)); err != nil {
 			return err
@@ -61,15 +63,14 @@ func (c *GitCommand) BeforeAndAfterFileForRename(file *models.File) (*models.Fil
 	// all files, passing the --no-renames flag and then recursively call the function
 	// again for the before file and after file. At some point we should fix the abstraction itself
 
-	split := strings.Split(file.Name, " -> ")
 	filesWithoutRenames := c.GetStatusFiles(GetStatusFileOptions{NoRenames: true})
 	var beforeFile *models.File
 	var afterFile *models.File
 	for _, f := range filesWithoutRenames {
-		if f.Name == split[0] {
+		if f.Name == file.PreviousName {
 			beforeFile = f
 		}
-		if f.Name == split[1] {
+		if f.Name == file.Name {
 			afterFile = f
 		}
 	}
@@ -125,6 +126,78 @@ func (c *GitCommand) DiscardUnstagedFileChanges(file *models.File) error {
 	return c.OSCommand.RunCommand("git checkout -- %s", quotedFileName)
 }
 
+// UnstageDirectory unstages every file in a directory
+func (c *GitCommand) UnstageDirectory(dirPath string) error {
+	return c.OSCommand.RunCommand("git reset HEAD -- %s", c.OSCommand.Quote(dirPath))
+}
+
+// DiscardAllDirChanges discards all changes to the given files of a directory,
+// deleting any untracked ones, in one go rather than file by file
+func (c *GitCommand) DiscardAllDirChanges(dirPath string, files []*models.File) error {
+	quotedDirPath := c.OSCommand.Quote(dirPath)
+
+	anyStaged := false
+	anyInHead := false
+	anyNew := false
+	for _, file := range files {
+		if file.HasStagedChanges || file.HasMergeConflicts {
+			anyStaged = true
+		}
+		// files we've only just added count as untracked
+		if file.Tracked {
+			anyInHead = true
+		} else {
+			anyNew = true
+		}
+	}
+
+	if anyStaged {
+		if err := c.OSCommand.RunCommand("git reset -- %s", quotedDirPath); err != nil {
+			return err
+		}
+	}
+
+	if anyInHead {
+		if err := c.OSCommand.RunCommand("git checkout -- %s", quotedDirPath); err != nil {
+			return err
+		}
+	}
+
+	if anyNew {
+		return c.OSCommand.RunCommand("git clean -fd -- %s", quotedDirPath)
+	}
+
+	return nil
+}
+
+// DiscardUnstagedDirChanges discards the unstaged changes to the given files of
+// a directory, deleting any untracked ones
+func (c *GitCommand) DiscardUnstagedDirChanges(dirPath string, files []*models.File) error {
+	quotedDirPath := c.OSCommand.Quote(dirPath)
+
+	anyInIndex := false
+	anyUntracked := false
+	for _, file := range files {
+		if file.Tracked || file.HasStagedChanges {
+			anyInIndex = true
+		} else {
+			anyUntracked = true
+		}
+	}
+
+	if anyUntracked {
+		if err := c.OSCommand.RunCommand("git clean -fd -- %s", quotedDirPath); err != nil {
+			return err
+		}
+	}
+
+	if anyInIndex {
+		return c.OSCommand.RunCommand("git checkout -- %s", quotedDirPath)
+	}
+
+	return nil
+}
+
 // Ignore adds a file to the gitignore for the repo
 func (c *GitCommand) Ignore(filename string) error {
 	return c.OSCommand.AppendLineToFile(".gitignore", filename)
@@ -138,22 +211,43 @@ func (c *GitCommand) WorktreeFileDiff(file *models.File, plain bool, cached bool
 }
 
 func (c *GitCommand) WorktreeFileDiffCmdStr(file *models.File, plain bool, cached bool) string {
+	colorArg := c.colorArg()
+	if plain {
+		colorArg = "never"
+	}
+
+	return fmt.Sprintf("git diff --submodule --no-ext-diff --color=%s %s", colorArg, c.worktreeFileDiffArgs(file, cached))
+}
+
+// worktreeFileDiffArgs returns the arguments for diffing a file, which for an
+// untracked file means diffing it against /dev/null
+func (c *GitCommand) worktreeFileDiffArgs(file *models.File, cached bool) string {
 	cachedArg := ""
 	trackedArg := "--"
-	colorArg := c.colorArg()
-	split := strings.Split(file.Name, " -> ") // in case of a renamed file we get the new filename
-	fileName := c.OSCommand.Quote(split[len(split)-1])
+	fileName := c.OSCommand.Quote(file.Name)
 	if cached {
 		cachedArg = "--cached"
 	}
 	if !file.Tracked && !file.HasStagedChanges && !cached {
 		trackedArg = "--no-index /dev/null"
 	}
+
+	return fmt.Sprintf("%s %s %s", cachedArg, trackedArg, fileName)
+}
+
+// WorktreeDirDiffCmdStr returns the command for the diff of the tracked files
+// in a directory
+func (c *GitCommand) WorktreeDirDiffCmdStr(dirPath string, plain bool, cached bool) string {
+	cachedArg := ""
+	colorArg := c.colorArg()
+	if cached {
+		cachedArg = "--cached"
+	}
 	if plain {
 		colorArg = "never"
 	}
 
-	return fmt.Sprintf("git diff --submodule --no-ext-diff --color=%s %s %s %s", colorArg, cachedArg, trackedArg, fileName)
+	return fmt.Sprintf("git diff --submodule --no-ext-diff --color=%s %s -- %s", colorArg, cachedArg, c.OSCommand.Quote(dirPath))
 }
 
 func (c *GitCommand) ApplyPatch(patch string, flags ...string) error {
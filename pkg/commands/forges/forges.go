@@ -0,0 +1,184 @@
+package forges
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// Provider talks to a forge's API about the pull requests on a repo
+type Provider interface {
+	// DefaultBranch is the branch that pull requests target unless told otherwise
+	DefaultBranch() (string, error)
+	// ListPullRequests returns the repo's open pull requests, without their statuses
+	ListPullRequests() ([]*models.PullRequest, error)
+	// FetchStatus sets the CI and review status of the given pull request
+	FetchStatus(pr *models.PullRequest) error
+	CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error)
+}
+
+type CreatePullRequestOpts struct {
+	Title string
+	Body  string
+	Head  string // the branch with the changes
+	Base  string // the branch the changes are to be merged into
+}
+
+// Config tells us which forge to talk to and how
+type Config struct {
+	Provider string // one of "github", "gitlab" or "gitea"
+	Domain   string // e.g. github.com
+	APIURL   string // if blank we use the forge's usual API url for the domain
+	Token    string
+	Owner    string
+	Repo     string
+}
+
+// NewProvider returns the provider for the given config. The http client is
+// passed in so that tests can point it somewhere else
+func NewProvider(config Config, httpClient *http.Client) (Provider, error) {
+	switch config.Provider {
+	case "github":
+		return newGithub(config, httpClient), nil
+	case "gitlab":
+		return newGitlab(config, httpClient), nil
+	case "gitea":
+		return newGitea(config, httpClient), nil
+	default:
+		return nil, errors.Errorf("unsupported forge: '%s'", config.Provider)
+	}
+}
+
+// NewHTTPClient returns a client suitable for talking to a forge's API
+func NewHTTPClient() *http.Client {
+	return &http.Client{Timeout: 15 * time.Second}
+}
+
+// ProviderForDomain guesses the forge from the domain of the repo's remote url
+func ProviderForDomain(domain string) string {
+	for _, provider := range []string{"github", "gitlab", "gitea"} {
+		if strings.Contains(domain, provider) {
+			return provider
+		}
+	}
+	return ""
+}
+
+// MAX_PULL_REQUEST_PAGES stops us making too many requests for a repo with a
+// huge number of open pull requests, given we list them every minute
+const MAX_PULL_REQUEST_PAGES = 10
+
+// getPages gets each page of a list in turn, starting from page 1, until it
+// gets a page that isn't full. getPage returns how many items were on the page
+func getPages(pageSize int, getPage func(page int) (int, error)) error {
+	for page := 1; page <= MAX_PULL_REQUEST_PAGES; page++ {
+		count, err := getPage(page)
+		if err != nil {
+			return err
+		}
+		if count < pageSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// apiClient does the JSON-over-HTTP part of talking to a forge
+type apiClient struct {
+	baseURL    string
+	httpClient *http.Client
+	// authorize adds the token to the request in whatever way the forge expects
+	authorize func(*http.Request)
+}
+
+func (c *apiClient) get(path string, result interface{}) error {
+	return c.do("GET", path, nil, result)
+}
+
+func (c *apiClient) post(path string, body interface{}, result interface{}) error {
+	return c.do("POST", path, body, result)
+}
+
+func (c *apiClient) do(method string, path string, body interface{}, result interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.baseURL, "/")+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("%s %s: %s%s", method, path, resp.Status, errorMessage(content))
+	}
+
+	if result == nil {
+		return nil
+	}
+	return json.Unmarshal(content, result)
+}
+
+// errorMessage pulls the message out of an error response, which all the
+// forges we support put in a 'message' field
+func errorMessage(content []byte) string {
+	var response struct {
+		Message interface{} `json:"message"`
+	}
+	if err := json.Unmarshal(content, &response); err != nil || response.Message == nil {
+		return ""
+	}
+	return ": " + strings.TrimSpace(fmtMessage(response.Message))
+}
+
+// GitLab sometimes gives us a list of messages rather than a single one
+func fmtMessage(message interface{}) string {
+	switch message := message.(type) {
+	case string:
+		return message
+	case []interface{}:
+		parts := make([]string, len(message))
+		for i, part := range message {
+			parts[i] = fmtMessage(part)
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		parts := []string{}
+		for key, value := range message {
+			parts = append(parts, key+" "+fmtMessage(value))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, ", ")
+	default:
+		return ""
+	}
+}
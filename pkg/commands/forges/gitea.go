@@ -0,0 +1,31 @@
+package forges
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Gitea's API follows GitHub's closely enough that we can mostly reuse what we
+// do for GitHub
+func newGitea(config Config, httpClient *http.Client) *githubCompatible {
+	baseURL := config.APIURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/api/v1", config.Domain)
+	}
+
+	return &githubCompatible{
+		client: &apiClient{
+			baseURL:    baseURL,
+			httpClient: httpClient,
+			authorize: func(req *http.Request) {
+				req.Header.Set("Authorization", "token "+config.Token)
+			},
+		},
+		owner:                 config.Owner,
+		repo:                  config.Repo,
+		listQuery:             "state=open&limit=50",
+		pageSize:              50,
+		approvedState:         "APPROVED",
+		changesRequestedState: "REQUEST_CHANGES",
+	}
+}
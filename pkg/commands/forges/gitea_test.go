@@ -0,0 +1,50 @@
+package forges
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGiteaPullRequests is a function.
+func TestGiteaPullRequests(t *testing.T) {
+	server := newTestServer(t, "Authorization", "token secret", map[string]string{
+		"GET /repos/alice/tool/pulls?state=open&limit=50&page=1": `[
+			{
+				"number": 3,
+				"title": "Docs",
+				"html_url": "https://gitea.example.com/alice/tool/pulls/3",
+				"user": {"login": "bob"},
+				"head": {"ref": "docs", "sha": "0a1b2c", "repo": {"full_name": "alice/tool"}},
+				"base": {"ref": "main", "repo": {"full_name": "alice/tool"}}
+			}
+		]`,
+		"GET /repos/alice/tool/commits/0a1b2c/status": `{"state": "success", "total_count": 1}`,
+		"GET /repos/alice/tool/pulls/3/reviews":       `[{"state": "REQUEST_CHANGES", "user": {"login": "carol"}}]`,
+	})
+	defer server.Close()
+
+	provider, err := NewProvider(Config{Provider: "gitea", APIURL: server.URL, Token: "secret", Owner: "alice", Repo: "tool"}, server.Client())
+	assert.NoError(t, err)
+
+	pullRequests, err := provider.ListPullRequests()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*models.PullRequest{
+		{
+			Number:     3,
+			Title:      "Docs",
+			Author:     "bob",
+			URL:        "https://gitea.example.com/alice/tool/pulls/3",
+			HeadBranch: "docs",
+			HeadSha:    "0a1b2c",
+			BaseBranch: "main",
+			FetchRef:   "refs/pull/3/head",
+		},
+	}, pullRequests)
+
+	pr := pullRequests[0]
+	assert.NoError(t, provider.FetchStatus(pr))
+	assert.EqualValues(t, "success", pr.CIStatus)
+	assert.EqualValues(t, "changes_requested", pr.ReviewStatus)
+}
@@ -0,0 +1,210 @@
+package forges
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// githubCompatible talks to GitHub, or to anything whose API follows GitHub's
+// closely enough, like Gitea
+type githubCompatible struct {
+	client *apiClient
+	owner  string
+	repo   string
+	// the query string for listing open pull requests, which differs in how
+	// it asks for the page size
+	listQuery string
+	pageSize  int
+	// the review states that tell us a reviewer approved or wants changes
+	approvedState         string
+	changesRequestedState string
+}
+
+func newGithub(config Config, httpClient *http.Client) *githubCompatible {
+	baseURL := config.APIURL
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+		if config.Domain != "" && config.Domain != "github.com" {
+			// GitHub Enterprise
+			baseURL = fmt.Sprintf("https://%s/api/v3", config.Domain)
+		}
+	}
+
+	return &githubCompatible{
+		client: &apiClient{
+			baseURL:    baseURL,
+			httpClient: httpClient,
+			authorize: func(req *http.Request) {
+				req.Header.Set("Authorization", "token "+config.Token)
+				req.Header.Set("Accept", "application/vnd.github.v3+json")
+			},
+		},
+		owner:                 config.Owner,
+		repo:                  config.Repo,
+		listQuery:             "state=open&per_page=100",
+		pageSize:              100,
+		approvedState:         "APPROVED",
+		changesRequestedState: "CHANGES_REQUESTED",
+	}
+}
+
+type githubPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Head struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+		// Repo is null if the fork's been deleted
+		Repo *githubRepo `json:"repo"`
+	} `json:"head"`
+	Base struct {
+		Ref  string      `json:"ref"`
+		Repo *githubRepo `json:"repo"`
+	} `json:"base"`
+}
+
+type githubRepo struct {
+	FullName string `json:"full_name"`
+}
+
+func (pr *githubPullRequest) fromFork() bool {
+	if pr.Head.Repo == nil || pr.Base.Repo == nil {
+		return pr.Head.Repo == nil
+	}
+	return pr.Head.Repo.FullName != pr.Base.Repo.FullName
+}
+
+func (pr *githubPullRequest) toModel() *models.PullRequest {
+	return &models.PullRequest{
+		Number:     pr.Number,
+		Title:      pr.Title,
+		Author:     pr.User.Login,
+		URL:        pr.HTMLURL,
+		HeadBranch: pr.Head.Ref,
+		HeadSha:    pr.Head.Sha,
+		FromFork:   pr.fromFork(),
+		BaseBranch: pr.Base.Ref,
+		FetchRef:   fmt.Sprintf("refs/pull/%d/head", pr.Number),
+	}
+}
+
+func (g *githubCompatible) repoPath() string {
+	return fmt.Sprintf("/repos/%s/%s", g.owner, g.repo)
+}
+
+func (g *githubCompatible) DefaultBranch() (string, error) {
+	var repo struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.client.get(g.repoPath(), &repo); err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
+
+func (g *githubCompatible) ListPullRequests() ([]*models.PullRequest, error) {
+	pullRequests := []*models.PullRequest{}
+	err := getPages(g.pageSize, func(page int) (int, error) {
+		var response []*githubPullRequest
+		if err := g.client.get(fmt.Sprintf("%s/pulls?%s&page=%d", g.repoPath(), g.listQuery, page), &response); err != nil {
+			return 0, err
+		}
+		for _, pr := range response {
+			pullRequests = append(pullRequests, pr.toModel())
+		}
+		return len(response), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+func (g *githubCompatible) FetchStatus(pr *models.PullRequest) error {
+	var status struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	if err := g.client.get(fmt.Sprintf("%s/commits/%s/status", g.repoPath(), pr.HeadSha), &status); err != nil {
+		return err
+	}
+	pr.CIStatus = ""
+	// with no statuses at all GitHub says the state is pending
+	if status.TotalCount > 0 {
+		pr.CIStatus = ciStatus(status.State)
+	}
+
+	var reviews []struct {
+		State string `json:"state"`
+		User  struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	}
+	if err := g.client.get(fmt.Sprintf("%s/pulls/%d/reviews", g.repoPath(), pr.Number), &reviews); err != nil {
+		return err
+	}
+
+	// reviews come oldest first, and it's each reviewer's latest verdict that counts
+	verdicts := map[string]string{}
+	for _, review := range reviews {
+		switch review.State {
+		case g.approvedState:
+			verdicts[review.User.Login] = "approved"
+		case g.changesRequestedState:
+			verdicts[review.User.Login] = "changes_requested"
+		case "DISMISSED":
+			delete(verdicts, review.User.Login)
+		}
+	}
+	pr.ReviewStatus = reviewStatus(verdicts)
+
+	return nil
+}
+
+func (g *githubCompatible) CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error) {
+	body := map[string]string{
+		"title": opts.Title,
+		"body":  opts.Body,
+		"head":  opts.Head,
+		"base":  opts.Base,
+	}
+
+	var response githubPullRequest
+	if err := g.client.post(g.repoPath()+"/pulls", body, &response); err != nil {
+		return nil, err
+	}
+	return response.toModel(), nil
+}
+
+// ciStatus boils the various states of a commit's combined status down to the ones we show
+func ciStatus(state string) string {
+	switch state {
+	case "success":
+		return "success"
+	case "failure", "error":
+		return "failure"
+	case "":
+		return ""
+	default:
+		return "pending"
+	}
+}
+
+// reviewStatus takes each reviewer's verdict and tells us where the pull
+// request stands. A single request for changes outweighs any approvals
+func reviewStatus(verdicts map[string]string) string {
+	status := ""
+	for _, verdict := range verdicts {
+		if verdict == "changes_requested" {
+			return verdict
+		}
+		status = verdict
+	}
+	return status
+}
@@ -0,0 +1,246 @@
+package forges
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer serves the given responses by request path, and checks that
+// every request carries the given auth header
+func newTestServer(t *testing.T, authHeader string, authValue string, responses map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.EqualValues(t, authValue, r.Header.Get(authHeader))
+
+		response, ok := responses[r.Method+" "+r.URL.RequestURI()]
+		if !ok {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.RequestURI())
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method == "POST" {
+			body, _ := ioutil.ReadAll(r.Body)
+			var decoded map[string]string
+			assert.NoError(t, json.Unmarshal(body, &decoded))
+			response = fmt.Sprintf(response, decoded["title"])
+		}
+
+		fmt.Fprint(w, response)
+	}))
+}
+
+// TestGithubListPullRequests is a function.
+func TestGithubListPullRequests(t *testing.T) {
+	server := newTestServer(t, "Authorization", "token secret", map[string]string{
+		"GET /repos/jesseduffield/lazygit/pulls?state=open&per_page=100&page=1": `[
+			{
+				"number": 12,
+				"title": "Add graph",
+				"html_url": "https://github.com/jesseduffield/lazygit/pull/12",
+				"user": {"login": "mark"},
+				"head": {"ref": "graph", "sha": "abc123", "repo": {"full_name": "jesseduffield/lazygit"}},
+				"base": {"ref": "master", "repo": {"full_name": "jesseduffield/lazygit"}}
+			},
+			{
+				"number": 14,
+				"title": "Fix typo",
+				"html_url": "https://github.com/jesseduffield/lazygit/pull/14",
+				"user": {"login": "jo"},
+				"head": {"ref": "master", "sha": "bcd234", "repo": {"full_name": "jo/lazygit"}},
+				"base": {"ref": "master", "repo": {"full_name": "jesseduffield/lazygit"}}
+			},
+			{
+				"number": 15,
+				"title": "From a deleted fork",
+				"html_url": "https://github.com/jesseduffield/lazygit/pull/15",
+				"user": {"login": "al"},
+				"head": {"ref": "patch-1", "sha": "cde345", "repo": null},
+				"base": {"ref": "master", "repo": {"full_name": "jesseduffield/lazygit"}}
+			}
+		]`,
+	})
+	defer server.Close()
+
+	provider, err := NewProvider(Config{Provider: "github", APIURL: server.URL, Token: "secret", Owner: "jesseduffield", Repo: "lazygit"}, server.Client())
+	assert.NoError(t, err)
+
+	pullRequests, err := provider.ListPullRequests()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*models.PullRequest{
+		{
+			Number:     12,
+			Title:      "Add graph",
+			Author:     "mark",
+			URL:        "https://github.com/jesseduffield/lazygit/pull/12",
+			HeadBranch: "graph",
+			HeadSha:    "abc123",
+			BaseBranch: "master",
+			FetchRef:   "refs/pull/12/head",
+		},
+		{
+			Number:     14,
+			Title:      "Fix typo",
+			Author:     "jo",
+			URL:        "https://github.com/jesseduffield/lazygit/pull/14",
+			HeadBranch: "master",
+			HeadSha:    "bcd234",
+			FromFork:   true,
+			BaseBranch: "master",
+			FetchRef:   "refs/pull/14/head",
+		},
+		{
+			Number:     15,
+			Title:      "From a deleted fork",
+			Author:     "al",
+			URL:        "https://github.com/jesseduffield/lazygit/pull/15",
+			HeadBranch: "patch-1",
+			HeadSha:    "cde345",
+			FromFork:   true,
+			BaseBranch: "master",
+			FetchRef:   "refs/pull/15/head",
+		},
+	}, pullRequests)
+	assert.EqualValues(t, "graph", pullRequests[0].LocalBranchName())
+	assert.EqualValues(t, "pr/14", pullRequests[1].LocalBranchName())
+}
+
+// TestGithubListPullRequestsPages is a function.
+func TestGithubListPullRequestsPages(t *testing.T) {
+	page := func(from int, count int) string {
+		pullRequests := make([]string, count)
+		for i := range pullRequests {
+			pullRequests[i] = fmt.Sprintf(`{"number": %d}`, from+i)
+		}
+		return "[" + strings.Join(pullRequests, ",") + "]"
+	}
+
+	server := newTestServer(t, "Authorization", "token secret", map[string]string{
+		"GET /repos/jesseduffield/lazygit/pulls?state=open&per_page=100&page=1": page(1, 100),
+		"GET /repos/jesseduffield/lazygit/pulls?state=open&per_page=100&page=2": page(101, 3),
+	})
+	defer server.Close()
+
+	provider, err := NewProvider(Config{Provider: "github", APIURL: server.URL, Token: "secret", Owner: "jesseduffield", Repo: "lazygit"}, server.Client())
+	assert.NoError(t, err)
+
+	pullRequests, err := provider.ListPullRequests()
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 103)
+	assert.EqualValues(t, 103, pullRequests[102].Number)
+}
+
+// TestGithubFetchStatus is a function.
+func TestGithubFetchStatus(t *testing.T) {
+	type scenario struct {
+		testName             string
+		status               string
+		reviews              string
+		expectedCIStatus     string
+		expectedReviewStatus string
+	}
+
+	scenarios := []scenario{
+		{
+			"No statuses or reviews",
+			`{"state": "pending", "total_count": 0}`,
+			`[]`,
+			"",
+			"",
+		},
+		{
+			"Passing CI and an approval",
+			`{"state": "success", "total_count": 2}`,
+			`[{"state": "COMMENTED", "user": {"login": "a"}}, {"state": "APPROVED", "user": {"login": "b"}}]`,
+			"success",
+			"approved",
+		},
+		{
+			"Failing CI and one reviewer still wanting changes",
+			`{"state": "error", "total_count": 1}`,
+			`[{"state": "APPROVED", "user": {"login": "a"}}, {"state": "CHANGES_REQUESTED", "user": {"login": "b"}}]`,
+			"failure",
+			"changes_requested",
+		},
+		{
+			"Reviewer approves after requesting changes",
+			`{"state": "pending", "total_count": 1}`,
+			`[{"state": "CHANGES_REQUESTED", "user": {"login": "a"}}, {"state": "APPROVED", "user": {"login": "a"}}]`,
+			"pending",
+			"approved",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			server := newTestServer(t, "Authorization", "token secret", map[string]string{
+				"GET /repos/jesseduffield/lazygit/commits/abc123/status": s.status,
+				"GET /repos/jesseduffield/lazygit/pulls/12/reviews":      s.reviews,
+			})
+			defer server.Close()
+
+			provider, err := NewProvider(Config{Provider: "github", APIURL: server.URL, Token: "secret", Owner: "jesseduffield", Repo: "lazygit"}, server.Client())
+			assert.NoError(t, err)
+
+			pr := &models.PullRequest{Number: 12, HeadSha: "abc123"}
+			assert.NoError(t, provider.FetchStatus(pr))
+			assert.EqualValues(t, s.expectedCIStatus, pr.CIStatus)
+			assert.EqualValues(t, s.expectedReviewStatus, pr.ReviewStatus)
+		})
+	}
+}
+
+// TestGithubCreatePullRequest is a function.
+func TestGithubCreatePullRequest(t *testing.T) {
+	server := newTestServer(t, "Authorization", "token secret", map[string]string{
+		"GET /repos/jesseduffield/lazygit":        `{"default_branch": "master"}`,
+		"POST /repos/jesseduffield/lazygit/pulls": `{"number": 13, "title": "%s", "head": {"ref": "graph"}, "base": {"ref": "master"}}`,
+	})
+	defer server.Close()
+
+	provider, err := NewProvider(Config{Provider: "github", APIURL: server.URL, Token: "secret", Owner: "jesseduffield", Repo: "lazygit"}, server.Client())
+	assert.NoError(t, err)
+
+	base, err := provider.DefaultBranch()
+	assert.NoError(t, err)
+	assert.EqualValues(t, "master", base)
+
+	pr, err := provider.CreatePullRequest(CreatePullRequestOpts{Title: "Add graph", Body: "body", Head: "graph", Base: base})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 13, pr.Number)
+	assert.EqualValues(t, "Add graph", pr.Title)
+	assert.EqualValues(t, "graph", pr.HeadBranch)
+	assert.EqualValues(t, "master", pr.BaseBranch)
+}
+
+// TestGithubErrorResponse is a function.
+func TestGithubErrorResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		fmt.Fprint(w, `{"message": "Validation Failed"}`)
+	}))
+	defer server.Close()
+
+	provider, err := NewProvider(Config{Provider: "github", APIURL: server.URL, Owner: "jesseduffield", Repo: "lazygit"}, server.Client())
+	assert.NoError(t, err)
+
+	_, err = provider.CreatePullRequest(CreatePullRequestOpts{Title: "Add graph", Head: "graph", Base: "master"})
+	assert.EqualError(t, err, "POST /repos/jesseduffield/lazygit/pulls: 422 Unprocessable Entity: Validation Failed")
+}
+
+// TestNewProvider is a function.
+func TestNewProvider(t *testing.T) {
+	_, err := NewProvider(Config{Provider: "bitbucket"}, NewHTTPClient())
+	assert.EqualError(t, err, "unsupported forge: 'bitbucket'")
+
+	assert.EqualValues(t, "github", ProviderForDomain("github.com"))
+	assert.EqualValues(t, "gitlab", ProviderForDomain("gitlab.example.com"))
+	assert.EqualValues(t, "", ProviderForDomain("example.com"))
+}
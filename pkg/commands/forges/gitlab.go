@@ -0,0 +1,151 @@
+package forges
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+type gitlab struct {
+	client *apiClient
+	// projectID is the url-encoded path of the project e.g. 'jesseduffield%2Flazygit'
+	projectID string
+}
+
+func newGitlab(config Config, httpClient *http.Client) *gitlab {
+	baseURL := config.APIURL
+	if baseURL == "" {
+		baseURL = fmt.Sprintf("https://%s/api/v4", config.Domain)
+	}
+
+	return &gitlab{
+		client: &apiClient{
+			baseURL:    baseURL,
+			httpClient: httpClient,
+			authorize: func(req *http.Request) {
+				req.Header.Set("PRIVATE-TOKEN", config.Token)
+			},
+		},
+		projectID: url.PathEscape(config.Owner + "/" + config.Repo),
+	}
+}
+
+type gitlabMergeRequest struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	WebURL string `json:"web_url"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	// the source project is a fork if it's not the target project
+	SourceProjectID int    `json:"source_project_id"`
+	TargetProjectID int    `json:"target_project_id"`
+	Sha             string `json:"sha"`
+}
+
+func (mr *gitlabMergeRequest) toModel() *models.PullRequest {
+	return &models.PullRequest{
+		Number:     mr.IID,
+		Title:      mr.Title,
+		Author:     mr.Author.Username,
+		URL:        mr.WebURL,
+		HeadBranch: mr.SourceBranch,
+		HeadSha:    mr.Sha,
+		FromFork:   mr.SourceProjectID != mr.TargetProjectID,
+		BaseBranch: mr.TargetBranch,
+		FetchRef:   fmt.Sprintf("refs/merge-requests/%d/head", mr.IID),
+	}
+}
+
+func (g *gitlab) projectPath() string {
+	return "/projects/" + g.projectID
+}
+
+func (g *gitlab) DefaultBranch() (string, error) {
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := g.client.get(g.projectPath(), &project); err != nil {
+		return "", err
+	}
+	return project.DefaultBranch, nil
+}
+
+func (g *gitlab) ListPullRequests() ([]*models.PullRequest, error) {
+	pageSize := 100
+	pullRequests := []*models.PullRequest{}
+	err := getPages(pageSize, func(page int) (int, error) {
+		var response []*gitlabMergeRequest
+		if err := g.client.get(fmt.Sprintf("%s/merge_requests?state=opened&per_page=%d&page=%d", g.projectPath(), pageSize, page), &response); err != nil {
+			return 0, err
+		}
+		for _, mr := range response {
+			pullRequests = append(pullRequests, mr.toModel())
+		}
+		return len(response), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+func (g *gitlab) FetchStatus(pr *models.PullRequest) error {
+	// pipelines come newest first
+	var pipelines []struct {
+		Status string `json:"status"`
+	}
+	if err := g.client.get(fmt.Sprintf("%s/merge_requests/%d/pipelines", g.projectPath(), pr.Number), &pipelines); err != nil {
+		return err
+	}
+	pr.CIStatus = ""
+	if len(pipelines) > 0 {
+		pr.CIStatus = gitlabCIStatus(pipelines[0].Status)
+	}
+
+	// GitLab has no notion of requesting changes, so approval is all we can go on
+	var approvals struct {
+		ApprovedBy []interface{} `json:"approved_by"`
+	}
+	if err := g.client.get(fmt.Sprintf("%s/merge_requests/%d/approvals", g.projectPath(), pr.Number), &approvals); err != nil {
+		return err
+	}
+	pr.ReviewStatus = ""
+	if len(approvals.ApprovedBy) > 0 {
+		pr.ReviewStatus = "approved"
+	}
+
+	return nil
+}
+
+func (g *gitlab) CreatePullRequest(opts CreatePullRequestOpts) (*models.PullRequest, error) {
+	body := map[string]string{
+		"title":         opts.Title,
+		"description":   opts.Body,
+		"source_branch": opts.Head,
+		"target_branch": opts.Base,
+	}
+
+	var response gitlabMergeRequest
+	if err := g.client.post(g.projectPath()+"/merge_requests", body, &response); err != nil {
+		return nil, err
+	}
+	return response.toModel(), nil
+}
+
+func gitlabCIStatus(status string) string {
+	switch status {
+	case "success":
+		return "success"
+	case "failed", "canceled":
+		return "failure"
+	case "skipped", "manual":
+		return ""
+	default:
+		return "pending"
+	}
+}
@@ -0,0 +1,84 @@
+package forges
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitlabPullRequests is a function.
+func TestGitlabPullRequests(t *testing.T) {
+	server := newTestServer(t, "PRIVATE-TOKEN", "secret", map[string]string{
+		"GET /projects/group%2Fsubgroup%2Fproject/merge_requests?state=opened&per_page=100&page=1": `[
+			{
+				"iid": 7,
+				"title": "Fix thing",
+				"web_url": "https://gitlab.com/group/subgroup/project/-/merge_requests/7",
+				"author": {"username": "sam"},
+				"source_branch": "fix",
+				"target_branch": "main",
+				"sha": "def456",
+				"source_project_id": 1,
+				"target_project_id": 1
+			},
+			{
+				"iid": 9,
+				"title": "Fork fix",
+				"web_url": "https://gitlab.com/group/subgroup/project/-/merge_requests/9",
+				"author": {"username": "pat"},
+				"source_branch": "main",
+				"target_branch": "main",
+				"sha": "fed987",
+				"source_project_id": 2,
+				"target_project_id": 1
+			}
+		]`,
+		"GET /projects/group%2Fsubgroup%2Fproject/merge_requests/7/pipelines": `[{"status": "failed"}, {"status": "success"}]`,
+		"GET /projects/group%2Fsubgroup%2Fproject/merge_requests/7/approvals": `{"approved_by": [{"user": {"username": "kim"}}]}`,
+		"POST /projects/group%2Fsubgroup%2Fproject/merge_requests":            `{"iid": 8, "title": "%s", "source_branch": "feature", "target_branch": "main"}`,
+	})
+	defer server.Close()
+
+	provider, err := NewProvider(Config{Provider: "gitlab", APIURL: server.URL, Token: "secret", Owner: "group/subgroup", Repo: "project"}, server.Client())
+	assert.NoError(t, err)
+
+	pullRequests, err := provider.ListPullRequests()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []*models.PullRequest{
+		{
+			Number:     7,
+			Title:      "Fix thing",
+			Author:     "sam",
+			URL:        "https://gitlab.com/group/subgroup/project/-/merge_requests/7",
+			HeadBranch: "fix",
+			HeadSha:    "def456",
+			BaseBranch: "main",
+			FetchRef:   "refs/merge-requests/7/head",
+		},
+		{
+			Number:     9,
+			Title:      "Fork fix",
+			Author:     "pat",
+			URL:        "https://gitlab.com/group/subgroup/project/-/merge_requests/9",
+			HeadBranch: "main",
+			HeadSha:    "fed987",
+			FromFork:   true,
+			BaseBranch: "main",
+			FetchRef:   "refs/merge-requests/9/head",
+		},
+	}, pullRequests)
+	assert.EqualValues(t, "fix", pullRequests[0].LocalBranchName())
+	assert.EqualValues(t, "pr/9", pullRequests[1].LocalBranchName())
+
+	pr := pullRequests[0]
+	assert.NoError(t, provider.FetchStatus(pr))
+	assert.EqualValues(t, "failure", pr.CIStatus)
+	assert.EqualValues(t, "approved", pr.ReviewStatus)
+
+	created, err := provider.CreatePullRequest(CreatePullRequestOpts{Title: "New feature", Head: "feature", Base: "main"})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 8, created.Number)
+	assert.EqualValues(t, "New feature", created.Title)
+	assert.EqualValues(t, "feature", created.HeadBranch)
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// FormatPatchOptions are the options for turning commits into a patch series,
+// as you'd send to a mailing list
+type FormatPatchOptions struct {
+	CoverLetter bool
+	// SubjectPrefix replaces 'PATCH' in the subject of each patch, e.g. 'PATCH
+	// v2' or 'RFC PATCH'. If empty, git's format.subjectPrefix config is used
+	SubjectPrefix string
+	// RerollCount marks the series as a new version of a series you've already
+	// sent, e.g. 2 gives 'v2-0001-...' files and '[PATCH v2 1/3]' subjects. 0
+	// means it's the first version
+	RerollCount int
+	// OutputDirectory is where the patch files are written, relative to the repo
+	OutputDirectory string
+}
+
+// FormatPatchCmdStr returns the command to format the given commits, which
+// are expected to be newest first and to follow on from each other, as
+// they're listed in the commits panel
+func (c *GitCommand) FormatPatchCmdStr(commits []*models.Commit, opts FormatPatchOptions, stdout bool) string {
+	args := []string{"git", "format-patch"}
+
+	if stdout {
+		args = append(args, "--stdout")
+	} else if opts.OutputDirectory != "" {
+		args = append(args, "--output-directory", c.OSCommand.Quote(opts.OutputDirectory))
+	}
+	if opts.CoverLetter {
+		args = append(args, "--cover-letter")
+	}
+	if opts.SubjectPrefix != "" {
+		args = append(args, "--subject-prefix", c.OSCommand.Quote(opts.SubjectPrefix))
+	}
+	if opts.RerollCount > 0 {
+		args = append(args, fmt.Sprintf("--reroll-count=%d", opts.RerollCount))
+	}
+
+	newest := commits[0]
+	oldest := commits[len(commits)-1]
+	if len(oldest.Parents) == 0 {
+		// the root commit has no parent to start the range from
+		args = append(args, "--root", newest.Sha)
+	} else {
+		args = append(args, fmt.Sprintf("%s^..%s", oldest.Sha, newest.Sha))
+	}
+
+	return strings.Join(args, " ")
+}
+
+// FormatPatch writes a numbered patch file for each commit, returning the
+// paths of the files
+func (c *GitCommand) FormatPatch(commits []*models.Commit, opts FormatPatchOptions) ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput(c.FormatPatchCmdStr(commits, opts, false))
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths, nil
+}
+
+// FormatPatchToString returns the patches for the commits concatenated into one
+// mailbox
+func (c *GitCommand) FormatPatchToString(commits []*models.Commit, opts FormatPatchOptions) (string, error) {
+	return c.OSCommand.RunCommandWithOutput(c.FormatPatchCmdStr(commits, opts, true))
+}
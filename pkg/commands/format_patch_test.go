@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandFormatPatchCmdStr is a function.
+func TestGitCommandFormatPatchCmdStr(t *testing.T) {
+	commits := []*models.Commit{
+		{Sha: "c3", Parents: []string{"c2"}},
+		{Sha: "c2", Parents: []string{"c1"}},
+	}
+	rootCommits := []*models.Commit{
+		{Sha: "c1"},
+	}
+
+	type scenario struct {
+		testName string
+		commits  []*models.Commit
+		opts     FormatPatchOptions
+		stdout   bool
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"default options",
+			commits,
+			FormatPatchOptions{},
+			false,
+			"git format-patch c2^..c3",
+		},
+		{
+			"all options",
+			commits,
+			FormatPatchOptions{
+				CoverLetter:     true,
+				SubjectPrefix:   "RFC PATCH",
+				RerollCount:     2,
+				OutputDirectory: "outgoing",
+			},
+			false,
+			"git format-patch --output-directory 'outgoing' --cover-letter --subject-prefix 'RFC PATCH' --reroll-count=2 c2^..c3",
+		},
+		{
+			"to stdout, ignoring the output directory",
+			commits,
+			FormatPatchOptions{OutputDirectory: "outgoing"},
+			true,
+			"git format-patch --stdout c2^..c3",
+		},
+		{
+			"from the root commit",
+			rootCommits,
+			FormatPatchOptions{},
+			false,
+			"git format-patch --root c1",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			assert.EqualValues(t, s.expected, gitCmd.FormatPatchCmdStr(s.commits, s.opts, s.stdout))
+		})
+	}
+}
@@ -36,7 +36,7 @@ type GitCommand struct {
 	getLocalGitConfig    func(string) (string, error)
 	removeFile           func(string) error
 	DotGitDir            string
-	onSuccessfulContinue func() error
+	mergeState           *mergeState
 	PatchManager         *patch.PatchManager
 
 	// Push to current determines whether the user has configured to push to the remote branch of the same name as the current or not
@@ -84,6 +84,7 @@ func NewGitCommand(log *logrus.Entry, osCommand *oscommands.OSCommand, tr *i18n.
 		removeFile:         os.RemoveAll,
 		DotGitDir:          dotGitDir,
 		PushToCurrent:      pushToCurrent,
+		mergeState:         &mergeState{},
 	}
 
 	gitCommand.PatchManager = patch.NewPatchManager(log, gitCommand.ApplyPatch, gitCommand.ShowFileDiff)
@@ -91,6 +92,27 @@ func NewGitCommand(log *logrus.Entry, osCommand *oscommands.OSCommand, tr *i18n.
 	return gitCommand, nil
 }
 
+// mergeState is what we need to remember in between merge/rebase commands. It's
+// kept behind a pointer so that it's shared with the copies made by WithAction
+type mergeState struct {
+	// sometimes we need to do a sequence of things in a rebase but the user
+	// needs to fix merge conflicts along the way, in which case the next step is
+	// queued up here
+	onSuccessfulContinue func() error
+}
+
+// WithAction returns a copy of the GitCommand which logs the commands it runs
+// in the command log against the given user action e.g. 'squash down'
+func (c *GitCommand) WithAction(name string) *GitCommand {
+	newGitCommand := *c
+	newGitCommand.OSCommand = c.OSCommand.WithAction(name)
+	if c.PatchManager != nil {
+		// otherwise the patches it applies wouldn't be logged against the action
+		newGitCommand.PatchManager = c.PatchManager.WithApplyPatch(newGitCommand.ApplyPatch)
+	}
+	return &newGitCommand
+}
+
 func verifyInGitRepo(runCmd func(string, ...interface{}) error) error {
 	return runCmd("git status")
 }
@@ -1,9 +1,12 @@
 package commands
 
 // Conflict : A git conflict with a start middle and end corresponding to line
-// numbers in the file where the conflict bars appear
+// numbers in the file where the conflict bars appear. With the diff3 and
+// zdiff3 conflict styles there's also a base section, starting at the
+// Ancestor line. Ancestor is zero when there's no base section
 type Conflict struct {
-	Start  int
-	Middle int
-	End    int
+	Start    int
+	Ancestor int
+	Middle   int
+	End      int
 }
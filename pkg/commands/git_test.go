@@ -310,113 +310,222 @@ func TestGitCommandGetStashEntries(t *testing.T) {
 	}
 }
 
-// TestGitCommandGetStatusFiles is a function.
-func TestGitCommandGetStatusFiles(t *testing.T) {
+// TestGitCommandParseStatus is a function.
+func TestGitCommandParseStatus(t *testing.T) {
 	type scenario struct {
-		testName string
-		command  func(string, ...string) *exec.Cmd
-		test     func([]*models.File)
+		testName       string
+		output         string
+		expectedFiles  []*models.File
+		expectedBranch BranchStatus
 	}
 
 	scenarios := []scenario{
 		{
 			"No files found",
-			func(cmd string, args ...string) *exec.Cmd {
-				return exec.Command("echo")
-			},
-			func(files []*models.File) {
-				assert.Len(t, files, 0)
+			"",
+			[]*models.File{},
+			BranchStatus{},
+		},
+		{
+			"Branch with an upstream",
+			"# branch.oid 55c6af2a2fd8fa5a5b1a8b1e1f3bd0e3c4f2d8f2\x00# branch.head master\x00# branch.upstream origin/master\x00# branch.ab +1 -2\x00",
+			[]*models.File{},
+			BranchStatus{
+				Oid:            "55c6af2a2fd8fa5a5b1a8b1e1f3bd0e3c4f2d8f2",
+				Head:           "master",
+				Upstream:       "origin/master",
+				HasAheadBehind: true,
+				Ahead:          1,
+				Behind:         2,
 			},
 		},
+		{
+			"Detached HEAD with no commits",
+			"# branch.oid (initial)\x00# branch.head (detached)\x00",
+			[]*models.File{},
+			BranchStatus{Oid: "(initial)", Head: "(detached)"},
+		},
 		{
 			"Several files found",
-			func(cmd string, args ...string) *exec.Cmd {
-				return exec.Command(
-					"echo",
-					"MM file1.txt\nA  file3.txt\nAM file2.txt\n?? file4.txt\nUU file5.txt",
-				)
+			"1 MM N... 100644 100644 100755 aaaaaaa bbbbbbb file1.txt\x00" +
+				"1 A. N... 000000 100644 100644 0000000 bbbbbbb file3.txt\x00" +
+				"1 AM N... 000000 100644 100644 0000000 bbbbbbb file2.txt\x00" +
+				"1 .D N... 100644 100644 000000 aaaaaaa aaaaaaa file6.txt\x00" +
+				"u UU N... 100644 100644 100644 100644 aaaaaaa bbbbbbb ccccccc file5.txt\x00" +
+				"? file4.txt\x00",
+			[]*models.File{
+				{
+					Name:               "file1.txt",
+					HasStagedChanges:   true,
+					HasUnstagedChanges: true,
+					Tracked:            true,
+					Type:               "other",
+					ShortStatus:        "MM",
+					HeadMode:           "100644",
+					IndexMode:          "100644",
+					WorktreeMode:       "100755",
+				},
+				{
+					Name:             "file3.txt",
+					HasStagedChanges: true,
+					Type:             "other",
+					ShortStatus:      "A ",
+					HeadMode:         "000000",
+					IndexMode:        "100644",
+					WorktreeMode:     "100644",
+				},
+				{
+					Name:               "file2.txt",
+					HasStagedChanges:   true,
+					HasUnstagedChanges: true,
+					Type:               "other",
+					ShortStatus:        "AM",
+					HeadMode:           "000000",
+					IndexMode:          "100644",
+					WorktreeMode:       "100644",
+				},
+				{
+					Name:               "file6.txt",
+					HasUnstagedChanges: true,
+					Tracked:            true,
+					Deleted:            true,
+					Type:               "other",
+					ShortStatus:        " D",
+					HeadMode:           "100644",
+					IndexMode:          "100644",
+					WorktreeMode:       "000000",
+				},
+				{
+					Name:                    "file5.txt",
+					HasUnstagedChanges:      true,
+					Tracked:                 true,
+					HasMergeConflicts:       true,
+					HasInlineMergeConflicts: true,
+					Type:                    "other",
+					ShortStatus:             "UU",
+					HeadMode:                "100644",
+					WorktreeMode:            "100644",
+				},
+				{
+					Name:               "file4.txt",
+					HasUnstagedChanges: true,
+					Type:               "other",
+					ShortStatus:        "??",
+				},
 			},
-			func(files []*models.File) {
-				assert.Len(t, files, 5)
-
-				expected := []*models.File{
-					{
-						Name:                    "file1.txt",
-						HasStagedChanges:        true,
-						HasUnstagedChanges:      true,
-						Tracked:                 true,
-						Deleted:                 false,
-						HasMergeConflicts:       false,
-						HasInlineMergeConflicts: false,
-						DisplayString:           "MM file1.txt",
-						Type:                    "other",
-						ShortStatus:             "MM",
-					},
-					{
-						Name:                    "file3.txt",
-						HasStagedChanges:        true,
-						HasUnstagedChanges:      false,
-						Tracked:                 false,
-						Deleted:                 false,
-						HasMergeConflicts:       false,
-						HasInlineMergeConflicts: false,
-						DisplayString:           "A  file3.txt",
-						Type:                    "other",
-						ShortStatus:             "A ",
-					},
-					{
-						Name:                    "file2.txt",
-						HasStagedChanges:        true,
-						HasUnstagedChanges:      true,
-						Tracked:                 false,
-						Deleted:                 false,
-						HasMergeConflicts:       false,
-						HasInlineMergeConflicts: false,
-						DisplayString:           "AM file2.txt",
-						Type:                    "other",
-						ShortStatus:             "AM",
-					},
-					{
-						Name:                    "file4.txt",
-						HasStagedChanges:        false,
-						HasUnstagedChanges:      true,
-						Tracked:                 false,
-						Deleted:                 false,
-						HasMergeConflicts:       false,
-						HasInlineMergeConflicts: false,
-						DisplayString:           "?? file4.txt",
-						Type:                    "other",
-						ShortStatus:             "??",
-					},
-					{
-						Name:                    "file5.txt",
-						HasStagedChanges:        false,
-						HasUnstagedChanges:      true,
-						Tracked:                 true,
-						Deleted:                 false,
-						HasMergeConflicts:       true,
-						HasInlineMergeConflicts: true,
-						DisplayString:           "UU file5.txt",
-						Type:                    "other",
-						ShortStatus:             "UU",
+			BranchStatus{},
+		},
+		{
+			"Renamed file, with the path it came from as the next entry",
+			"2 R. N... 100644 100644 100644 aaaaaaa aaaaaaa R100 after.txt\x00before.txt\x00",
+			[]*models.File{
+				{
+					Name:             "after.txt",
+					PreviousName:     "before.txt",
+					HasStagedChanges: true,
+					Tracked:          true,
+					Type:             "other",
+					ShortStatus:      "R ",
+					HeadMode:         "100644",
+					IndexMode:        "100644",
+					WorktreeMode:     "100644",
+				},
+			},
+			BranchStatus{},
+		},
+		{
+			"Filenames are taken as they are",
+			"1 .M N... 100644 100644 100644 aaaaaaa aaaaaaa a -> b.txt\x00" +
+				"? new\nline.txt\x00" +
+				"? \"quoted\" file.txt\x00",
+			[]*models.File{
+				{
+					Name:               "a -> b.txt",
+					HasUnstagedChanges: true,
+					Tracked:            true,
+					Type:               "other",
+					ShortStatus:        " M",
+					HeadMode:           "100644",
+					IndexMode:          "100644",
+					WorktreeMode:       "100644",
+				},
+				{
+					Name:               "new\nline.txt",
+					HasUnstagedChanges: true,
+					Type:               "other",
+					ShortStatus:        "??",
+				},
+				{
+					Name:               `"quoted" file.txt`,
+					HasUnstagedChanges: true,
+					Type:               "other",
+					ShortStatus:        "??",
+				},
+			},
+			BranchStatus{},
+		},
+		{
+			"Warnings before the entries are skipped",
+			"warning: could not open directory 'private/': Permission denied\n? file4.txt\x00",
+			[]*models.File{
+				{
+					Name:               "file4.txt",
+					HasUnstagedChanges: true,
+					Type:               "other",
+					ShortStatus:        "??",
+				},
+			},
+			BranchStatus{},
+		},
+		{
+			"Submodule with new commits and untracked content",
+			"1 .M SC.U 160000 160000 160000 aaaaaaa aaaaaaa sub\x00",
+			[]*models.File{
+				{
+					Name:               "sub",
+					HasUnstagedChanges: true,
+					Tracked:            true,
+					Type:               "other",
+					ShortStatus:        " M",
+					HeadMode:           "160000",
+					IndexMode:          "160000",
+					WorktreeMode:       "160000",
+					Submodule: &models.SubmoduleStatus{
+						CommitChanged:       true,
+						HasUntrackedChanges: true,
 					},
-				}
-
-				assert.EqualValues(t, expected, files)
+				},
 			},
+			BranchStatus{},
 		},
 	}
 
 	for _, s := range scenarios {
 		t.Run(s.testName, func(t *testing.T) {
 			gitCmd := NewDummyGitCommand()
-			gitCmd.OSCommand.Command = s.command
 
-			s.test(gitCmd.GetStatusFiles(GetStatusFileOptions{}))
+			status := gitCmd.parseStatus(s.output)
+			assert.EqualValues(t, s.expectedFiles, status.Files)
+			assert.EqualValues(t, s.expectedBranch, status.Branch)
 		})
 	}
 }
 
+// TestGitCommandGetStatusCommand is a function.
+func TestGitCommandGetStatusCommand(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		if args[0] == "config" {
+			return exec.Command("echo")
+		}
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"status", "--untracked-files=all", "--porcelain=v2", "-z", "--branch", "--no-renames"}, args)
+		return exec.Command("echo")
+	}
+
+	gitCmd.GetStatusFiles(GetStatusFileOptions{NoRenames: true})
+}
+
 // TestGitCommandStashDo is a function.
 func TestGitCommandStashDo(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -685,6 +794,100 @@ func TestGitCommandDeleteBranch(t *testing.T) {
 	}
 }
 
+// TestGitCommandRemoveWorktree is a function.
+func TestGitCommandRemoveWorktree(t *testing.T) {
+	type scenario struct {
+		testName string
+		force    bool
+		command  func(string, ...string) *exec.Cmd
+		test     func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Remove a worktree",
+			false,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"worktree", "remove", "../my worktree"}, args)
+
+				return exec.Command("echo")
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Force remove a worktree",
+			true,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"worktree", "remove", "--force", "../my worktree"}, args)
+
+				return exec.Command("echo")
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.RemoveWorktree("../my worktree", s.force))
+		})
+	}
+}
+
+// TestGitCommandSubmoduleUpdate is a function.
+func TestGitCommandSubmoduleUpdate(t *testing.T) {
+	type scenario struct {
+		testName  string
+		recursive bool
+		command   func(string, ...string) *exec.Cmd
+		test      func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Update a submodule",
+			false,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"submodule", "update", "--init", "--", "vendor/my submodule"}, args)
+
+				return exec.Command("echo")
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Update a submodule recursively",
+			true,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"submodule", "update", "--init", "--recursive", "--", "vendor/my submodule"}, args)
+
+				return exec.Command("echo")
+			},
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.SubmoduleUpdate(&models.SubmoduleConfig{Name: "my submodule", Path: "vendor/my submodule"}, s.recursive))
+		})
+	}
+}
+
 // TestGitCommandMerge is a function.
 func TestGitCommandMerge(t *testing.T) {
 	gitCmd := NewDummyGitCommand()
@@ -953,6 +1156,93 @@ func TestGitCommandAmendHead(t *testing.T) {
 	}
 }
 
+// TestGitCommandCreateAnnotatedTag is a function.
+func TestGitCommandCreateAnnotatedTag(t *testing.T) {
+	type scenario struct {
+		testName           string
+		sign               bool
+		command            func(string, ...string) *exec.Cmd
+		getGlobalGitConfig func(string) (string, error)
+		test               func(*exec.Cmd, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Annotated tag",
+			false,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"tag", "--annotate", "v1.0.0", "-m", "release", "abc123"}, args)
+
+				return exec.Command("echo")
+			},
+			func(string) (string, error) {
+				return "", nil
+			},
+			func(cmd *exec.Cmd, err error) {
+				assert.Nil(t, cmd)
+				assert.Nil(t, err)
+			},
+		},
+		{
+			"Signed tag",
+			true,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "bash", cmd)
+				assert.EqualValues(t, []string{"-c", "git tag --sign v1.0.0 -m 'release' abc123"}, args)
+
+				return exec.Command("echo")
+			},
+			func(string) (string, error) {
+				return "", nil
+			},
+			func(cmd *exec.Cmd, err error) {
+				assert.NotNil(t, cmd)
+				assert.Nil(t, err)
+			},
+		},
+		{
+			"Annotated tag with tag.gpgsign enabled",
+			false,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "bash", cmd)
+				assert.EqualValues(t, []string{"-c", "git tag --annotate v1.0.0 -m 'release' abc123"}, args)
+
+				return exec.Command("echo")
+			},
+			func(string) (string, error) {
+				return "true", nil
+			},
+			func(cmd *exec.Cmd, err error) {
+				assert.NotNil(t, cmd)
+				assert.Nil(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.getGlobalGitConfig = s.getGlobalGitConfig
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.CreateAnnotatedTag("v1.0.0", "abc123", "release", s.sign))
+		})
+	}
+}
+
+// TestGitCommandDeleteRemoteTag is a function.
+func TestGitCommandDeleteRemoteTag(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"push", "origin", "--delete", "refs/tags/v1.0.0"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.DeleteRemoteTag("origin", "v1.0.0"))
+}
+
 // TestGitCommandPush is a function.
 func TestGitCommandPush(t *testing.T) {
 	type scenario struct {
@@ -1050,6 +1340,19 @@ func TestGitCommandStageFile(t *testing.T) {
 	assert.NoError(t, gitCmd.StageFile("test.txt"))
 }
 
+// TestGitCommandAddIntentToAdd is a function.
+func TestGitCommandAddIntentToAdd(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"add", "--intent-to-add", "--", "test.txt"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.AddIntentToAdd("test.txt"))
+}
+
 // TestGitCommandUnstageFile is a function.
 func TestGitCommandUnstageFile(t *testing.T) {
 	type scenario struct {
@@ -1092,7 +1395,7 @@ func TestGitCommandUnstageFile(t *testing.T) {
 		t.Run(s.testName, func(t *testing.T) {
 			gitCmd := NewDummyGitCommand()
 			gitCmd.OSCommand.Command = s.command
-			s.test(gitCmd.UnStageFile("test.txt", s.tracked))
+			s.test(gitCmd.UnStageFile([]string{"test.txt"}, s.tracked))
 		})
 	}
 }
@@ -1664,6 +1967,107 @@ func TestGitCommandRebaseBranch(t *testing.T) {
 	}
 }
 
+// TestGitCommandRebaseOnto is a function.
+func TestGitCommandRebaseOnto(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  "git rebase --interactive --autostash --keep-empty --onto master 1234567",
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.RebaseOnto("master", "1234567"))
+}
+
+// TestGitCommandGetCommitsSince is a function.
+func TestGitCommandGetCommitsSince(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"log", "--oneline", "--no-decorate", "--no-color", "1234567..HEAD"}, args)
+		return exec.Command("echo", "abc1234 second\ndef5678 first")
+	}
+
+	commits, err := gitCmd.GetCommitsSince("1234567")
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"abc1234 second", "def5678 first"}, commits)
+}
+
+// TestGitCommandGenerateRangeRebaseTodo is a function.
+func TestGitCommandGenerateRangeRebaseTodo(t *testing.T) {
+	commits := []*models.Commit{
+		{Sha: "c4", Name: "four"},
+		{Sha: "c3", Name: "three"},
+		{Sha: "c2", Name: "two"},
+		{Sha: "c1", Name: "one"},
+	}
+
+	type scenario struct {
+		testName     string
+		start        int
+		end          int
+		action       string
+		expectedTodo string
+		expectedSha  string
+		expectedErr  bool
+	}
+
+	scenarios := []scenario{
+		{
+			"drop a single commit",
+			1,
+			1,
+			"drop",
+			"drop c3 three\npick c4 four\n",
+			"c2",
+			false,
+		},
+		{
+			"drop a range of commits",
+			0,
+			1,
+			"drop",
+			"drop c3 three\ndrop c4 four\n",
+			"c2",
+			false,
+		},
+		{
+			"squash a range of commits into the commit below",
+			0,
+			1,
+			"squash",
+			"pick c2 two\nsquash c3 three\nsquash c4 four\n",
+			"c1",
+			false,
+		},
+		{
+			"squash a range onto the first commit",
+			1,
+			2,
+			"squash",
+			"",
+			"",
+			true,
+		},
+	}
+
+	gitCmd := NewDummyGitCommand()
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			todo, sha, err := gitCmd.GenerateRangeRebaseTodo(commits, s.start, s.end, s.action)
+			if s.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.EqualValues(t, s.expectedTodo, todo)
+			assert.EqualValues(t, s.expectedSha, sha)
+		})
+	}
+}
+
 // TestGitCommandCheckoutFile is a function.
 func TestGitCommandCheckoutFile(t *testing.T) {
 	type scenario struct {
@@ -1841,6 +2245,116 @@ func TestGitCommandDiscardUnstagedFileChanges(t *testing.T) {
 	}
 }
 
+// TestGitCommandUnstageDirectory is a function.
+func TestGitCommandUnstageDirectory(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git reset HEAD -- "pkg/gui"`,
+			Replace: "echo",
+		},
+	})
+
+	assert.NoError(t, gitCmd.UnstageDirectory("pkg/gui"))
+}
+
+// TestGitCommandDiscardAllDirChanges is a function.
+func TestGitCommandDiscardAllDirChanges(t *testing.T) {
+	type scenario struct {
+		testName string
+		files    []*models.File
+		command  func(string, ...string) *exec.Cmd
+		test     func(error)
+	}
+
+	scenarios := []scenario{
+		{
+			"unstaged changes to tracked files",
+			[]*models.File{{Name: "dir/a", Tracked: true, HasUnstagedChanges: true, ShortStatus: " M"}},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git checkout -- "dir"`,
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"staged, added and untracked files",
+			[]*models.File{
+				{Name: "dir/a", Tracked: true, HasStagedChanges: true, ShortStatus: "M "},
+				{Name: "dir/b", Tracked: false, HasStagedChanges: true, ShortStatus: "A "},
+				{Name: "dir/c", Tracked: false, HasUnstagedChanges: true, ShortStatus: "??"},
+			},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git reset -- "dir"`,
+					Replace: "echo",
+				},
+				{
+					Expect:  `git checkout -- "dir"`,
+					Replace: "echo",
+				},
+				{
+					Expect:  `git clean -fd -- "dir"`,
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"only added files",
+			[]*models.File{{Name: "dir/b", Tracked: false, HasStagedChanges: true, ShortStatus: "A "}},
+			test.CreateMockCommand(t, []*test.CommandSwapper{
+				{
+					Expect:  `git reset -- "dir"`,
+					Replace: "echo",
+				},
+				{
+					Expect:  `git clean -fd -- "dir"`,
+					Replace: "echo",
+				},
+			}),
+			func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.DiscardAllDirChanges("dir", s.files))
+		})
+	}
+}
+
+// TestGitCommandDiscardUnstagedDirChanges is a function.
+func TestGitCommandDiscardUnstagedDirChanges(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = test.CreateMockCommand(t, []*test.CommandSwapper{
+		{
+			Expect:  `git clean -fd -- "dir"`,
+			Replace: "echo",
+		},
+		{
+			Expect:  `git checkout -- "dir"`,
+			Replace: "echo",
+		},
+	})
+
+	files := []*models.File{
+		{Name: "dir/a", Tracked: true, HasUnstagedChanges: true},
+		{Name: "dir/b", Tracked: false, HasUnstagedChanges: true},
+	}
+	assert.NoError(t, gitCmd.DiscardUnstagedDirChanges("dir", files))
+}
+
 // TestGitCommandDiscardAnyUnstagedFileChanges is a function.
 func TestGitCommandDiscardAnyUnstagedFileChanges(t *testing.T) {
 	type scenario struct {
@@ -2089,3 +2603,123 @@ func TestFindDotGitDir(t *testing.T) {
 		})
 	}
 }
+
+// TestGitCommandGetBisectInfo is a function.
+func TestGitCommandGetBisectInfo(t *testing.T) {
+	type scenario struct {
+		testName string
+		files    map[string]string
+		command  func(string, ...string) *exec.Cmd
+		test     func(*models.BisectInfo, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Not bisecting",
+			map[string]string{},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.Fail(t, "should not run any commands")
+				return exec.Command("echo")
+			},
+			func(info *models.BisectInfo, err error) {
+				assert.NoError(t, err)
+				assert.False(t, info.Started())
+				assert.EqualValues(t, "", info.Culprit())
+			},
+		},
+		{
+			"Bisect started with only a bad commit",
+			map[string]string{"BISECT_START": "master\n"},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+
+				switch args[0] {
+				case "rev-parse":
+					return exec.Command("echo", "aaa")
+				case "for-each-ref":
+					assert.EqualValues(t, []string{"for-each-ref", "--format=%(refname) %(objectname)", "refs/bisect/"}, args)
+					return exec.Command("echo", "refs/bisect/bad aaa")
+				}
+				assert.Fail(t, "unexpected command")
+				return nil
+			},
+			func(info *models.BisectInfo, err error) {
+				assert.NoError(t, err)
+				assert.True(t, info.Started())
+				assert.EqualValues(t, "master", info.Start)
+				assert.EqualValues(t, "aaa", info.NewSha)
+				assert.EqualValues(t, models.BisectStatusNew, info.Status("aaa"))
+				assert.Len(t, info.Candidates, 0)
+				assert.EqualValues(t, "", info.Culprit())
+			},
+		},
+		{
+			"Bisect in progress with custom terms",
+			map[string]string{"BISECT_START": "master\n", "BISECT_TERMS": "broken\nfixed\n"},
+			func(cmd string, args ...string) *exec.Cmd {
+				switch args[0] {
+				case "rev-parse":
+					return exec.Command("echo", "ccc")
+				case "for-each-ref":
+					return exec.Command("echo", "refs/bisect/broken aaa\nrefs/bisect/fixed-eee eee\nrefs/bisect/skip-ddd ddd")
+				case "rev-list":
+					assert.EqualValues(t, []string{"rev-list", "aaa", "--not", "eee"}, args)
+					return exec.Command("echo", "aaa\nbbb\nccc\nddd")
+				}
+				assert.Fail(t, "unexpected command")
+				return nil
+			},
+			func(info *models.BisectInfo, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "broken", info.NewTerm)
+				assert.EqualValues(t, "fixed", info.OldTerm)
+				assert.EqualValues(t, models.BisectStatusNew, info.Status("aaa"))
+				assert.EqualValues(t, models.BisectStatusNone, info.Status("bbb"))
+				assert.EqualValues(t, models.BisectStatusCurrent, info.Status("ccc"))
+				assert.EqualValues(t, models.BisectStatusSkipped, info.Status("ddd"))
+				assert.EqualValues(t, models.BisectStatusOld, info.Status("eee"))
+				assert.True(t, info.IsCandidate("bbb"))
+				assert.False(t, info.IsCandidate("eee"))
+				assert.EqualValues(t, "", info.Culprit())
+			},
+		},
+		{
+			"Bisect finished",
+			map[string]string{"BISECT_START": "master\n"},
+			func(cmd string, args ...string) *exec.Cmd {
+				switch args[0] {
+				case "rev-parse":
+					return exec.Command("echo", "bbb")
+				case "for-each-ref":
+					return exec.Command("echo", "refs/bisect/bad aaa\nrefs/bisect/good-bbb bbb")
+				case "rev-list":
+					return exec.Command("echo", "aaa")
+				}
+				assert.Fail(t, "unexpected command")
+				return nil
+			},
+			func(info *models.BisectInfo, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, "aaa", info.Culprit())
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dotGitDir, err := ioutil.TempDir("", "lazygit-bisect")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dotGitDir)
+
+			for name, content := range s.files {
+				assert.NoError(t, ioutil.WriteFile(dotGitDir+"/"+name, []byte(content), 0644))
+			}
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.DotGitDir = dotGitDir
+			gitCmd.OSCommand.Command = s.command
+
+			s.test(gitCmd.GetBisectInfo())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"strings"
+)
+
+// GetIgnoredDirectories returns the directories that are ignored as a whole,
+// relative to the root of the worktree and with a trailing slash, e.g.
+// 'node_modules/'
+func (c *GitCommand) GetIgnoredDirectories() ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git ls-files -z --others --ignored --exclude-standard --directory")
+	if err != nil {
+		return nil, err
+	}
+
+	directories := []string{}
+	for _, path := range strings.Split(output, "\x00") {
+		if strings.HasSuffix(path, "/") {
+			directories = append(directories, path)
+		}
+	}
+	return directories, nil
+}
+
+// FilterIgnoredPaths returns the paths that are ignored out of the given paths,
+// which are relative to the root of the worktree
+func (c *GitCommand) FilterIgnoredPaths(paths []string) []string {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	quotedPaths := make([]string, len(paths))
+	for i, path := range paths {
+		quotedPaths[i] = c.OSCommand.Quote(path)
+	}
+
+	// check-ignore exits with an error when none of the paths are ignored
+	output, err := c.OSCommand.RunCommandWithOutput("git check-ignore -z -- %s", strings.Join(quotedPaths, " "))
+	if err != nil {
+		return nil
+	}
+
+	ignoredPaths := []string{}
+	for _, path := range strings.Split(output, "\x00") {
+		if path != "" {
+			ignoredPaths = append(ignoredPaths, path)
+		}
+	}
+	return ignoredPaths
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetIgnoredDirectories is a function.
+func TestGitCommandGetIgnoredDirectories(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"ls-files", "-z", "--others", "--ignored", "--exclude-standard", "--directory"}, args)
+		return exec.Command("printf", `node_modules/\0debug.log\0vendor/cache/\0`)
+	}
+
+	directories, err := gitCmd.GetIgnoredDirectories()
+	assert.NoError(t, err)
+	assert.EqualValues(t, []string{"node_modules/", "vendor/cache/"}, directories)
+}
+
+// TestGitCommandFilterIgnoredPaths is a function.
+func TestGitCommandFilterIgnoredPaths(t *testing.T) {
+	type scenario struct {
+		testName string
+		paths    []string
+		command  func(string, ...string) *exec.Cmd
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"No paths",
+			[]string{},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.Fail(t, "shouldn't run a command without any paths")
+				return nil
+			},
+			nil,
+		},
+		{
+			"Some paths ignored",
+			[]string{"main.go", "debug.log", "build/out"},
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"check-ignore", "-z", "--", "main.go", "debug.log", "build/out"}, args)
+				return exec.Command("printf", `debug.log\0build/out\0`)
+			},
+			[]string{"debug.log", "build/out"},
+		},
+		{
+			"No paths ignored",
+			[]string{"main.go"},
+			func(cmd string, args ...string) *exec.Cmd {
+				return exec.Command("test", "")
+			},
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			assert.EqualValues(t, s.expected, gitCmd.FilterIgnoredPaths(s.paths))
+		})
+	}
+}
@@ -72,10 +72,6 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *models.Commit {
 
 	unitTimestampInt, _ := strconv.Atoi(unixTimestamp)
 
-	// Any commit with multiple parents is a merge commit.
-	// If there's a space then it means there must be more than one parent hash
-	isMerge := strings.Contains(parentHashes, " ")
-
 	return &models.Commit{
 		Sha:           sha,
 		Name:          message,
@@ -83,7 +79,7 @@ func (c *CommitListBuilder) extractCommitFromLine(line string) *models.Commit {
 		ExtraInfo:     extraInfo,
 		UnixTimestamp: int64(unitTimestampInt),
 		Author:        author,
-		IsMerge:       isMerge,
+		Parents:       strings.Fields(parentHashes),
 	}
 }
 
@@ -92,6 +88,50 @@ type GetCommitsOptions struct {
 	FilterPath           string
 	IncludeRebaseCommits bool
 	RefName              string // e.g. "HEAD" or "my_branch"
+	Search               CommitSearchOptions
+}
+
+// CommitSearchOptions narrows down the commits we get from git log. When more
+// than one is set, a commit has to match all of them
+type CommitSearchOptions struct {
+	Message string // a regex to match against the commit message
+	Author  string // a regex to match against the author's name and email
+	// Pickaxe is a string whose number of occurrences the commit changes, or
+	// if PickaxeRegex is set, a regex that a line in the commit's diff matches
+	Pickaxe      string
+	PickaxeRegex bool
+	Since        string // any date git understands e.g. '2 weeks ago'
+	Until        string
+}
+
+// Active tells us whether we're searching at all
+func (o CommitSearchOptions) Active() bool {
+	return len(o.Args()) > 0
+}
+
+// Args returns the git log arguments for the search, unquoted
+func (o CommitSearchOptions) Args() []string {
+	args := []string{}
+	if o.Message != "" {
+		args = append(args, "--grep="+o.Message)
+	}
+	if o.Author != "" {
+		args = append(args, "--author="+o.Author)
+	}
+	if o.Pickaxe != "" {
+		if o.PickaxeRegex {
+			args = append(args, "-G"+o.Pickaxe)
+		} else {
+			args = append(args, "-S"+o.Pickaxe)
+		}
+	}
+	if o.Since != "" {
+		args = append(args, "--since="+o.Since)
+	}
+	if o.Until != "" {
+		args = append(args, "--until="+o.Until)
+	}
+	return args
 }
 
 func (c *CommitListBuilder) MergeRebasingCommits(commits []*models.Commit) ([]*models.Commit, error) {
@@ -134,7 +174,9 @@ func (c *CommitListBuilder) GetCommits(opts GetCommitsOptions) ([]*models.Commit
 		return nil, err
 	}
 
-	if opts.IncludeRebaseCommits && opts.FilterPath == "" {
+	// if we're only showing some of the commits, the ones we're rebasing
+	// won't necessarily be among them
+	if opts.IncludeRebaseCommits && opts.FilterPath == "" && !opts.Search.Active() {
 		var err error
 		rebasingCommits, err = c.MergeRebasingCommits(commits)
 		if err != nil {
@@ -167,7 +209,7 @@ func (c *CommitListBuilder) GetCommits(opts GetCommitsOptions) ([]*models.Commit
 		return nil, err
 	}
 
-	if rebaseMode != "" {
+	if rebaseMode != "" && len(commits) > len(rebasingCommits) {
 		currentCommit := commits[len(rebasingCommits)]
 		blue := color.New(color.FgYellow)
 		youAreHere := blue.Sprintf("<-- %s ---", c.Tr.SLocalize("YouAreHere"))
@@ -189,6 +231,8 @@ func (c *CommitListBuilder) getRebasingCommits(rebaseMode string) ([]*models.Com
 		return c.getNormalRebasingCommits()
 	case "interactive":
 		return c.getInteractiveRebasingCommits()
+	case "am":
+		return c.getAmCommits()
 	default:
 		return nil, nil
 	}
@@ -235,6 +279,70 @@ func (c *CommitListBuilder) getNormalRebasingCommits() ([]*models.Commit, error)
 	return commits, nil
 }
 
+// getAmCommits returns the patches that git am has yet to apply. git am splits
+// the mailbox into numbered files in rebase-apply, and 'next' holds the number
+// of the patch it's up to
+func (c *CommitListBuilder) getAmCommits() ([]*models.Commit, error) {
+	amDir := filepath.Join(c.GitCommand.DotGitDir, "rebase-apply")
+	bytesContent, err := ioutil.ReadFile(filepath.Join(amDir, "next"))
+	if err != nil {
+		// we assume an error means the session has just ended
+		return nil, nil
+	}
+	next, err := strconv.Atoi(strings.TrimSpace(string(bytesContent)))
+	if err != nil {
+		return nil, err
+	}
+
+	fileInfos, err := ioutil.ReadDir(amDir)
+	if err != nil {
+		return nil, err
+	}
+
+	// ReadDir sorts by name and the numbers are zero-padded, so we're going from
+	// the first patch to the last
+	commits := []*models.Commit{}
+	re := regexp.MustCompile(`^\d+$`)
+	for _, fileInfo := range fileInfos {
+		if !re.MatchString(fileInfo.Name()) {
+			continue
+		}
+		number, err := strconv.Atoi(fileInfo.Name())
+		if err != nil || number < next {
+			continue
+		}
+		bytesContent, err := ioutil.ReadFile(filepath.Join(amDir, fileInfo.Name()))
+		if err != nil {
+			return nil, err
+		}
+		commits = append([]*models.Commit{commitFromMail(string(bytesContent))}, commits...)
+	}
+
+	return commits, nil
+}
+
+// commitFromMail reads the headers of a patch sent by email. Unlike the patches
+// of a normal rebase, the headers can come in any order, and there may not be a
+// 'From <sha>' line at all if the patch didn't come from git format-patch
+func commitFromMail(content string) *models.Commit {
+	commit := &models.Commit{Status: "rebasing"}
+	for i, line := range strings.Split(content, "\n") {
+		if line == "" {
+			// the headers end at the first blank line
+			break
+		}
+		if i == 0 && strings.HasPrefix(line, "From ") {
+			commit.Sha = strings.Split(line, " ")[1]
+		} else if strings.HasPrefix(line, "Subject: ") {
+			commit.Name = mailSubjectPrefixRegexp.ReplaceAllString(strings.TrimPrefix(line, "Subject: "), "")
+		}
+	}
+	return commit
+}
+
+// matches the prefix that git format-patch adds to the subject, like '[PATCH 1/2] '
+var mailSubjectPrefixRegexp = regexp.MustCompile(`^\[PATCH[^\]]*\]\s*`)
+
 // git-rebase-todo example:
 // pick ac446ae94ee560bdb8d1d057278657b251aaef17 ac446ae
 // pick afb893148791a2fbd8091aeb81deba4930c73031 afb8931
@@ -256,21 +364,8 @@ func (c *CommitListBuilder) getInteractiveRebasingCommits() ([]*models.Commit, e
 	}
 
 	commits := []*models.Commit{}
-	lines := strings.Split(string(bytesContent), "\n")
-	for _, line := range lines {
-		if line == "" || line == "noop" {
-			return commits, nil
-		}
-		if strings.HasPrefix(line, "#") {
-			continue
-		}
-		splitLine := strings.Split(line, " ")
-		commits = append([]*models.Commit{{
-			Sha:    splitLine[1],
-			Name:   strings.Join(splitLine[2:], " "),
-			Status: "rebasing",
-			Action: splitLine[0],
-		}}, commits...)
+	for _, item := range parseTodoItems(strings.Split(string(bytesContent), "\n")) {
+		commits = append([]*models.Commit{item.commit()}, commits...)
 	}
 
 	return commits, nil
@@ -359,14 +454,9 @@ func (c *CommitListBuilder) getLogCmd(opts GetCommitsOptions) *exec.Cmd {
 		limitFlag = "-300"
 	}
 
-	filterFlag := ""
-	if opts.FilterPath != "" {
-		filterFlag = fmt.Sprintf(" --follow -- %s", c.OSCommand.Quote(opts.FilterPath))
-	}
-
-	return c.OSCommand.ExecutableFromString(
+	cmd := c.OSCommand.ExecutableFromString(
 		fmt.Sprintf(
-			"git log %s --oneline --pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%p%s%%s\" %s --abbrev=%d --date=unix %s",
+			"git log %s --oneline --pretty=format:\"%%H%s%%at%s%%aN%s%%d%s%%P%s%%s\" %s --abbrev=%d --date=unix",
 			opts.RefName,
 			SEPARATION_CHAR,
 			SEPARATION_CHAR,
@@ -375,7 +465,16 @@ func (c *CommitListBuilder) getLogCmd(opts GetCommitsOptions) *exec.Cmd {
 			SEPARATION_CHAR,
 			limitFlag,
 			20,
-			filterFlag,
 		),
 	)
+
+	// the search terms are often regexes, so we pass them on as they are rather
+	// than quoting them, which would lose any backslashes
+	cmd.Args = append(cmd.Args, opts.Search.Args()...)
+
+	if opts.FilterPath != "" {
+		cmd.Args = append(cmd.Args, "--follow", "--", opts.FilterPath)
+	}
+
+	return cmd
 }
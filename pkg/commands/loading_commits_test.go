@@ -111,3 +111,99 @@ func TestCommitListBuilderGetMergeBase(t *testing.T) {
 		})
 	}
 }
+
+// TestCommitFromMail is a function.
+func TestCommitFromMail(t *testing.T) {
+	type scenario struct {
+		testName     string
+		content      string
+		expectedSha  string
+		expectedName string
+	}
+
+	scenarios := []scenario{
+		{
+			"patch from git format-patch",
+			`From e93d4193e6dd45ca9cf3a5a273d7ba6cd8b8fb20 Mon Sep 17 00:00:00 2001
+From: Lazygit Tester <test@example.com>
+Date: Wed, 5 Dec 2018 21:03:23 +1100
+Subject: [PATCH 1/2] second commit on master
+
+---
+ file | 1 +
+`,
+			"e93d4193e6dd45ca9cf3a5a273d7ba6cd8b8fb20",
+			"second commit on master",
+		},
+		{
+			"email with the headers in another order",
+			`Message-ID: <123@example.com>
+Subject: fix the thing
+From: Lazygit Tester <test@example.com>
+
+Subject: not a header
+`,
+			"",
+			"fix the thing",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			commit := commitFromMail(s.content)
+			assert.EqualValues(t, s.expectedSha, commit.Sha)
+			assert.EqualValues(t, s.expectedName, commit.Name)
+			assert.EqualValues(t, "rebasing", commit.Status)
+		})
+	}
+}
+
+// TestCommitListBuilderGetLogCmd is a function.
+func TestCommitListBuilderGetLogCmd(t *testing.T) {
+	type scenario struct {
+		testName         string
+		opts             GetCommitsOptions
+		expectedTailArgs []string
+	}
+
+	scenarios := []scenario{
+		{
+			"no search",
+			GetCommitsOptions{RefName: "HEAD"},
+			[]string{"--abbrev=20", "--date=unix"},
+		},
+		{
+			"search by message and author",
+			GetCommitsOptions{
+				RefName: "HEAD",
+				Search:  CommitSearchOptions{Message: "fix the thing", Author: "Jesse"},
+			},
+			[]string{"--abbrev=20", "--date=unix", "--grep=fix the thing", "--author=Jesse"},
+		},
+		{
+			"pickaxe with dates",
+			GetCommitsOptions{
+				RefName: "HEAD",
+				Search:  CommitSearchOptions{Pickaxe: "getLogCmd", Since: "2 weeks ago", Until: "yesterday"},
+			},
+			[]string{"--abbrev=20", "--date=unix", "-SgetLogCmd", "--since=2 weeks ago", "--until=yesterday"},
+		},
+		{
+			"regex pickaxe combined with a path",
+			GetCommitsOptions{
+				RefName:    "HEAD",
+				FilterPath: "pkg/gui",
+				Search:     CommitSearchOptions{Pickaxe: "func \\w+", PickaxeRegex: true},
+			},
+			[]string{"--abbrev=20", "--date=unix", "-Gfunc \\w+", "--follow", "--", "pkg/gui"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			c := NewDummyCommitListBuilder()
+			args := c.getLogCmd(s.opts).Args
+			assert.EqualValues(t, s.expectedTailArgs, args[len(args)-len(s.expectedTailArgs):])
+		})
+	}
+}
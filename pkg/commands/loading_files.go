@@ -2,6 +2,7 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
@@ -14,6 +15,32 @@ type GetStatusFileOptions struct {
 }
 
 func (c *GitCommand) GetStatusFiles(opts GetStatusFileOptions) []*models.File {
+	return c.GetStatus(opts).Files
+}
+
+// Status is what git status tells us about the working tree and the checked
+// out branch
+type Status struct {
+	Files  []*models.File
+	Branch BranchStatus
+}
+
+// BranchStatus is the checked out branch as git status reports it
+type BranchStatus struct {
+	// Oid is the sha of HEAD, or '(initial)' if there are no commits yet
+	Oid string
+	// Head is the name of the branch, or '(detached)' if HEAD is detached
+	Head     string
+	Upstream string
+	// HasAheadBehind is false if there's no upstream or git couldn't find it,
+	// in which case Ahead and Behind are meaningless
+	HasAheadBehind bool
+	Ahead          int
+	Behind         int
+}
+
+// GetStatus loads the files and the branch's tracking info in one call
+func (c *GitCommand) GetStatus(opts GetStatusFileOptions) *Status {
 	// check if config wants us ignoring untracked files
 	untrackedFilesSetting := c.GetConfigValue("status.showUntrackedFiles")
 
@@ -26,41 +53,141 @@ func (c *GitCommand) GetStatusFiles(opts GetStatusFileOptions) []*models.File {
 	if err != nil {
 		c.Log.Error(err)
 	}
-	statusStrings := utils.SplitLines(statusOutput)
-	files := []*models.File{}
 
-	for _, statusString := range statusStrings {
-		if strings.HasPrefix(statusString, "warning") {
-			c.Log.Warningf("warning when calling git status: %s", statusString)
+	return c.parseStatus(statusOutput)
+}
+
+// parseStatus parses the output of 'git status --porcelain=v2 -z --branch'.
+// Entries are separated by NUL characters and paths aren't quoted, so any
+// filename comes through as is. See
+// https://git-scm.com/docs/git-status#_porcelain_format_version_2
+func (c *GitCommand) parseStatus(output string) *Status {
+	// warnings go to stderr, which comes before the entries in the output
+	for strings.HasPrefix(output, "warning") {
+		lineEnd := strings.Index(output, "\n")
+		if lineEnd == -1 {
+			lineEnd = len(output) - 1
+		}
+		c.Log.Warningf("warning when calling git status: %s", output[:lineEnd])
+		output = output[lineEnd+1:]
+	}
+
+	status := &Status{Files: []*models.File{}}
+
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 2 {
+			continue
+		}
+
+		var file *models.File
+		switch entry[0] {
+		case '#':
+			parseBranchHeader(&status.Branch, entry)
+		case '1':
+			// 1 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <path>
+			fields := strings.SplitN(entry, " ", 9)
+			if len(fields) < 9 {
+				continue
+			}
+			file = &models.File{Name: fields[8], HeadMode: fields[3], IndexMode: fields[4], WorktreeMode: fields[5]}
+			setFileStatus(file, fields[1], fields[2])
+		case '2':
+			// 2 <XY> <sub> <mH> <mI> <mW> <hH> <hI> <X><score> <path>, followed
+			// by the path it was renamed or copied from as its own entry
+			fields := strings.SplitN(entry, " ", 10)
+			if len(fields) < 10 || i+1 >= len(entries) {
+				continue
+			}
+			i++
+			file = &models.File{Name: fields[9], PreviousName: entries[i], HeadMode: fields[3], IndexMode: fields[4], WorktreeMode: fields[5]}
+			setFileStatus(file, fields[1], fields[2])
+		case 'u':
+			// u <XY> <sub> <m1> <m2> <m3> <mW> <h1> <h2> <h3> <path>
+			fields := strings.SplitN(entry, " ", 11)
+			if len(fields) < 11 {
+				continue
+			}
+			file = &models.File{Name: fields[10], HeadMode: fields[4], WorktreeMode: fields[6]}
+			setFileStatus(file, fields[1], fields[2])
+		case '?':
+			file = &models.File{Name: entry[2:]}
+			setFileStatus(file, "??", "N...")
+		default:
+			// ignored files, which we don't ask for
 			continue
 		}
-		change := statusString[0:2]
-		stagedChange := change[0:1]
-		unstagedChange := statusString[1:2]
-		filename := c.OSCommand.Unquote(statusString[3:])
-		untracked := utils.IncludesString([]string{"??", "A ", "AM"}, change)
-		hasNoStagedChanges := utils.IncludesString([]string{" ", "U", "?"}, stagedChange)
-		hasMergeConflicts := utils.IncludesString([]string{"DD", "AA", "UU", "AU", "UA", "UD", "DU"}, change)
-		hasInlineMergeConflicts := utils.IncludesString([]string{"UU", "AA"}, change)
-
-		file := &models.File{
-			Name:                    filename,
-			DisplayString:           statusString,
-			HasStagedChanges:        !hasNoStagedChanges,
-			HasUnstagedChanges:      unstagedChange != " ",
-			Tracked:                 !untracked,
-			Deleted:                 unstagedChange == "D" || stagedChange == "D",
-			HasMergeConflicts:       hasMergeConflicts,
-			HasInlineMergeConflicts: hasInlineMergeConflicts,
-			Type:                    c.OSCommand.FileType(filename),
-			ShortStatus:             change,
+
+		if file != nil {
+			file.Type = c.OSCommand.FileType(file.Name)
+			status.Files = append(status.Files, file)
+		}
+	}
+
+	return status
+}
+
+func parseBranchHeader(branch *BranchStatus, header string) {
+	fields := strings.SplitN(header, " ", 3)
+	if len(fields) < 3 {
+		return
+	}
+
+	switch fields[1] {
+	case "branch.oid":
+		branch.Oid = fields[2]
+	case "branch.head":
+		branch.Head = fields[2]
+	case "branch.upstream":
+		branch.Upstream = fields[2]
+	case "branch.ab":
+		// e.g. '+1 -2'
+		aheadBehind := strings.Fields(fields[2])
+		if len(aheadBehind) != 2 {
+			return
+		}
+		ahead, aheadErr := strconv.Atoi(strings.TrimPrefix(aheadBehind[0], "+"))
+		behind, behindErr := strconv.Atoi(strings.TrimPrefix(aheadBehind[1], "-"))
+		if aheadErr != nil || behindErr != nil {
+			return
+		}
+		branch.HasAheadBehind = true
+		branch.Ahead = ahead
+		branch.Behind = behind
+	}
+}
+
+// setFileStatus sets the file's state from its XY status, where porcelain v2
+// uses '.' for unchanged, and from its submodule field, which is 'N...' for a
+// file that isn't a submodule, or 'S' followed by whether the commit has
+// changed, there are tracked changes and there are untracked files, e.g. 'SC.U'
+func setFileStatus(file *models.File, xy string, sub string) {
+	change := strings.Replace(xy, ".", " ", -1)
+	stagedChange := change[0:1]
+	unstagedChange := change[1:2]
+	untracked := utils.IncludesString([]string{"??", "A ", "AM"}, change)
+	hasNoStagedChanges := utils.IncludesString([]string{" ", "U", "?"}, stagedChange)
+
+	file.ShortStatus = change
+	file.HasStagedChanges = !hasNoStagedChanges
+	file.HasUnstagedChanges = unstagedChange != " "
+	file.Tracked = !untracked
+	file.Deleted = unstagedChange == "D" || stagedChange == "D"
+	file.HasMergeConflicts = utils.IncludesString([]string{"DD", "AA", "UU", "AU", "UA", "UD", "DU"}, change)
+	file.HasInlineMergeConflicts = utils.IncludesString([]string{"UU", "AA"}, change)
+
+	if len(sub) == 4 && sub[0] == 'S' {
+		file.Submodule = &models.SubmoduleStatus{
+			CommitChanged:       sub[1] == 'C',
+			HasTrackedChanges:   sub[2] == 'M',
+			HasUntrackedChanges: sub[3] == 'U',
 		}
-		files = append(files, file)
 	}
-	return files
 }
 
-// GitStatus returns the plaintext short status of the repo
+// GitStatus returns the porcelain v2 status of the repo, with the branch
+// headers, and entries separated by NUL characters
 type GitStatusOptions struct {
 	NoRenames         bool
 	UntrackedFilesArg string
@@ -72,7 +199,7 @@ func (c *GitCommand) GitStatus(opts GitStatusOptions) (string, error) {
 		noRenamesFlag = "--no-renames"
 	}
 
-	return c.OSCommand.RunCommandWithOutput("git status %s --porcelain %s", opts.UntrackedFilesArg, noRenamesFlag)
+	return c.OSCommand.RunCommandWithOutput("git status %s --porcelain=v2 -z --branch %s", opts.UntrackedFilesArg, noRenamesFlag)
 }
 
 // MergeStatusFiles merge status files
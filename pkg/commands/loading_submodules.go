@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetSubmodules returns the submodules configured in .gitmodules along with
+// the commit each one is checked out at and whether it has changes of its own
+func (c *GitCommand) GetSubmodules() ([]*models.SubmoduleConfig, error) {
+	configs, err := c.GetSubmoduleConfigs()
+	if err != nil || len(configs) == 0 {
+		return configs, err
+	}
+
+	quotedPaths := make([]string, len(configs))
+	for i, config := range configs {
+		quotedPaths[i] = c.OSCommand.Quote(config.Path)
+	}
+	pathsArg := strings.Join(quotedPaths, " ")
+
+	// e.g. '160000 9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae 0	vendor/mysubmodule'
+	// where 160000 is the mode git uses for submodules
+	recordedOutput, err := c.OSCommand.RunCommandWithOutput("git ls-files --stage -- %s", pathsArg)
+	if err != nil {
+		return nil, err
+	}
+	recordedShas := map[string]string{}
+	for _, line := range utils.SplitLines(recordedOutput) {
+		split := strings.SplitN(line, "\t", 2)
+		fields := strings.Fields(split[0])
+		if len(split) != 2 || len(fields) < 2 || fields[0] != "160000" {
+			continue
+		}
+		recordedShas[split[1]] = fields[1]
+	}
+
+	// e.g. '+9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae vendor/mysubmodule (heads/master)'
+	// where the prefix is '-' if the submodule isn't initialized, otherwise the
+	// sha is the commit that's checked out
+	statusOutput, err := c.OSCommand.RunCommandWithOutput("git submodule status -- %s", pathsArg)
+	if err != nil {
+		return nil, err
+	}
+	checkedOutShas := map[string]string{}
+	for _, line := range utils.SplitLines(statusOutput) {
+		if len(line) < 2 || line[0] == '-' {
+			continue
+		}
+		fields := strings.Fields(line[1:])
+		if len(fields) < 2 {
+			continue
+		}
+		checkedOutShas[fields[1]] = fields[0]
+	}
+
+	for _, config := range configs {
+		config.RecordedSha = recordedShas[config.Path]
+		config.CheckedOutSha = checkedOutShas[config.Path]
+
+		if !config.IsInitialized() {
+			continue
+		}
+
+		dirtyOutput, err := c.OSCommand.RunCommandWithOutput("git -C %s status --porcelain", c.OSCommand.Quote(config.Path))
+		if err != nil {
+			return nil, err
+		}
+		config.IsDirty = strings.TrimSpace(dirtyOutput) != ""
+	}
+
+	return configs, nil
+}
@@ -0,0 +1,117 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetSubmodules is a function.
+func TestGitCommandGetSubmodules(t *testing.T) {
+	type scenario struct {
+		testName   string
+		gitmodules string
+		command    func(string, ...string) *exec.Cmd
+		test       func([]*models.SubmoduleConfig, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"No submodules",
+			"",
+			func(cmd string, args ...string) *exec.Cmd {
+				t.Errorf("unexpected command: %s %v", cmd, args)
+				return exec.Command("echo")
+			},
+			func(submodules []*models.SubmoduleConfig, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, submodules, 0)
+			},
+		},
+		{
+			"Submodules in various states",
+			`[submodule "clean"]
+	path = vendor/clean
+	url = git@github.com:clean.git
+[submodule "moved"]
+	path = vendor/moved
+	url = git@github.com:moved.git
+[submodule "uninitialized"]
+	path = vendor/uninitialized
+	url = git@github.com:uninitialized.git
+`,
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+
+				switch args[0] {
+				case "ls-files":
+					assert.EqualValues(t, []string{"ls-files", "--stage", "--", "vendor/clean", "vendor/moved", "vendor/uninitialized"}, args)
+					return exec.Command("printf", "160000 aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 0\tvendor/clean\n160000 bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb 0\tvendor/moved\n160000 cccccccccccccccccccccccccccccccccccccccc 0\tvendor/uninitialized\n")
+				case "submodule":
+					assert.EqualValues(t, []string{"submodule", "status", "--", "vendor/clean", "vendor/moved", "vendor/uninitialized"}, args)
+					return exec.Command("printf", " aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa vendor/clean (heads/master)\n+dddddddddddddddddddddddddddddddddddddddd vendor/moved (heads/feature)\n-cccccccccccccccccccccccccccccccccccccccc vendor/uninitialized\n")
+				case "-C":
+					if args[1] == "vendor/moved" {
+						return exec.Command("echo", " M main.go")
+					}
+					return exec.Command("echo")
+				}
+
+				t.Errorf("unexpected command: %s %v", cmd, args)
+				return exec.Command("echo")
+			},
+			func(submodules []*models.SubmoduleConfig, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.SubmoduleConfig{
+					{
+						Name:          "clean",
+						Path:          "vendor/clean",
+						Url:           "git@github.com:clean.git",
+						RecordedSha:   "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+						CheckedOutSha: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+					},
+					{
+						Name:          "moved",
+						Path:          "vendor/moved",
+						Url:           "git@github.com:moved.git",
+						RecordedSha:   "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+						CheckedOutSha: "dddddddddddddddddddddddddddddddddddddddd",
+						IsDirty:       true,
+					},
+					{
+						Name:        "uninitialized",
+						Path:        "vendor/uninitialized",
+						Url:         "git@github.com:uninitialized.git",
+						RecordedSha: "cccccccccccccccccccccccccccccccccccccccc",
+					},
+				}, submodules)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "lazygit-submodules")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+
+			if s.gitmodules != "" {
+				assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".gitmodules"), []byte(s.gitmodules), 0644))
+			}
+
+			wd, err := os.Getwd()
+			assert.NoError(t, err)
+			assert.NoError(t, os.Chdir(dir))
+			defer func() { _ = os.Chdir(wd) }()
+
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.GetSubmodules())
+		})
+	}
+}
@@ -12,6 +12,9 @@ import (
 
 const semverRegex = `v?((\d+\.?)+)([^\d]?.*)`
 
+// tagFormat gives us a tag's name, type, tagger and subject separated by null bytes
+const tagFormat = "%(refname:strip=2)%00%(objecttype)%00%(taggername)%00%(contents:subject)"
+
 func convertToInt(s string) int {
 	i, err := strconv.Atoi(s)
 	if err != nil {
@@ -21,26 +24,33 @@ func convertToInt(s string) int {
 }
 
 func (c *GitCommand) GetTags() ([]*models.Tag, error) {
-	// get remote branches
-	remoteBranchesStr, err := c.OSCommand.RunCommandWithOutput(`git tag --list`)
+	tagsStr, err := c.OSCommand.RunCommandWithOutput("git for-each-ref refs/tags --format=%s", tagFormat)
 	if err != nil {
 		return nil, err
 	}
 
-	content := utils.TrimTrailingNewline(remoteBranchesStr)
+	content := utils.TrimTrailingNewline(tagsStr)
 	if content == "" {
 		return nil, nil
 	}
 
 	split := strings.Split(content, "\n")
 
-	// first step is to get our remotes from go-git
-	tags := make([]*models.Tag, len(split))
-	for i, tagName := range split {
-
-		tags[i] = &models.Tag{
-			Name: tagName,
+	tags := make([]*models.Tag, 0, len(split))
+	for _, line := range split {
+		fields := strings.SplitN(line, "\x00", 4)
+		if len(fields) < 4 {
+			continue
 		}
+
+		// lightweight tags point straight at a commit so they have no tag object,
+		// meaning no tagger or message either
+		tags = append(tags, &models.Tag{
+			Name:        fields[0],
+			IsAnnotated: fields[1] == "tag",
+			Tagger:      fields[2],
+			Message:     fields[3],
+		})
 	}
 
 	// now lets sort our tags by name numerically
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetTags is a function.
+func TestGitCommandGetTags(t *testing.T) {
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*models.Tag, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"No tags",
+			func(cmd string, args ...string) *exec.Cmd {
+				return exec.Command("echo")
+			},
+			func(tags []*models.Tag, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, tags, 0)
+			},
+		},
+		{
+			"Lightweight and annotated tags, sorted numerically",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"for-each-ref", "refs/tags", "--format=%(refname:strip=2)%00%(objecttype)%00%(taggername)%00%(contents:subject)"}, args)
+
+				return exec.Command("printf", "v1.10.0\\0tag\\0Jesse Duffield\\0Release 1.10.0\nv1.9.0\\0commit\\0\\0\nexperiment\\0commit\\0\\0\n")
+			},
+			func(tags []*models.Tag, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.Tag{
+					{Name: "experiment"},
+					{Name: "v1.9.0"},
+					{Name: "v1.10.0", IsAnnotated: true, Tagger: "Jesse Duffield", Message: "Release 1.10.0"},
+				}, tags)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+			s.test(gitCmd.GetTags())
+		})
+	}
+}
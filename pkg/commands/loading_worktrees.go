@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// `git worktree list --porcelain` gives us one block per worktree, separated by
+// blank lines. e.g.
+// worktree /path/to/repo
+// HEAD 9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae
+// branch refs/heads/master
+//
+// worktree /path/to/repo-release
+// HEAD 2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f
+// detached
+// locked
+// The first block is always the main worktree.
+
+func (c *GitCommand) GetWorktrees() ([]*models.Worktree, error) {
+	worktreesStr, err := c.OSCommand.RunCommandWithOutput("git worktree list --porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	currentPath, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	currentPath = normalisePath(currentPath)
+
+	worktrees := []*models.Worktree{}
+	var current *models.Worktree
+	for _, line := range utils.SplitLines(worktreesStr) {
+		if strings.HasPrefix(line, "worktree ") {
+			path := strings.TrimPrefix(line, "worktree ")
+			current = &models.Worktree{
+				Path:      path,
+				IsMain:    len(worktrees) == 0,
+				IsCurrent: normalisePath(path) == currentPath,
+			}
+			worktrees = append(worktrees, current)
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "HEAD "):
+			current.Head = strings.TrimPrefix(line, "HEAD ")
+		case strings.HasPrefix(line, "branch "):
+			current.Branch = strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+		case line == "bare":
+			current.IsBare = true
+		case line == "locked" || strings.HasPrefix(line, "locked "):
+			current.IsLocked = true
+		case line == "prunable" || strings.HasPrefix(line, "prunable "):
+			current.Prunable = true
+		}
+	}
+
+	return worktrees, nil
+}
+
+// normalisePath resolves symlinks so that we can compare the paths git gives us
+// against our working directory (e.g. /tmp vs /private/tmp on macOS)
+func normalisePath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return resolved
+}
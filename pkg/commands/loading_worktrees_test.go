@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandGetWorktrees is a function.
+func TestGitCommandGetWorktrees(t *testing.T) {
+	currentPath, err := os.Getwd()
+	assert.NoError(t, err)
+
+	type scenario struct {
+		testName string
+		command  func(string, ...string) *exec.Cmd
+		test     func([]*models.Worktree, error)
+	}
+
+	scenarios := []scenario{
+		{
+			"Only the main worktree",
+			func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, []string{"worktree", "list", "--porcelain"}, args)
+
+				return exec.Command("echo", "worktree "+currentPath+"\nHEAD 9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae\nbranch refs/heads/master\n")
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.Worktree{
+					{
+						Path:      currentPath,
+						Head:      "9a6f5bd5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae",
+						Branch:    "master",
+						IsMain:    true,
+						IsCurrent: true,
+					},
+				}, worktrees)
+			},
+		},
+		{
+			"Several worktrees in various states",
+			func(cmd string, args ...string) *exec.Cmd {
+				return exec.Command(
+					"echo",
+					"worktree /repos/bare.git\nbare\n\n"+
+						"worktree /repos/feature\nHEAD 2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f\nbranch refs/heads/feature/thing\nlocked on a usb stick\n\n"+
+						"worktree /repos/detached\nHEAD 5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae9a6f5bd\ndetached\nprunable gitdir file points to non-existent location\n",
+				)
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.NoError(t, err)
+				assert.EqualValues(t, []*models.Worktree{
+					{
+						Path:   "/repos/bare.git",
+						IsMain: true,
+						IsBare: true,
+					},
+					{
+						Path:     "/repos/feature",
+						Head:     "2b4bb0b7a5e1b4fd3c8ae9a6f5bd5bc61f8d1e2f",
+						Branch:   "feature/thing",
+						IsLocked: true,
+					},
+					{
+						Path:     "/repos/detached",
+						Head:     "5bc61f8d1e2f2b4bb0b7a5e1b4fd3c8ae9a6f5bd",
+						Prunable: true,
+					},
+				}, worktrees)
+			},
+		},
+		{
+			"Command fails",
+			func(string, ...string) *exec.Cmd {
+				return exec.Command("test")
+			},
+			func(worktrees []*models.Worktree, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, worktrees)
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = s.command
+
+			s.test(gitCmd.GetWorktrees())
+		})
+	}
+}
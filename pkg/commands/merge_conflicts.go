@@ -0,0 +1,123 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// the sections of a conflict. ALL refers to every section at once
+const (
+	CONFLICT_OURS = iota
+	CONFLICT_BASE
+	CONFLICT_THEIRS
+	CONFLICT_ALL
+)
+
+// FindConflicts returns the conflicts in the content of a file. The conflict
+// markers can have any label, e.g. a branch name, a sha, or the subject of a
+// commit being cherry-picked
+func FindConflicts(content string) []Conflict {
+	conflicts := make([]Conflict, 0)
+
+	if content == "" {
+		return conflicts
+	}
+
+	var newConflict Conflict
+	inConflict := false
+	for i, line := range utils.SplitLines(content) {
+		trimmedLine := strings.TrimPrefix(line, "++")
+		switch {
+		case isConflictMarker(trimmedLine, "<<<<<<<"):
+			newConflict = Conflict{Start: i}
+			inConflict = true
+		case !inConflict:
+			// outside of a conflict, a line of equals signs is just a line
+			// (e.g. a markdown heading underline)
+		case isConflictMarker(trimmedLine, "|||||||"):
+			newConflict.Ancestor = i
+		case trimmedLine == "=======":
+			newConflict.Middle = i
+		case isConflictMarker(trimmedLine, ">>>>>>>"):
+			newConflict.End = i
+			conflicts = append(conflicts, newConflict)
+			inConflict = false
+		}
+	}
+	return conflicts
+}
+
+// isConflictMarker tells us whether a line is the given marker, either on its
+// own or followed by a label
+func isConflictMarker(line string, marker string) bool {
+	return line == marker || strings.HasPrefix(line, marker+" ")
+}
+
+// HasAncestor tells us whether the conflict has a base section, as written with
+// the diff3 and zdiff3 conflict styles
+func (c Conflict) HasAncestor() bool {
+	return c.Ancestor > c.Start
+}
+
+// Sections returns the sections of the conflict in the order they appear
+func (c Conflict) Sections() []int {
+	if c.HasAncestor() {
+		return []int{CONFLICT_OURS, CONFLICT_BASE, CONFLICT_THEIRS}
+	}
+	return []int{CONFLICT_OURS, CONFLICT_THEIRS}
+}
+
+// IsMarker tells us whether the given line is one of the conflict's markers
+func (c Conflict) IsMarker(i int) bool {
+	return i == c.Start || i == c.Middle || i == c.End || (c.HasAncestor() && i == c.Ancestor)
+}
+
+// SectionBounds returns the lines of the markers surrounding a section
+func (c Conflict) SectionBounds(section int) (int, int) {
+	switch section {
+	case CONFLICT_OURS:
+		if c.HasAncestor() {
+			return c.Start, c.Ancestor
+		}
+		return c.Start, c.Middle
+	case CONFLICT_BASE:
+		return c.Ancestor, c.Middle
+	case CONFLICT_THEIRS:
+		return c.Middle, c.End
+	default:
+		return c.Start, c.End
+	}
+}
+
+// SectionOfLine returns the section a line sits in, or -1 if it's a marker or
+// outside the conflict
+func (c Conflict) SectionOfLine(i int) int {
+	if i <= c.Start || i >= c.End || c.IsMarker(i) {
+		return -1
+	}
+	for _, section := range c.Sections() {
+		start, end := c.SectionBounds(section)
+		if i > start && i < end {
+			return section
+		}
+	}
+	return -1
+}
+
+// IsLineToRemove tells us whether a line goes when we resolve the conflict by
+// picking the given section. The markers always go, and picking all of them
+// keeps ours and theirs but not the base
+func (c Conflict) IsLineToRemove(i int, pick int) bool {
+	if i < c.Start || i > c.End {
+		return false
+	}
+	if c.IsMarker(i) {
+		return true
+	}
+	section := c.SectionOfLine(i)
+	if pick == CONFLICT_ALL {
+		return section == CONFLICT_BASE
+	}
+	return section != pick
+}
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFindConflicts is a function.
+func TestFindConflicts(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		expected []Conflict
+	}
+
+	scenarios := []scenario{
+		{
+			"No content",
+			"",
+			[]Conflict{},
+		},
+		{
+			"No conflicts, but a markdown heading",
+			"Title\n=======\n",
+			[]Conflict{},
+		},
+		{
+			"Merge style conflict",
+			"line\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> MERGE_HEAD\nline\n",
+			[]Conflict{{Start: 1, Middle: 3, End: 5}},
+		},
+		{
+			"Diff3 style conflict",
+			"<<<<<<< HEAD\nours\n||||||| merged common ancestors\nbase\n=======\ntheirs\n>>>>>>> feature\n",
+			[]Conflict{{Start: 0, Ancestor: 2, Middle: 4, End: 6}},
+		},
+		{
+			"Zdiff3 style conflict with an empty base section",
+			"shared\n<<<<<<< HEAD\nours\n||||||| 5b3c2a1\n=======\ntheirs\n>>>>>>> 8d9e0f1\n",
+			[]Conflict{{Start: 1, Ancestor: 3, Middle: 4, End: 6}},
+		},
+		{
+			"Conflict from a rebase labelled with a sha and subject",
+			"<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> 8d9e0f1 (add the thing)\n",
+			[]Conflict{{Start: 0, Middle: 2, End: 4}},
+		},
+		{
+			"Conflicts labelled with branch names and unlabelled markers",
+			"<<<<<<< my-branch\na\n=======\nb\n>>>>>>> other-branch\nline\n<<<<<<<\nc\n|||||||\nd\n=======\ne\n>>>>>>>\n",
+			[]Conflict{
+				{Start: 0, Middle: 2, End: 4},
+				{Start: 6, Ancestor: 8, Middle: 10, End: 12},
+			},
+		},
+		{
+			"Conflict in a combined diff",
+			"++<<<<<<< Updated upstream\n ours\n++=======\n theirs\n++>>>>>>> Stashed changes\n",
+			[]Conflict{{Start: 0, Middle: 2, End: 4}},
+		},
+		{
+			"Lines that merely look like markers",
+			"<<<<<<<<\n=======\n>>>>>>>>\n",
+			[]Conflict{},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, FindConflicts(s.content))
+		})
+	}
+}
+
+// TestConflictIsLineToRemove is a function.
+func TestConflictIsLineToRemove(t *testing.T) {
+	type scenario struct {
+		testName string
+		content  string
+		pick     int
+		expected string
+	}
+
+	mergeContent := "before\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> feature\nafter\n"
+	diff3Content := "before\n<<<<<<< HEAD\nours\n||||||| base\nbase\n=======\ntheirs\n>>>>>>> feature\nafter\n"
+
+	scenarios := []scenario{
+		{"Merge style, pick ours", mergeContent, CONFLICT_OURS, "before\nours\nafter\n"},
+		{"Merge style, pick theirs", mergeContent, CONFLICT_THEIRS, "before\ntheirs\nafter\n"},
+		{"Merge style, pick all", mergeContent, CONFLICT_ALL, "before\nours\ntheirs\nafter\n"},
+		{"Diff3 style, pick ours", diff3Content, CONFLICT_OURS, "before\nours\nafter\n"},
+		{"Diff3 style, pick base", diff3Content, CONFLICT_BASE, "before\nbase\nafter\n"},
+		{"Diff3 style, pick theirs", diff3Content, CONFLICT_THEIRS, "before\ntheirs\nafter\n"},
+		{"Diff3 style, pick all", diff3Content, CONFLICT_ALL, "before\nours\ntheirs\nafter\n"},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			conflict := FindConflicts(s.content)[0]
+			output := ""
+			for i, line := range strings.SplitAfter(s.content, "\n") {
+				if !conflict.IsLineToRemove(i, s.pick) {
+					output += line
+				}
+			}
+			assert.EqualValues(t, s.expected, output)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+// BisectInfo : The state of an in-progress git bisect
+type BisectInfo struct {
+	// Start is the ref that was checked out when the bisect was started, and
+	// which we return to upon reset. It is blank when we're not bisecting
+	Start string
+
+	// NewTerm and OldTerm are 'bad' and 'good' unless they've been overridden
+	// via `git bisect start --term-new=<term> --term-old=<term>`
+	NewTerm string
+	OldTerm string
+
+	// Current is the sha of the commit that is currently checked out for testing
+	Current string
+
+	// NewSha is the sha of the commit marked with the new term (i.e. 'bad').
+	// Git only keeps track of the earliest of these
+	NewSha      string
+	OldShas     map[string]bool
+	SkippedShas map[string]bool
+
+	// Candidates are the shas of the commits which may still be the first new
+	// commit. This is empty until we've marked both a new and an old commit
+	Candidates map[string]bool
+}
+
+type BisectStatus int
+
+const (
+	BisectStatusNone BisectStatus = iota
+	BisectStatusNew
+	BisectStatusOld
+	BisectStatusSkipped
+	BisectStatusCurrent
+)
+
+func (b *BisectInfo) Started() bool {
+	return b.Start != ""
+}
+
+func (b *BisectInfo) Status(sha string) BisectStatus {
+	switch {
+	case sha == b.NewSha:
+		return BisectStatusNew
+	case b.OldShas[sha]:
+		return BisectStatusOld
+	case b.SkippedShas[sha]:
+		return BisectStatusSkipped
+	case sha == b.Current:
+		return BisectStatusCurrent
+	default:
+		return BisectStatusNone
+	}
+}
+
+func (b *BisectInfo) IsCandidate(sha string) bool {
+	return b.Candidates[sha]
+}
+
+// Culprit returns the sha of the first new commit once the bisect has narrowed
+// things down to a single candidate, and an empty string otherwise
+func (b *BisectInfo) Culprit() string {
+	if len(b.Candidates) != 1 {
+		return ""
+	}
+	return b.NewSha
+}
@@ -0,0 +1,38 @@
+package models
+
+// BlameCommit : A commit that some lines of a blamed file are attributed to
+type BlameCommit struct {
+	Sha        string
+	Author     string
+	AuthorTime int64
+	Summary    string
+	// Boundary is true when the commit is the root commit, or the oldest commit
+	// in the range that was blamed, in which case there's nothing before it to blame
+	Boundary bool
+	// PreviousSha and PreviousPath point at the commit before this one touched
+	// the file, and the file's path at that commit (which differs if it was renamed)
+	PreviousSha  string
+	PreviousPath string
+}
+
+func (c *BlameCommit) ShortSha() string {
+	if len(c.Sha) < 8 {
+		return c.Sha
+	}
+	return c.Sha[:8]
+}
+
+// IsUncommitted is true for lines that have been changed in the working tree
+func (c *BlameCommit) IsUncommitted() bool {
+	return c.Sha == "0000000000000000000000000000000000000000"
+}
+
+// BlameLine : A line of a blamed file
+type BlameLine struct {
+	Commit *BlameCommit
+	// LineNumber is the line's number in the blamed version of the file
+	LineNumber int
+	// OriginalLineNumber is the line's number in the commit it is attributed to
+	OriginalLineNumber int
+	Content            string
+}
@@ -7,14 +7,39 @@ type Commit struct {
 	Sha           string
 	Name          string
 	Status        string // one of "unpushed", "pushed", "merged", "rebasing" or "selected"
-	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup"
+	Action        string // one of "", "pick", "edit", "squash", "reword", "drop", "fixup", or for lines of the rebase todo that aren't commits, "exec", "break", "label", "reset" or "merge"
 	Tags          []string
 	ExtraInfo     string // something like 'HEAD -> master, tag: v0.15.2'
 	Author        string
 	UnixTimestamp int64
+	Parents       []string // the shas of the commit's parents
+}
+
+// the rebase todo actions that pick a commit, as opposed to running a command
+// or recreating merges
+var commitTodoActions = map[string]bool{
+	"pick":   true,
+	"reword": true,
+	"edit":   true,
+	"squash": true,
+	"fixup":  true,
+	"drop":   true,
+}
+
+// IsCommitTodoAction tells us whether the rebase todo action picks a commit
+func IsCommitTodoAction(action string) bool {
+	return commitTodoActions[action]
+}
+
+// IsTodo tells us whether we're dealing with a line of the rebase todo that
+// does something other than pick a commit, e.g. 'exec make test'
+func (c *Commit) IsTodo() bool {
+	return c.Status == "rebasing" && c.Action != "" && !IsCommitTodoAction(c.Action)
+}
 
-	// IsMerge tells us whether we're dealing with a merge commit i.e. a commit with two parents
-	IsMerge bool
+// IsMerge tells us whether we're dealing with a merge commit i.e. a commit with two parents
+func (c *Commit) IsMerge() bool {
+	return len(c.Parents) > 1
 }
 
 func (c *Commit) ShortSha() string {
@@ -33,5 +58,8 @@ func (c *Commit) ID() string {
 }
 
 func (c *Commit) Description() string {
+	if c.IsTodo() {
+		return fmt.Sprintf("%s %s", c.Action, c.Name)
+	}
 	return fmt.Sprintf("%s %s", c.Sha[:7], c.Name)
 }
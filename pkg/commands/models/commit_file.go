@@ -19,3 +19,7 @@ func (f *CommitFile) ID() string {
 func (f *CommitFile) Description() string {
 	return f.Name
 }
+
+func (f *CommitFile) GetPath() string {
+	return f.Name
+}
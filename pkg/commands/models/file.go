@@ -1,35 +1,51 @@
 package models
 
 import (
-	"strings"
-
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
 // File : A file from git status
 // duplicating this for now
 type File struct {
-	Name                    string
+	Name string
+	// PreviousName is the path the file had before it was renamed or copied,
+	// and is empty otherwise
+	PreviousName            string
 	HasStagedChanges        bool
 	HasUnstagedChanges      bool
 	Tracked                 bool
 	Deleted                 bool
 	HasMergeConflicts       bool
 	HasInlineMergeConflicts bool
-	DisplayString           string
 	Type                    string // one of 'file', 'directory', and 'other'
 	ShortStatus             string // e.g. 'AD', ' A', 'M ', '??'
+	// the octal file modes in HEAD, the index and the worktree, e.g. '100644'.
+	// They're '000000' where the file doesn't exist, and empty for untracked
+	// files. For a merge conflict, the HEAD mode is our side's
+	HeadMode     string
+	IndexMode    string
+	WorktreeMode string
+	// Submodule is nil unless the file is a submodule
+	Submodule *SubmoduleStatus
 }
 
-const RENAME_SEPARATOR = " -> "
+// SubmoduleStatus is what's changed in a submodule, as git status reports it
+type SubmoduleStatus struct {
+	CommitChanged       bool
+	HasTrackedChanges   bool
+	HasUntrackedChanges bool
+}
 
 func (f *File) IsRename() bool {
-	return strings.Contains(f.Name, RENAME_SEPARATOR)
+	return f.PreviousName != ""
 }
 
-// Names returns an array containing just the filename, or in the case of a rename, the after filename and the before filename
+// Names returns an array containing just the filename, or in the case of a rename, the before filename and the after filename
 func (f *File) Names() []string {
-	return strings.Split(f.Name, RENAME_SEPARATOR)
+	if f.IsRename() {
+		return []string{f.PreviousName, f.Name}
+	}
+	return []string{f.Name}
 }
 
 // returns true if the file names are the same or if a a file rename includes the filename of the other
@@ -58,3 +74,9 @@ func (f *File) SubmoduleConfig(configs []*SubmoduleConfig) *SubmoduleConfig {
 
 	return nil
 }
+
+// GetPath returns the path of the file, or in the case of a rename, the after
+// path
+func (f *File) GetPath() string {
+	return f.Name
+}
@@ -0,0 +1,32 @@
+package models
+
+import "fmt"
+
+// PullRequest : A pull request (or merge request, in GitLab's terms) on the repo's forge
+type PullRequest struct {
+	Number     int
+	Title      string
+	Author     string
+	URL        string
+	HeadBranch string
+	HeadSha    string
+	// FromFork is true if the head branch is in another repo, in which case it
+	// has nothing to do with our branch of the same name
+	FromFork   bool
+	BaseBranch string
+	// FetchRef is the ref on the remote that points to the head of the pull
+	// request. Unlike the head branch, this works for pull requests from forks
+	FetchRef     string
+	CIStatus     string // one of "", "pending", "success" or "failure"
+	ReviewStatus string // one of "", "approved" or "changes_requested"
+}
+
+// LocalBranchName is the name of the local branch we check the pull request out
+// into. A fork's branch gets a name of its own, so that e.g. someone's pull
+// request from their master doesn't get mixed up with our master
+func (pr *PullRequest) LocalBranchName() string {
+	if pr.FromFork {
+		return fmt.Sprintf("pr/%d", pr.Number)
+	}
+	return pr.HeadBranch
+}
@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// SavedPatch is a custom patch that's been saved under a name so that it can be
+// applied again later, e.g. to each of several release branches
+type SavedPatch struct {
+	Name    string
+	ModTime time.Time
+}
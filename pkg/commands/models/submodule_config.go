@@ -4,4 +4,30 @@ type SubmoduleConfig struct {
 	Name string
 	Path string
 	Url  string
+
+	// RecordedSha is the commit that the parent repo has recorded for the submodule
+	RecordedSha string
+	// CheckedOutSha is the commit checked out in the submodule itself. It's blank
+	// if the submodule hasn't been initialized
+	CheckedOutSha string
+	// IsDirty is true when the submodule has uncommitted changes of its own
+	IsDirty bool
+}
+
+func (s *SubmoduleConfig) IsInitialized() bool {
+	return s.CheckedOutSha != ""
+}
+
+// HasNewCheckout is true when the submodule has a different commit checked out
+// to the one the parent repo has recorded
+func (s *SubmoduleConfig) HasNewCheckout() bool {
+	return s.IsInitialized() && s.CheckedOutSha != s.RecordedSha
+}
+
+func (s *SubmoduleConfig) ID() string {
+	return s.Name
+}
+
+func (s *SubmoduleConfig) Description() string {
+	return s.Name
 }
@@ -3,6 +3,13 @@ package models
 // Tag : A git tag
 type Tag struct {
 	Name string
+	// IsAnnotated is true for tags that are objects in their own right, as
+	// opposed to lightweight tags which are just a name for a commit
+	IsAnnotated bool
+	// Tagger and Message are only set for annotated tags. Message is just the
+	// subject of the tag's message
+	Tagger  string
+	Message string
 }
 
 func (t *Tag) RefName() string {
@@ -14,5 +21,8 @@ func (t *Tag) ID() string {
 }
 
 func (t *Tag) Description() string {
+	if t.IsAnnotated && t.Message != "" {
+		return "tag " + t.Name + ": " + t.Message
+	}
 	return "tag " + t.Name
 }
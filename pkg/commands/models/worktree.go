@@ -0,0 +1,31 @@
+package models
+
+// Worktree : A git worktree
+type Worktree struct {
+	Path string
+	Head string
+	// Branch is the short name of the checked out branch. It is blank when the
+	// worktree has a detached HEAD or is bare
+	Branch   string
+	IsMain   bool
+	IsBare   bool
+	IsLocked bool
+	// Prunable is true when the worktree's directory no longer exists
+	Prunable  bool
+	IsCurrent bool
+}
+
+func (w *Worktree) ShortHead() string {
+	if len(w.Head) < 8 {
+		return w.Head
+	}
+	return w.Head[:8]
+}
+
+func (w *Worktree) ID() string {
+	return w.Path
+}
+
+func (w *Worktree) Description() string {
+	return "worktree " + w.Path
+}
@@ -0,0 +1,126 @@
+package oscommands
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+)
+
+// when git or ssh need a username, password, passphrase or confirmation, they
+// run the program in GIT_ASKPASS or SSH_ASKPASS with the prompt as its
+// argument, and take whatever it prints as the answer. We point them at
+// lazygit itself, which then runs as a client (see app.Run) that sends the
+// prompt to us and prints the answer we send back. That way we show the real
+// question, whatever's asking it and whatever language it's in.
+//
+// We talk over a loopback TCP connection because unix sockets can't be relied
+// upon on windows. Any local process could connect to it, so the client has to
+// start by sending a token that only the commands we run know
+
+const (
+	// ASKPASS_ADDRESS_ENV_VAR tells the askpass client where to send the prompt
+	ASKPASS_ADDRESS_ENV_VAR = "LAZYGIT_ASKPASS_ADDRESS"
+	// ASKPASS_TOKEN_ENV_VAR holds the token the client sends before the prompt
+	ASKPASS_TOKEN_ENV_VAR = "LAZYGIT_ASKPASS_TOKEN"
+)
+
+// RunCommandWithCredentials runs a command that may need credentials, like a
+// push or a fetch. promptUserForCredential is given each prompt and returns the
+// user's answer
+func (c *OSCommand) RunCommandWithCredentials(command string, promptUserForCredential func(string) string) error {
+	token, err := newAskpassToken()
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+
+	go serveAskpassPrompts(listener, token, promptUserForCredential)
+
+	lazygitPath := c.lazygitExecutable()
+	cmd := c.ExecutableFromString(command)
+	cmd.Env = append(
+		cmd.Env,
+		"LAZYGIT_CLIENT_COMMAND=ASKPASS",
+		ASKPASS_ADDRESS_ENV_VAR+"="+listener.Addr().String(),
+		ASKPASS_TOKEN_ENV_VAR+"="+token,
+		"GIT_ASKPASS="+lazygitPath,
+		"SSH_ASKPASS="+lazygitPath,
+		// ssh only uses SSH_ASKPASS without a terminal unless we force it to
+		"SSH_ASKPASS_REQUIRE=force",
+	)
+	// we don't want anything prompting on the terminal underneath lazygit
+	detachFromTerminal(cmd)
+
+	return c.RunExecutable(cmd)
+}
+
+func newAskpassToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func serveAskpassPrompts(listener net.Listener, token string, promptUserForCredential func(string) string) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// the listener's been closed because the command has finished
+			return
+		}
+		handleAskpassPrompt(conn, token, promptUserForCredential)
+	}
+}
+
+// handleAskpassPrompt reads the token and the prompt, which the client ends by
+// closing its side of the connection, and sends back the answer. If the token
+// is wrong we hang up without asking the user anything
+func handleAskpassPrompt(conn net.Conn, token string, promptUserForCredential func(string) string) {
+	defer conn.Close()
+
+	message, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return
+	}
+
+	splitMessage := strings.SplitN(string(message), "\n", 2)
+	if len(splitMessage) != 2 || subtle.ConstantTimeCompare([]byte(splitMessage[0]), []byte(token)) != 1 {
+		return
+	}
+
+	_, _ = io.WriteString(conn, promptUserForCredential(splitMessage[1]))
+}
+
+// SendAskpassPrompt is the client side: it sends the prompt to the lazygit
+// that's running the command and returns the user's answer
+func SendAskpassPrompt(prompt string) (string, error) {
+	conn, err := net.Dial("tcp", os.Getenv(ASKPASS_ADDRESS_ENV_VAR))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	if _, err := io.WriteString(conn, os.Getenv(ASKPASS_TOKEN_ENV_VAR)+"\n"+prompt); err != nil {
+		return "", err
+	}
+	if err := conn.(*net.TCPConn).CloseWrite(); err != nil {
+		return "", err
+	}
+
+	answer, err := ioutil.ReadAll(conn)
+	if err != nil {
+		return "", err
+	}
+	return string(answer), nil
+}
@@ -0,0 +1,14 @@
+// +build !windows
+
+package oscommands
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// detachFromTerminal runs the command in its own session, so that it has no
+// terminal to prompt on and falls back to asking us
+func detachFromTerminal(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+}
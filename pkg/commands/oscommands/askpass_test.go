@@ -0,0 +1,43 @@
+package oscommands
+
+import (
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAskpassPromptRoundTrip is a function.
+func TestAskpassPromptRoundTrip(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+
+	prompts := []string{}
+	go serveAskpassPrompts(listener, "secret", func(prompt string) string {
+		prompts = append(prompts, prompt)
+		return "answer " + prompt[:4]
+	})
+
+	os.Setenv(ASKPASS_ADDRESS_ENV_VAR, listener.Addr().String())
+	defer os.Unsetenv(ASKPASS_ADDRESS_ENV_VAR)
+	os.Setenv(ASKPASS_TOKEN_ENV_VAR, "secret")
+	defer os.Unsetenv(ASKPASS_TOKEN_ENV_VAR)
+
+	hostKeyPrompt := "The authenticity of host 'github.com' can't be established.\nAre you sure you want to continue connecting (yes/no/[fingerprint])? "
+	for _, prompt := range []string{"Enter passphrase for key '/home/user/.ssh/id_ed25519': ", hostKeyPrompt} {
+		answer, err := SendAskpassPrompt(prompt)
+		assert.NoError(t, err)
+		assert.EqualValues(t, "answer "+prompt[:4], answer)
+	}
+
+	assert.EqualValues(t, []string{"Enter passphrase for key '/home/user/.ssh/id_ed25519': ", hostKeyPrompt}, prompts)
+
+	// without the token we don't get to ask the user anything
+	os.Setenv(ASKPASS_TOKEN_ENV_VAR, "guess")
+	answer, err := SendAskpassPrompt("Password for 'https://github.com': ")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "", answer)
+	assert.Len(t, prompts, 2)
+}
@@ -0,0 +1,9 @@
+package oscommands
+
+import (
+	"os/exec"
+)
+
+// detachFromTerminal does nothing on windows, where git and ssh don't prompt on
+// the console when they have an askpass program
+func detachFromTerminal(cmd *exec.Cmd) {}
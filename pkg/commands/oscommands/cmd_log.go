@@ -0,0 +1,157 @@
+package oscommands
+
+import (
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// we don't want the log growing forever given that we run git status every few
+// seconds, so once we've got this many commands we forget the oldest ones
+const maxCmdLogEntries = 2000
+
+// we only keep the start of a failed command's output. If you want the rest you
+// can always run the command yourself
+const maxCmdLogStderrLength = 500
+
+// CmdLogEntry is a command that we've run
+type CmdLogEntry struct {
+	Command  string
+	Time     time.Time
+	Duration time.Duration
+	ExitCode int
+	// Stderr is the (truncated) output of the command if it failed. We combine
+	// stdout and stderr when running commands, but given that it's a failure
+	// it's typically git complaining on stderr
+	Stderr string
+}
+
+// CmdLogAction is something the user did, along with the commands we ran in
+// order to do it. Commands we run of our own accord (e.g. to refresh the files
+// panel) are logged against a background action
+type CmdLogAction struct {
+	Name       string
+	Time       time.Time
+	Entries    []*CmdLogEntry
+	Background bool
+}
+
+// CmdLog is a record of the commands we've run, grouped by the user action
+// that triggered them
+type CmdLog struct {
+	mutex    sync.Mutex
+	actions  []*CmdLogAction
+	size     int
+	onChange func()
+}
+
+func NewCmdLog() *CmdLog {
+	return &CmdLog{onChange: func() {}}
+}
+
+// SetOnChange sets a function to be called whenever a command is logged. It's
+// called from whichever goroutine ran the command
+func (l *CmdLog) SetOnChange(onChange func()) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.onChange = onChange
+}
+
+// Actions returns a snapshot of the log, safe to read while commands are running
+func (l *CmdLog) Actions() []*CmdLogAction {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	actions := make([]*CmdLogAction, len(l.actions))
+	for i, action := range l.actions {
+		actionCopy := *action
+		actionCopy.Entries = append([]*CmdLogEntry{}, action.Entries...)
+		actions[i] = &actionCopy
+	}
+	return actions
+}
+
+// add logs an entry against the given action, or against the current
+// background action if there's no action
+func (l *CmdLog) add(action *CmdLogAction, entry *CmdLogEntry) {
+	l.mutex.Lock()
+
+	if action == nil {
+		if len(l.actions) > 0 && l.actions[len(l.actions)-1].Background {
+			action = l.actions[len(l.actions)-1]
+		} else {
+			action = &CmdLogAction{Time: entry.Time, Background: true}
+		}
+	}
+
+	if !l.contains(action) {
+		l.actions = append(l.actions, action)
+	}
+	action.Entries = append(action.Entries, entry)
+	l.size++
+
+	for l.size > maxCmdLogEntries {
+		oldest := l.actions[0]
+		oldest.Entries = oldest.Entries[1:]
+		if len(oldest.Entries) == 0 {
+			l.actions = l.actions[1:]
+		}
+		l.size--
+	}
+
+	onChange := l.onChange
+	l.mutex.Unlock()
+
+	onChange()
+}
+
+func (l *CmdLog) contains(action *CmdLogAction) bool {
+	// an action will almost always be one of the last few so we look from the end
+	for i := len(l.actions) - 1; i >= 0; i-- {
+		if l.actions[i] == action {
+			return true
+		}
+	}
+	return false
+}
+
+// WithAction returns a copy of the OSCommand which logs the commands it runs
+// against a new action with the given name
+func (c *OSCommand) WithAction(name string) *OSCommand {
+	newOSCommand := *c
+	newOSCommand.cmdLogAction = &CmdLogAction{Name: name, Time: time.Now()}
+	return &newOSCommand
+}
+
+// LogCommand adds a command we've run to the command log
+func (c *OSCommand) LogCommand(command string, start time.Time, output string, err error) {
+	entry := &CmdLogEntry{
+		Command:  command,
+		Time:     start,
+		Duration: time.Since(start),
+	}
+
+	if err != nil {
+		entry.ExitCode = -1
+		if exitError, ok := err.(*exec.ExitError); ok {
+			entry.ExitCode = exitError.ExitCode()
+		}
+
+		if output == "" {
+			output = err.Error()
+		}
+		entry.Stderr = truncateStderr(output)
+	}
+
+	c.CmdLog.add(c.cmdLogAction, entry)
+}
+
+func truncateStderr(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if len(stderr) <= maxCmdLogStderrLength {
+		return stderr
+	}
+	return stderr[:maxCmdLogStderrLength] + "..."
+}
@@ -0,0 +1,70 @@
+package oscommands
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestOSCommandLogsCommands is a function.
+func TestOSCommandLogsCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	_, _ = osCommand.RunCommandWithOutput("echo background")
+	_, _ = osCommand.RunCommandWithOutput("echo background again")
+
+	actionCommand := osCommand.WithAction("squash down")
+	_, _ = actionCommand.RunCommandWithOutput("echo first")
+	_ = actionCommand.RunCommand("rmdir unexisting-folder")
+
+	_, _ = osCommand.RunCommandWithOutput("echo more background")
+
+	actions := osCommand.CmdLog.Actions()
+	assert.Len(t, actions, 3)
+
+	assert.True(t, actions[0].Background)
+	assert.EqualValues(t, []string{"echo background", "echo background again"}, commandsOf(actions[0]))
+
+	assert.False(t, actions[1].Background)
+	assert.EqualValues(t, "squash down", actions[1].Name)
+	assert.EqualValues(t, []string{"echo first", "rmdir unexisting-folder"}, commandsOf(actions[1]))
+	assert.EqualValues(t, 0, actions[1].Entries[0].ExitCode)
+	assert.EqualValues(t, "", actions[1].Entries[0].Stderr)
+	assert.NotEqual(t, 0, actions[1].Entries[1].ExitCode)
+	assert.Regexp(t, "rmdir.*unexisting-folder.*", actions[1].Entries[1].Stderr)
+
+	assert.True(t, actions[2].Background)
+	assert.EqualValues(t, []string{"echo more background"}, commandsOf(actions[2]))
+}
+
+// TestCmdLogForgetsOldestCommands is a function.
+func TestCmdLogForgetsOldestCommands(t *testing.T) {
+	osCommand := NewDummyOSCommand()
+
+	osCommand.WithAction("first").LogCommand("git status", time.Now(), "", nil)
+	for i := 0; i < maxCmdLogEntries; i++ {
+		osCommand.WithAction("second").LogCommand("git status", time.Now(), "", nil)
+	}
+
+	actions := osCommand.CmdLog.Actions()
+	assert.Len(t, actions, maxCmdLogEntries)
+	assert.EqualValues(t, "second", actions[0].Name)
+}
+
+// TestTruncateStderr is a function.
+func TestTruncateStderr(t *testing.T) {
+	assert.EqualValues(t, "error: oops", truncateStderr("error: oops\n"))
+
+	long := strings.Repeat("a", maxCmdLogStderrLength+1)
+	assert.EqualValues(t, strings.Repeat("a", maxCmdLogStderrLength)+"...", truncateStderr(long))
+}
+
+func commandsOf(action *CmdLogAction) []string {
+	commands := make([]string, len(action.Entries))
+	for i, entry := range action.Entries {
+		commands[i] = entry.Command
+	}
+	return commands
+}
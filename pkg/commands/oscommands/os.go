@@ -7,10 +7,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/go-errors/errors"
 
@@ -43,6 +43,11 @@ type OSCommand struct {
 	BeforeExecuteCmd   func(*exec.Cmd)
 	GetGlobalGitConfig func(string) (string, error)
 	Getenv             func(string) string
+	CmdLog             *CmdLog
+
+	// cmdLogAction is the user action we log our commands against. If it's nil
+	// we're running commands of our own accord
+	cmdLogAction *CmdLogAction
 }
 
 // NewOSCommand os command runner
@@ -55,6 +60,7 @@ func NewOSCommand(log *logrus.Entry, config config.AppConfigurer) *OSCommand {
 		BeforeExecuteCmd:   func(*exec.Cmd) {},
 		GetGlobalGitConfig: gitconfig.Global,
 		Getenv:             os.Getenv,
+		CmdLog:             NewCmdLog(),
 	}
 }
 
@@ -76,7 +82,7 @@ func (c *OSCommand) RunCommandWithOutputWithOptions(command string, options RunC
 	c.Log.WithField("command", command).Info("RunCommand")
 	cmd := c.ExecutableFromString(command)
 	cmd.Env = append(cmd.Env, options.EnvVars...)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	return sanitisedCommandOutput(c.runAndLog(command, cmd))
 }
 
 func (c *OSCommand) RunCommandWithOptions(command string, options RunCommandOptions) error {
@@ -97,13 +103,21 @@ func (c *OSCommand) RunCommandWithOutput(formatString string, formatArgs ...inte
 	}
 	c.Log.WithField("command", command).Info("RunCommand")
 	cmd := c.ExecutableFromString(command)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	return sanitisedCommandOutput(c.runAndLog(command, cmd))
+}
+
+// runAndLog runs the command, adding it to the command log
+func (c *OSCommand) runAndLog(command string, cmd *exec.Cmd) ([]byte, error) {
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	c.LogCommand(command, start, string(output), err)
+	return output, err
 }
 
 // RunExecutableWithOutput runs an executable file and returns its output
 func (c *OSCommand) RunExecutableWithOutput(cmd *exec.Cmd) (string, error) {
 	c.BeforeExecuteCmd(cmd)
-	return sanitisedCommandOutput(cmd.CombinedOutput())
+	return sanitisedCommandOutput(c.runAndLog(strings.Join(cmd.Args, " "), cmd))
 }
 
 // RunExecutable runs an executable file and returns an error if there was one
@@ -134,37 +148,6 @@ func (c *OSCommand) ShellCommandFromString(commandStr string) *exec.Cmd {
 	return c.ExecutableFromString(shellCommand)
 }
 
-// RunCommandWithOutputLive runs RunCommandWithOutputLiveWrapper
-func (c *OSCommand) RunCommandWithOutputLive(command string, output func(string) string) error {
-	return RunCommandWithOutputLiveWrapper(c, command, output)
-}
-
-// DetectUnamePass detect a username / password question in a command
-// promptUserForCredential is a function that gets executed when this function detect you need to fillin a password
-// The promptUserForCredential argument will be "username" or "password" and expects the user's password or username back
-func (c *OSCommand) DetectUnamePass(command string, promptUserForCredential func(string) string) error {
-	ttyText := ""
-	errMessage := c.RunCommandWithOutputLive(command, func(word string) string {
-		ttyText = ttyText + " " + word
-
-		prompts := map[string]string{
-			`.+'s password:`:         "password",
-			`Password\s*for\s*'.+':`: "password",
-			`Username\s*for\s*'.+':`: "username",
-		}
-
-		for pattern, askFor := range prompts {
-			if match, _ := regexp.MatchString(pattern, ttyText); match {
-				ttyText = ""
-				return promptUserForCredential(askFor)
-			}
-		}
-
-		return ""
-	})
-	return errMessage
-}
-
 // RunCommand runs a command and just returns the error
 func (c *OSCommand) RunCommand(formatString string, formatArgs ...interface{}) error {
 	_, err := c.RunCommandWithOutput(formatString, formatArgs...)
@@ -188,8 +171,7 @@ func (c *OSCommand) RunDirectCommand(command string) (string, error) {
 	c.Log.WithField("command", command).Info("RunDirectCommand")
 
 	return sanitisedCommandOutput(
-		c.Command(c.Platform.Shell, c.Platform.ShellArg, command).
-			CombinedOutput(),
+		c.runAndLog(command, c.Command(c.Platform.Shell, c.Platform.ShellArg, command)),
 	)
 }
 
@@ -353,7 +335,7 @@ func (c *OSCommand) FileExists(path string) (bool, error) {
 // before running it
 func (c *OSCommand) RunPreparedCommand(cmd *exec.Cmd) error {
 	c.BeforeExecuteCmd(cmd)
-	out, err := cmd.CombinedOutput()
+	out, err := c.runAndLog(strings.Join(cmd.Args, " "), cmd)
 	outString := string(out)
 	c.Log.Info(outString)
 	if err != nil {
@@ -367,11 +349,17 @@ func (c *OSCommand) RunPreparedCommand(cmd *exec.Cmd) error {
 
 // GetLazygitPath returns the path of the currently executed file
 func (c *OSCommand) GetLazygitPath() string {
+	return `"` + c.lazygitExecutable() + `"`
+}
+
+// lazygitExecutable returns the unquoted path of lazygit, for programs that
+// run it directly rather than through a shell
+func (c *OSCommand) lazygitExecutable() string {
 	ex, err := os.Executable() // get the executable path for git to use
 	if err != nil {
 		ex = os.Args[0] // fallback to the first call argument if needed
 	}
-	return `"` + filepath.ToSlash(ex) + `"`
+	return filepath.ToSlash(ex)
 }
 
 // RunCustomCommand returns the pointer to a custom command
@@ -381,6 +369,7 @@ func (c *OSCommand) RunCustomCommand(command string) *exec.Cmd {
 
 // PipeCommands runs a heap of commands and pipes their inputs/outputs together like A | B | C
 func (c *OSCommand) PipeCommands(commandStrings ...string) error {
+	start := time.Now()
 
 	cmds := make([]*exec.Cmd, len(commandStrings))
 
@@ -433,10 +422,13 @@ func (c *OSCommand) PipeCommands(commandStrings ...string) error {
 
 	wg.Wait()
 
+	var err error
 	if len(finalErrors) > 0 {
-		return errors.New(strings.Join(finalErrors, "\n"))
+		err = errors.New(strings.Join(finalErrors, "\n"))
 	}
-	return nil
+	c.LogCommand(strings.Join(commandStrings, " | "), start, "", err)
+
+	return err
 }
 
 func Kill(cmd *exec.Cmd) error {
@@ -2,7 +2,9 @@ package patch
 
 import (
 	"errors"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -30,6 +32,18 @@ type loadFileDiffFunc func(from string, to string, reverse bool, filename string
 
 // PatchManager manages the building of a patch for a commit to be applied to another commit (or the working tree, or removed from the current commit). We also support building patches from things like stashes, for which there is less flexibility
 type PatchManager struct {
+	// the patch we're building lives in here so that copies made with
+	// WithApplyPatch share it
+	*patchState
+
+	Log        *logrus.Entry
+	ApplyPatch applyPatchFunc
+
+	// LoadFileDiff loads the diff of a file, for a given to (typically a commit SHA)
+	LoadFileDiff loadFileDiffFunc
+}
+
+type patchState struct {
 	// To is the commit sha if we're dealing with files of a commit, or a stash ref for a stash
 	To      string
 	From    string
@@ -41,22 +55,26 @@ type PatchManager struct {
 
 	// fileInfoMap starts empty but you add files to it as you go along
 	fileInfoMap map[string]*fileInfo
-	Log         *logrus.Entry
-	ApplyPatch  applyPatchFunc
-
-	// LoadFileDiff loads the diff of a file, for a given to (typically a commit SHA)
-	LoadFileDiff loadFileDiffFunc
 }
 
 // NewPatchManager returns a new PatchManager
 func NewPatchManager(log *logrus.Entry, applyPatch applyPatchFunc, loadFileDiff loadFileDiffFunc) *PatchManager {
 	return &PatchManager{
+		patchState:   &patchState{},
 		Log:          log,
 		ApplyPatch:   applyPatch,
 		LoadFileDiff: loadFileDiff,
 	}
 }
 
+// WithApplyPatch returns a copy of the PatchManager which applies patches with
+// the given function, but works on the same patch as the original
+func (p *PatchManager) WithApplyPatch(applyPatch applyPatchFunc) *PatchManager {
+	newPatchManager := *p
+	newPatchManager.ApplyPatch = applyPatch
+	return &newPatchManager
+}
+
 // NewPatchManager returns a new PatchManager
 func (p *PatchManager) Start(from, to string, reverse bool, canRebase bool) {
 	p.To = to
@@ -66,6 +84,60 @@ func (p *PatchManager) Start(from, to string, reverse bool, canRebase bool) {
 	p.fileInfoMap = map[string]*fileInfo{}
 }
 
+// LoadPatch starts a new patch from one we rendered earlier, e.g. a saved patch,
+// adding the whole of each file's diff. We no longer know which commit the
+// patch came from, so the patch can't be removed from it
+func (p *PatchManager) LoadPatch(patch string) {
+	p.Start("", "", false, false)
+
+	for _, filePatch := range splitPatchByFile(patch) {
+		info := &fileInfo{diff: filePatch.diff}
+		p.addFileWhole(info)
+		p.fileInfoMap[filePatch.filename] = info
+	}
+}
+
+type filePatch struct {
+	filename string
+	diff     string
+}
+
+var diffHeaderRegexp = regexp.MustCompile(`^diff --git a/.* b/(.*)$`)
+
+// splitPatchByFile splits an aggregated patch into the diffs of each file
+func splitPatchByFile(patch string) []filePatch {
+	filePatches := []filePatch{}
+	var lines []string
+
+	flush := func() {
+		// we separate the diffs of each file with a blank line, which we don't want
+		// to pass on to git apply
+		for len(lines) > 0 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+		if len(lines) == 0 {
+			return
+		}
+
+		filename := strconv.Itoa(len(filePatches))
+		if match := diffHeaderRegexp.FindStringSubmatch(lines[0]); match != nil {
+			filename = match[1]
+		}
+		filePatches = append(filePatches, filePatch{filename: filename, diff: strings.Join(lines, "\n") + "\n"})
+		lines = nil
+	}
+
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+		}
+		lines = append(lines, line)
+	}
+	flush()
+
+	return filePatches
+}
+
 func (p *PatchManager) addFileWhole(info *fileInfo) {
 	info.mode = WHOLE
 	lineCount := len(strings.Split(info.diff, "\n"))
@@ -81,6 +153,26 @@ func (p *PatchManager) removeFile(info *fileInfo) {
 	info.includedLineIndices = nil
 }
 
+// AddFileWhole adds the whole of a file to the patch
+func (p *PatchManager) AddFileWhole(filename string) error {
+	info, err := p.getFileInfo(filename)
+	if err != nil {
+		return err
+	}
+	p.addFileWhole(info)
+	return nil
+}
+
+// RemoveFile removes a file from the patch
+func (p *PatchManager) RemoveFile(filename string) error {
+	info, err := p.getFileInfo(filename)
+	if err != nil {
+		return err
+	}
+	p.removeFile(info)
+	return nil
+}
+
 func (p *PatchManager) ToggleFileWhole(filename string) error {
 	info, err := p.getFileInfo(filename)
 	if err != nil {
@@ -234,6 +326,12 @@ func (p *PatchManager) GetFileIncLineIndices(filename string) ([]int, error) {
 }
 
 func (p *PatchManager) ApplyPatches(reverse bool) error {
+	return p.ApplyPatchesWithFlags(reverse, "index", "3way")
+}
+
+// ApplyPatchesWithFlags is like ApplyPatches except that you choose the flags
+// passed to git apply, e.g. 'cached' to only apply the patch to the index
+func (p *PatchManager) ApplyPatchesWithFlags(reverse bool, flags ...string) error {
 	// for whole patches we'll apply the patch in reverse
 	// but for part patches we'll apply a reverse patch forwards
 	for filename, info := range p.fileInfoMap {
@@ -241,7 +339,7 @@ func (p *PatchManager) ApplyPatches(reverse bool) error {
 			continue
 		}
 
-		applyFlags := []string{"index", "3way"}
+		applyFlags := append([]string{}, flags...)
 		reverseOnGenerate := false
 		if reverse {
 			if info.mode == WHOLE {
@@ -0,0 +1,75 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const twoFileDiff = `diff --git a/apple b/apple
+index dcd3485..1ba5540 100644
+--- a/apple
++++ b/apple
+@@ -1 +1 @@
+-orange
++grape
+
+diff --git a/banana b/banana
+new file mode 100644
+index 0000000..1ba5540
+--- /dev/null
++++ b/banana
+@@ -0,0 +1 @@
++grape
+
+`
+
+// TestSplitPatchByFile is a function.
+func TestSplitPatchByFile(t *testing.T) {
+	filePatches := splitPatchByFile(twoFileDiff)
+
+	assert.EqualValues(t, []filePatch{
+		{
+			filename: "apple",
+			diff:     "diff --git a/apple b/apple\nindex dcd3485..1ba5540 100644\n--- a/apple\n+++ b/apple\n@@ -1 +1 @@\n-orange\n+grape\n",
+		},
+		{
+			filename: "banana",
+			diff:     "diff --git a/banana b/banana\nnew file mode 100644\nindex 0000000..1ba5540\n--- /dev/null\n+++ b/banana\n@@ -0,0 +1 @@\n+grape\n",
+		},
+	}, filePatches)
+
+	assert.Empty(t, splitPatchByFile(""))
+}
+
+// TestLoadPatch is a function.
+func TestLoadPatch(t *testing.T) {
+	p := NewPatchManager(nil, nil, nil)
+	p.LoadPatch(twoFileDiff)
+
+	assert.False(t, p.IsEmpty())
+	assert.EqualValues(t, WHOLE, p.GetFileStatus("apple"))
+	assert.EqualValues(t, WHOLE, p.GetFileStatus("banana"))
+
+	// rendering the loaded patch gives us back what we started with
+	assert.EqualValues(t, twoFileDiff, p.RenderAggregatedPatchColored(true))
+}
+
+// TestWithApplyPatch is a function.
+func TestWithApplyPatch(t *testing.T) {
+	p := NewPatchManager(nil, func(string, ...string) error { return nil }, nil)
+	p.LoadPatch(twoFileDiff)
+
+	applied := []string{}
+	copied := p.WithApplyPatch(func(patch string, flags ...string) error {
+		applied = append(applied, patch)
+		return nil
+	})
+	assert.NoError(t, copied.ApplyPatches(false))
+	assert.Len(t, applied, 2)
+
+	// resetting the copy resets the original, given they share the patch
+	copied.Reset()
+	assert.True(t, p.IsEmpty())
+	assert.False(t, p.Active())
+}
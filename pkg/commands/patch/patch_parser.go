@@ -26,6 +26,9 @@ const (
 type PatchLine struct {
 	Kind    int
 	Content string // something like '+ hello' (note the first character is not removed)
+	// changedSpans are the parts of a deletion or addition which differ from the
+	// line it's paired with, if any
+	changedSpans []span
 }
 
 type PatchParser struct {
@@ -113,10 +116,16 @@ func (l *PatchLine) render(selected bool, included bool) string {
 		colorAttr = theme.DefaultTextColor
 	}
 
-	return coloredString(colorAttr, content, selected, included)
+	return highlightedString(colorAttr, content, l.changedSpans, selected, included)
 }
 
 func coloredString(colorAttr color.Attribute, str string, selected bool, included bool) string {
+	return highlightedString(colorAttr, str, nil, selected, included)
+}
+
+// highlightedString is like coloredString except that the given spans of the
+// string are emphasised
+func highlightedString(colorAttr color.Attribute, str string, spans []span, selected bool, included bool) string {
 	var cl *color.Color
 	attributes := []color.Attribute{colorAttr}
 	if selected {
@@ -134,7 +143,7 @@ func coloredString(colorAttr color.Attribute, str string, selected bool, include
 		return utils.ColoredStringDirect(str, clIncluded)
 	}
 
-	return utils.ColoredStringDirect(str[:1], clIncluded) + utils.ColoredStringDirect(str[1:], cl)
+	return emphasisedString(str, spans, cl, clIncluded)
 }
 
 func parsePatch(patch string) ([]int, []int, []*PatchLine, error) {
@@ -183,6 +192,7 @@ func parsePatch(patch string) ([]int, []int, []*PatchLine, error) {
 		}
 		patchLines[index] = &PatchLine{Kind: lineKind, Content: line}
 	}
+	highlightChangedWords(patchLines)
 	return hunkStarts, stageableLines, patchLines, nil
 }
 
@@ -0,0 +1,381 @@
+package patch
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// the job of this file is to find which words changed between a deleted line
+// and the added line that replaced it, so that we can emphasise just those words
+// rather than leaving the user to spot the difference between two long lines.
+
+// a line is only ever paired with another line if they're in a block of
+// consecutive deletions followed by the same number of consecutive additions.
+// If the counts differ we can't tell which line replaced which.
+
+// beyond this many lines in a block we don't bother pairing, because it's
+// unlikely that the lines correspond to one another
+const maxWordDiffBlockSize = 50
+
+// beyond this many tokens in a pair of lines we don't bother, because the LCS
+// is quadratic in the number of tokens
+const maxWordDiffCells = 250000
+
+// span is a range of bytes [start, end) within a line
+type span struct {
+	start int
+	end   int
+}
+
+// tokenize splits a line into words, runs of whitespace, and individual
+// punctuation characters
+func tokenize(str string) []string {
+	tokens := []string{}
+	start := 0
+	kind := -1
+	for i, r := range str {
+		var runeKind int
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			runeKind = 0
+		case unicode.IsSpace(r):
+			runeKind = 1
+		default:
+			// each punctuation character is its own token
+			runeKind = 2
+		}
+
+		if i > 0 && (runeKind != kind || runeKind == 2) {
+			tokens = append(tokens, str[start:i])
+			start = i
+		}
+		kind = runeKind
+	}
+	if start < len(str) {
+		tokens = append(tokens, str[start:])
+	}
+	return tokens
+}
+
+// changedSpans returns the spans of each line which are not in the longest
+// common subsequence of the two lines' tokens. If the lines have too little in
+// common for highlighting to be useful, ok is false
+func changedSpans(oldLine string, newLine string) (oldSpans []span, newSpans []span, ok bool) {
+	oldTokens := tokenize(oldLine)
+	newTokens := tokenize(newLine)
+
+	// trimming the common prefix and suffix keeps the LCS table small in the
+	// typical case where only a small part of the line has changed
+	prefix := 0
+	for prefix < len(oldTokens) && prefix < len(newTokens) && oldTokens[prefix] == newTokens[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldTokens)-prefix && suffix < len(newTokens)-prefix &&
+		oldTokens[len(oldTokens)-1-suffix] == newTokens[len(newTokens)-1-suffix] {
+		suffix++
+	}
+
+	oldMiddle := oldTokens[prefix : len(oldTokens)-suffix]
+	newMiddle := newTokens[prefix : len(newTokens)-suffix]
+	if (len(oldMiddle)+1)*(len(newMiddle)+1) > maxWordDiffCells {
+		return nil, nil, false
+	}
+
+	oldKept, newKept := lcs(oldMiddle, newMiddle)
+
+	oldChanged := make([]bool, len(oldTokens))
+	for i := range oldMiddle {
+		oldChanged[prefix+i] = !oldKept[i]
+	}
+	newChanged := make([]bool, len(newTokens))
+	for i := range newMiddle {
+		newChanged[prefix+i] = !newKept[i]
+	}
+
+	oldSpans, oldUnchanged := spansFromTokens(oldTokens, oldChanged)
+	newSpans, newUnchanged := spansFromTokens(newTokens, newChanged)
+
+	// if most of the two lines differ then emphasising the changes just adds noise
+	if 2*(oldUnchanged+newUnchanged) < len(oldLine)+len(newLine) {
+		return nil, nil, false
+	}
+
+	return oldSpans, newSpans, true
+}
+
+// lcs returns, for each token of a and b, whether it's part of the longest
+// common subsequence of the two
+func lcs(a []string, b []string) ([]bool, []bool) {
+	table := make([][]int, len(a)+1)
+	for i := range table {
+		table[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	aKept := make([]bool, len(a))
+	bKept := make([]bool, len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if a[i] == b[j] {
+			aKept[i] = true
+			bKept[j] = true
+			i++
+			j++
+		} else if table[i+1][j] >= table[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return aKept, bKept
+}
+
+// spansFromTokens merges consecutive changed tokens into spans, also returning
+// how many bytes were left unchanged. Whitespace between two changed tokens is
+// counted as changed so that 'foo bar' -> 'baz qux' is one span rather than two
+func spansFromTokens(tokens []string, changed []bool) ([]span, int) {
+	spans := []span{}
+	unchanged := 0
+	offset := 0
+	for i, token := range tokens {
+		isChanged := changed[i]
+		if !isChanged && strings.TrimSpace(token) == "" && i > 0 && i < len(tokens)-1 && changed[i-1] && changed[i+1] {
+			isChanged = true
+		}
+
+		if !isChanged {
+			unchanged += len(token)
+		} else if len(spans) > 0 && spans[len(spans)-1].end == offset {
+			spans[len(spans)-1].end = offset + len(token)
+		} else {
+			spans = append(spans, span{start: offset, end: offset + len(token)})
+		}
+		offset += len(token)
+	}
+	return spans, unchanged
+}
+
+// pairChangedLines takes the content (without the leading '-' or '+') of a
+// block of deleted lines and the added lines which follow them, and returns the
+// changed spans of each line, offset by one to account for the leading
+// character. If the lines can't be paired, both return values are nil
+func pairChangedLines(deletions []string, additions []string) ([][]span, [][]span) {
+	if len(deletions) != len(additions) || len(deletions) == 0 || len(deletions) > maxWordDiffBlockSize {
+		return nil, nil
+	}
+
+	deletionSpans := make([][]span, len(deletions))
+	additionSpans := make([][]span, len(additions))
+	for i := range deletions {
+		oldSpans, newSpans, ok := changedSpans(deletions[i], additions[i])
+		if !ok {
+			continue
+		}
+		deletionSpans[i] = offsetSpans(oldSpans, 1)
+		additionSpans[i] = offsetSpans(newSpans, 1)
+	}
+	return deletionSpans, additionSpans
+}
+
+func offsetSpans(spans []span, offset int) []span {
+	result := make([]span, len(spans))
+	for i, s := range spans {
+		result[i] = span{start: s.start + offset, end: s.end + offset}
+	}
+	return result
+}
+
+// highlightChangedWords sets the changed spans on each paired deletion and
+// addition in the patch
+func highlightChangedWords(patchLines []*PatchLine) {
+	for i := 0; i < len(patchLines); {
+		if patchLines[i].Kind != DELETION {
+			i++
+			continue
+		}
+
+		deletionsStart := i
+		for i < len(patchLines) && patchLines[i].Kind == DELETION {
+			i++
+		}
+		additionsStart := i
+		for i < len(patchLines) && patchLines[i].Kind == ADDITION {
+			i++
+		}
+
+		deletions := patchLines[deletionsStart:additionsStart]
+		additions := patchLines[additionsStart:i]
+		deletionSpans, additionSpans := pairChangedLines(lineContents(deletions), lineContents(additions))
+		if deletionSpans == nil {
+			continue
+		}
+		for j := range deletions {
+			deletions[j].changedSpans = deletionSpans[j]
+			additions[j].changedSpans = additionSpans[j]
+		}
+	}
+}
+
+func lineContents(patchLines []*PatchLine) []string {
+	contents := make([]string, len(patchLines))
+	for i, patchLine := range patchLines {
+		if len(patchLine.Content) > 0 {
+			contents[i] = patchLine.Content[1:]
+		}
+	}
+	return contents
+}
+
+// emphasisedString renders a line with its changed spans in reverse video. The
+// first character is rendered with firstCharColour so that we can show whether
+// the line is included in a custom patch
+func emphasisedString(str string, spans []span, colour *color.Color, firstCharColour *color.Color) string {
+	emphasisColour := *colour
+	emphasisColour.Add(color.ReverseVideo)
+
+	if len(str) == 0 {
+		return ""
+	}
+
+	result := utils.ColoredStringDirect(str[:1], firstCharColour)
+	offset := 1
+	for _, s := range spans {
+		if s.start > offset {
+			result += utils.ColoredStringDirect(str[offset:s.start], colour)
+		}
+		result += utils.ColoredStringDirect(str[s.start:s.end], &emphasisColour)
+		offset = s.end
+	}
+	if offset < len(str) {
+		result += utils.ColoredStringDirect(str[offset:], colour)
+	}
+	return result
+}
+
+// WordDiffHighlighter adds word-level highlighting to a diff, for when we're
+// streaming the output of git diff or git show to the main view rather than
+// parsing the whole patch upfront. Deletions are held back until we know
+// whether they're followed by the same number of additions.
+type WordDiffHighlighter struct {
+	inHunk bool
+	// overflow is true when we've given up on a block for being too big, in
+	// which case we pass its lines straight through
+	overflow  bool
+	deletions []string
+	additions []string
+}
+
+func NewWordDiffHighlighter() *WordDiffHighlighter {
+	return &WordDiffHighlighter{}
+}
+
+// Write takes the next line of the diff and returns the lines which are now
+// ready to be rendered, if any
+func (h *WordDiffHighlighter) Write(line string) []string {
+	plain := utils.Decolorise(line)
+
+	if h.inHunk && !h.overflow {
+		switch {
+		case strings.HasPrefix(plain, "-"):
+			output := []string{}
+			if len(h.additions) > 0 {
+				output = h.Flush()
+			}
+			h.deletions = append(h.deletions, line)
+			if len(h.deletions) > maxWordDiffBlockSize {
+				output = append(output, h.Flush()...)
+				h.overflow = true
+			}
+			return output
+		case strings.HasPrefix(plain, "+"):
+			if len(h.deletions) == 0 {
+				return []string{line}
+			}
+			h.additions = append(h.additions, line)
+			if len(h.additions) > len(h.deletions) {
+				// the block can't be paired, and with no deletions held back
+				// any further additions will go straight through
+				return h.Flush()
+			}
+			return nil
+		}
+	}
+
+	if h.overflow && (strings.HasPrefix(plain, "-") || strings.HasPrefix(plain, "+")) {
+		return []string{line}
+	}
+	h.overflow = false
+
+	switch {
+	case strings.HasPrefix(plain, "@@@"):
+		// combined diffs of merge commits have more than one column of
+		// prefixes which we don't attempt to pair up
+		h.inHunk = false
+	case strings.HasPrefix(plain, "@@"):
+		h.inHunk = true
+	case strings.HasPrefix(plain, "diff") || strings.HasPrefix(plain, "commit"):
+		h.inHunk = false
+	}
+
+	return append(h.Flush(), line)
+}
+
+// Flush returns any lines which have been held back
+func (h *WordDiffHighlighter) Flush() []string {
+	deletions := h.deletions
+	additions := h.additions
+	h.deletions = nil
+	h.additions = nil
+
+	plainDeletions := make([]string, len(deletions))
+	for i, line := range deletions {
+		plainDeletions[i] = strings.TrimPrefix(utils.Decolorise(line), "-")
+	}
+	plainAdditions := make([]string, len(additions))
+	for i, line := range additions {
+		plainAdditions[i] = strings.TrimPrefix(utils.Decolorise(line), "+")
+	}
+
+	deletionSpans, additionSpans := pairChangedLines(plainDeletions, plainAdditions)
+	if deletionSpans == nil {
+		return append(deletions, additions...)
+	}
+
+	output := make([]string, 0, len(deletions)+len(additions))
+	for i, line := range deletions {
+		output = append(output, highlightStreamedLine(line, "-", plainDeletions[i], deletionSpans[i], color.FgRed))
+	}
+	for i, line := range additions {
+		output = append(output, highlightStreamedLine(line, "+", plainAdditions[i], additionSpans[i], color.FgGreen))
+	}
+	return output
+}
+
+// highlightStreamedLine re-renders a line of git's output with its changed
+// spans emphasised. We only colour the line if git did, so that we respect the
+// colorArg config
+func highlightStreamedLine(line string, prefix string, content string, spans []span, colorAttr color.Attribute) string {
+	if len(spans) == 0 {
+		return line
+	}
+
+	colour := color.New()
+	if line != prefix+content {
+		colour = color.New(colorAttr)
+	}
+	return emphasisedString(prefix+content, spans, colour, colour)
+}
@@ -0,0 +1,145 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTokenize is a function.
+func TestTokenize(t *testing.T) {
+	assert.EqualValues(t, []string{"foo", "(", "bar_1", ",", "  ", "baz", ")"}, tokenize("foo(bar_1,  baz)"))
+	assert.EqualValues(t, []string{}, tokenize(""))
+}
+
+// TestChangedSpans is a function.
+func TestChangedSpans(t *testing.T) {
+	type scenario struct {
+		testName         string
+		oldLine          string
+		newLine          string
+		expectedOk       bool
+		expectedOldSpans []span
+		expectedNewSpans []span
+	}
+
+	scenarios := []scenario{
+		{
+			"one changed argument",
+			"doSomething(apple, orange, banana)",
+			"doSomething(apple, grape, banana)",
+			true,
+			[]span{{start: 19, end: 25}},
+			[]span{{start: 19, end: 24}},
+		},
+		{
+			"added word",
+			"hello world",
+			"hello big world",
+			true,
+			[]span{},
+			[]span{{start: 6, end: 10}},
+		},
+		{
+			"adjacent changed words are merged",
+			"the quick brown fox jumps over the lazy dog",
+			"the slow red fox jumps over the lazy dog",
+			true,
+			[]span{{start: 4, end: 15}},
+			[]span{{start: 4, end: 12}},
+		},
+		{
+			"identical lines",
+			"same",
+			"same",
+			true,
+			[]span{},
+			[]span{},
+		},
+		{
+			"lines with too little in common",
+			"return nil",
+			"for _, file := range files {",
+			false,
+			nil,
+			nil,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			oldSpans, newSpans, ok := changedSpans(s.oldLine, s.newLine)
+			assert.EqualValues(t, s.expectedOk, ok)
+			assert.EqualValues(t, s.expectedOldSpans, oldSpans)
+			assert.EqualValues(t, s.expectedNewSpans, newSpans)
+		})
+	}
+}
+
+// TestHighlightChangedWords is a function.
+func TestHighlightChangedWords(t *testing.T) {
+	diff := `diff --git a/filename b/filename
+index dcd3485..1ba5540 100644
+--- a/filename
++++ b/filename
+@@ -1,6 +1,6 @@
+ apple
+-orange juice
++grape juice
+ ...
+-one
+-two
++three
+`
+
+	_, _, patchLines, err := parsePatch(diff)
+	assert.NoError(t, err)
+
+	// the paired lines have their changed words highlighted, offset by the
+	// leading character
+	assert.EqualValues(t, []span{{start: 1, end: 7}}, patchLines[6].changedSpans)
+	assert.EqualValues(t, []span{{start: 1, end: 6}}, patchLines[7].changedSpans)
+
+	// two deletions followed by one addition can't be paired
+	assert.Nil(t, patchLines[9].changedSpans)
+	assert.Nil(t, patchLines[10].changedSpans)
+	assert.Nil(t, patchLines[11].changedSpans)
+
+	// the headers aren't mistaken for a deletion and an addition
+	assert.Nil(t, patchLines[2].changedSpans)
+	assert.Nil(t, patchLines[3].changedSpans)
+}
+
+// TestWordDiffHighlighter is a function.
+func TestWordDiffHighlighter(t *testing.T) {
+	highlighter := NewWordDiffHighlighter()
+
+	write := func(line string) []string {
+		return highlighter.Write(line)
+	}
+
+	assert.EqualValues(t, []string{"diff --git a/filename b/filename"}, write("diff --git a/filename b/filename"))
+	assert.EqualValues(t, []string{"--- a/filename"}, write("--- a/filename"))
+	assert.EqualValues(t, []string{"+++ b/filename"}, write("+++ b/filename"))
+	assert.EqualValues(t, []string{"@@ -1,3 +1,3 @@"}, write("@@ -1,3 +1,3 @@"))
+
+	// deletions and the additions that follow are held back until the block ends
+	assert.Empty(t, write("-orange juice"))
+	assert.Empty(t, write("+grape juice"))
+	output := write(" apple")
+	assert.Len(t, output, 3)
+	assert.EqualValues(t, " apple", output[2])
+
+	// an addition without a preceding deletion goes straight through
+	assert.EqualValues(t, []string{"+banana"}, write("+banana"))
+
+	// once there are more additions than deletions we stop holding lines back
+	assert.Empty(t, write("-one"))
+	assert.EqualValues(t, []string(nil), write("+two"))
+	assert.EqualValues(t, []string{"-one", "+two", "+three"}, write("+three"))
+	assert.EqualValues(t, []string{"+four"}, write("+four"))
+
+	// anything left is returned when flushing
+	assert.Empty(t, write("-five"))
+	assert.EqualValues(t, []string{"-five"}, highlighter.Flush())
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-errors/errors"
+)
+
+// patch files are the ones other people send you, usually made with git
+// format-patch. A mailbox can be applied with git am, which makes a commit for
+// each patch in it, whereas git apply just applies the changes
+
+// the extensions of files we'll offer to apply
+var patchFileExtensions = []string{".patch", ".diff", ".mbox", ".eml"}
+
+// GetPatchFiles returns the patch files in the top level of the repo, which is
+// where git format-patch puts them by default
+func (c *GitCommand) GetPatchFiles() ([]string, error) {
+	fileInfos, err := ioutil.ReadDir(".")
+	if err != nil {
+		return nil, errors.Wrap(err, 0)
+	}
+
+	patchFiles := []string{}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || !isPatchFile(fileInfo.Name()) {
+			continue
+		}
+		patchFiles = append(patchFiles, fileInfo.Name())
+	}
+
+	sort.Strings(patchFiles)
+
+	return patchFiles, nil
+}
+
+func isPatchFile(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	for _, patchFileExtension := range patchFileExtensions {
+		if extension == patchFileExtension {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyMailbox makes a commit for each patch in the mailbox. With threeWay, git
+// falls back to a three-way merge when a patch doesn't apply cleanly, leaving
+// conflicts to resolve rather than failing outright
+func (c *GitCommand) ApplyMailbox(path string, threeWay bool) error {
+	threeWayFlag := ""
+	if threeWay {
+		threeWayFlag = " --3way"
+	}
+
+	return c.OSCommand.RunCommand("git am%s %s", threeWayFlag, c.OSCommand.Quote(path))
+}
+
+// ApplyPatchFile applies the patch to both the working tree and the index,
+// without making a commit
+func (c *GitCommand) ApplyPatchFile(path string) error {
+	return c.OSCommand.RunCommand("git apply --index %s", c.OSCommand.Quote(path))
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandApplyMailbox is a function.
+func TestGitCommandApplyMailbox(t *testing.T) {
+	type scenario struct {
+		testName     string
+		threeWay     bool
+		expectedArgs []string
+	}
+
+	scenarios := []scenario{
+		{
+			"without a three-way merge",
+			false,
+			[]string{"am", "fixes.mbox"},
+		},
+		{
+			"with a three-way merge",
+			true,
+			[]string{"am", "--3way", "fixes.mbox"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			gitCmd := NewDummyGitCommand()
+			gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+				assert.EqualValues(t, "git", cmd)
+				assert.EqualValues(t, s.expectedArgs, args)
+
+				return exec.Command("echo")
+			}
+
+			assert.NoError(t, gitCmd.ApplyMailbox("fixes.mbox", s.threeWay))
+		})
+	}
+}
+
+// TestGitCommandApplyPatchFile is a function.
+func TestGitCommandApplyPatchFile(t *testing.T) {
+	gitCmd := NewDummyGitCommand()
+	gitCmd.OSCommand.Command = func(cmd string, args ...string) *exec.Cmd {
+		assert.EqualValues(t, "git", cmd)
+		assert.EqualValues(t, []string{"apply", "--index", "0001-fix.patch"}, args)
+
+		return exec.Command("echo")
+	}
+
+	assert.NoError(t, gitCmd.ApplyPatchFile("0001-fix.patch"))
+}
+
+// TestIsPatchFile is a function.
+func TestIsPatchFile(t *testing.T) {
+	assert.True(t, isPatchFile("0001-fix.patch"))
+	assert.True(t, isPatchFile("series.MBOX"))
+	assert.True(t, isPatchFile("review.eml"))
+	assert.False(t, isPatchFile("main.go"))
+	assert.False(t, isPatchFile("patch"))
+}
@@ -10,12 +10,17 @@ import (
 
 // DeletePatchesFromCommit applies a patch in reverse for a commit
 func (c *GitCommand) DeletePatchesFromCommit(commits []*models.Commit, commitIndex int, p *patch.PatchManager) error {
+	return c.ApplyPatchesToCommit(commits, commitIndex, p, true)
+}
+
+// ApplyPatchesToCommit amends a commit with the patch, or with the patch in
+// reverse, rebasing the commits above it
+func (c *GitCommand) ApplyPatchesToCommit(commits []*models.Commit, commitIndex int, p *patch.PatchManager, reverse bool) error {
 	if err := c.BeginInteractiveRebaseForCommit(commits, commitIndex); err != nil {
 		return err
 	}
 
-	// apply each patch in reverse
-	if err := p.ApplyPatches(true); err != nil {
+	if err := p.ApplyPatches(reverse); err != nil {
 		if err := c.GenericMergeOrRebaseAction("rebase", "abort"); err != nil {
 			return err
 		}
@@ -27,8 +32,8 @@ func (c *GitCommand) DeletePatchesFromCommit(commits []*models.Commit, commitInd
 		return err
 	}
 
-	c.onSuccessfulContinue = func() error {
-		c.PatchManager.Reset()
+	c.mergeState.onSuccessfulContinue = func() error {
+		p.Reset()
 		return nil
 	}
 
@@ -55,7 +60,7 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 			return err
 		}
 
-		c.onSuccessfulContinue = func() error {
+		c.mergeState.onSuccessfulContinue = func() error {
 			c.PatchManager.Reset()
 			return nil
 		}
@@ -107,11 +112,11 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 		return err
 	}
 
-	if c.onSuccessfulContinue != nil {
+	if c.mergeState.onSuccessfulContinue != nil {
 		return errors.New("You are midway through another rebase operation. Please abort to start again")
 	}
 
-	c.onSuccessfulContinue = func() error {
+	c.mergeState.onSuccessfulContinue = func() error {
 		// now we should be up to the destination, so let's apply forward these patches to that.
 		// ideally we would ensure we're on the right commit but I'm not sure if that check is necessary
 		if err := p.ApplyPatches(false); err != nil {
@@ -126,7 +131,7 @@ func (c *GitCommand) MovePatchToSelectedCommit(commits []*models.Commit, sourceC
 			return err
 		}
 
-		c.onSuccessfulContinue = func() error {
+		c.mergeState.onSuccessfulContinue = func() error {
 			c.PatchManager.Reset()
 			return nil
 		}
@@ -162,11 +167,11 @@ func (c *GitCommand) PullPatchIntoIndex(commits []*models.Commit, commitIdx int,
 		return err
 	}
 
-	if c.onSuccessfulContinue != nil {
+	if c.mergeState.onSuccessfulContinue != nil {
 		return errors.New("You are midway through another rebase operation. Please abort to start again")
 	}
 
-	c.onSuccessfulContinue = func() error {
+	c.mergeState.onSuccessfulContinue = func() error {
 		// add patches to index
 		if err := p.ApplyPatches(false); err != nil {
 			if c.WorkingTreeState() == "rebasing" {
@@ -222,7 +227,7 @@ func (c *GitCommand) PullPatchIntoNewCommit(commits []*models.Commit, commitIdx
 		return err
 	}
 
-	if c.onSuccessfulContinue != nil {
+	if c.mergeState.onSuccessfulContinue != nil {
 		return errors.New("You are midway through another rebase operation. Please abort to start again")
 	}
 
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/forges"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/config"
 )
@@ -118,10 +119,83 @@ func (pr *PullRequest) Create(branch *models.Branch) error {
 	))
 }
 
+// GetForge returns a provider for the API of the forge that the repo's origin
+// remote is hosted on. Using the API is optional, so if the user hasn't given
+// us a token we return nil
+func (c *GitCommand) GetForge() (forges.Provider, error) {
+	userConfig := c.Config.GetUserConfig()
+	token := userConfig.GetString("forge.token")
+	if token == "" {
+		return nil, nil
+	}
+
+	repoURL := c.GetRemoteURL()
+	if repoURL == "" {
+		return nil, errors.New(c.Tr.SLocalize("NoOriginRemote"))
+	}
+
+	domain := getDomainFromURL(repoURL)
+	provider := userConfig.GetString("forge.provider")
+	if provider == "" {
+		provider = forges.ProviderForDomain(domain)
+	}
+	// a service configured for the domain tells us the provider, and where
+	// its web interface (and so its API) lives
+	for repoDomain, typeAndDomain := range userConfig.GetStringMapString("services") {
+		splitData := strings.Split(typeAndDomain, ":")
+		if repoDomain == domain && len(splitData) == 2 {
+			if provider == "" {
+				provider = splitData[0]
+			}
+			domain = splitData[1]
+		}
+	}
+	if provider == "" {
+		return nil, errors.New(c.Tr.SLocalize("UnsupportedGitService"))
+	}
+
+	repoInfo := getRepoInfoFromURL(repoURL)
+
+	return forges.NewProvider(
+		forges.Config{
+			Provider: provider,
+			Domain:   domain,
+			APIURL:   userConfig.GetString("forge.apiUrl"),
+			Token:    token,
+			Owner:    repoInfo.Owner,
+			Repo:     repoInfo.Repository,
+		},
+		forges.NewHTTPClient(),
+	)
+}
+
+// getDomainFromURL returns the host of a remote url, which may be in the
+// scheme://host/path form (e.g. https or ssh) or the scp-like ssh form
+func getDomainFromURL(url string) string {
+	if i := strings.Index(url, "://"); i != -1 {
+		host := strings.Split(url[i+len("://"):], "/")[0]
+		if i := strings.LastIndex(host, "@"); i != -1 {
+			host = host[i+1:]
+		}
+		// an ssh port has nothing to do with where the forge's web interface is
+		if !strings.HasPrefix(url, "http") {
+			host = strings.Split(host, ":")[0]
+		}
+		return host
+	}
+
+	host := strings.Split(url, ":")[0]
+	if i := strings.LastIndex(host, "@"); i != -1 {
+		host = host[i+1:]
+	}
+	return host
+}
+
 func getRepoInfoFromURL(url string) *RepoInformation {
-	isHTTP := strings.HasPrefix(url, "http")
+	// urls with a scheme, like https:// or ssh://, have the path after the host
+	hasScheme := strings.Contains(url, "://")
 
-	if isHTTP {
+	if hasScheme {
 		splits := strings.Split(url, "/")
 		owner := strings.Join(splits[3:len(splits)-1], "/")
 		repo := strings.TrimSuffix(splits[len(splits)-1], ".git")
@@ -34,6 +34,14 @@ func TestGetRepoInfoFromURL(t *testing.T) {
 				assert.EqualValues(t, repoInfo.Repository, "social_network")
 			},
 		},
+		{
+			"Returns repository information for ssh remote url with a scheme",
+			"ssh://git@gitlab.example.com:2222/group/subgroup/project.git",
+			func(repoInfo *RepoInformation) {
+				assert.EqualValues(t, repoInfo.Owner, "group/subgroup")
+				assert.EqualValues(t, repoInfo.Repository, "project")
+			},
+		},
 	}
 
 	for _, s := range scenarios {
@@ -43,6 +51,49 @@ func TestGetRepoInfoFromURL(t *testing.T) {
 	}
 }
 
+// TestGetDomainFromURL is a function.
+func TestGetDomainFromURL(t *testing.T) {
+	type scenario struct {
+		testName string
+		repoURL  string
+		expected string
+	}
+
+	scenarios := []scenario{
+		{
+			"ssh remote url",
+			"git@github.com:petersmith/super_calculator",
+			"github.com",
+		},
+		{
+			"http remote url with username",
+			"https://my_username@bitbucket.org/johndoe/social_network.git",
+			"bitbucket.org",
+		},
+		{
+			"http remote url",
+			"https://gitea.example.com/johndoe/social_network.git",
+			"gitea.example.com",
+		},
+		{
+			"ssh remote url with a scheme",
+			"ssh://git@github.com/petersmith/super_calculator.git",
+			"github.com",
+		},
+		{
+			"ssh remote url with a scheme and a port",
+			"ssh://git@gitlab.example.com:2222/group/project.git",
+			"gitlab.example.com",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, getDomainFromURL(s.repoURL))
+		})
+	}
+}
+
 // TestCreatePullRequest is a function.
 func TestCreatePullRequest(t *testing.T) {
 	type scenario struct {
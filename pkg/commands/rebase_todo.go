@@ -0,0 +1,271 @@
+package commands
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+)
+
+// the rebase todo is what git has left to do in an interactive rebase. As well
+// as picking commits, it can run commands ('exec'), stop ('break'), and when
+// rebasing with --rebase-merges, recreate merges using labels ('label',
+// 'reset' and 'merge'). The label lines refer to each other, so we never change
+// them, and we only ever change the action of lines that pick a commit
+
+// todoItem is a line of the todo that does something, as opposed to a blank line
+// or a comment
+type todoItem struct {
+	// lineIndex is the index of the line in the todo file
+	lineIndex int
+	action    string
+	// args is everything after the action, e.g. the sha and subject of a commit
+	args string
+}
+
+// git lets you abbreviate actions, and will do so itself if you've set
+// rebase.abbreviateCommands
+var todoActionAbbreviations = map[string]string{
+	"p": "pick",
+	"r": "reword",
+	"e": "edit",
+	"s": "squash",
+	"f": "fixup",
+	"d": "drop",
+	"x": "exec",
+	"b": "break",
+	"l": "label",
+	"t": "reset",
+	"m": "merge",
+}
+
+// the actions that other lines depend on, meaning we can't move or change them
+// without breaking the rebase
+var labelTodoActions = map[string]bool{
+	"label": true,
+	"reset": true,
+	"merge": true,
+}
+
+func parseTodoItems(lines []string) []todoItem {
+	items := []todoItem{}
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || line == "noop" {
+			continue
+		}
+
+		action, args := line, ""
+		if spaceIndex := strings.Index(line, " "); spaceIndex != -1 {
+			action, args = line[:spaceIndex], strings.TrimSpace(line[spaceIndex+1:])
+		}
+		if fullAction, ok := todoActionAbbreviations[action]; ok {
+			action = fullAction
+		}
+
+		items = append(items, todoItem{lineIndex: i, action: action, args: args})
+	}
+	return items
+}
+
+// commitArgs returns the args of a line that picks a commit, starting from the
+// sha. 'fixup -C <sha>' and 'fixup -c <sha>', which take the message of the
+// fixup commit, have a flag before the sha that we leave out
+func (item todoItem) commitArgs() string {
+	if item.action == "fixup" && (strings.HasPrefix(item.args, "-C ") || strings.HasPrefix(item.args, "-c ")) {
+		return strings.TrimSpace(item.args[3:])
+	}
+	return item.args
+}
+
+// commit returns the todo item as we show it in the commits panel. Lines that
+// don't pick a commit get an empty sha, except for merges which point to the
+// merge commit they're recreating, if there is one
+func (item todoItem) commit() *models.Commit {
+	commit := &models.Commit{
+		Status: "rebasing",
+		Action: item.action,
+	}
+
+	switch {
+	case models.IsCommitTodoAction(item.action):
+		splitArgs := strings.SplitN(item.commitArgs(), " ", 2)
+		commit.Sha = splitArgs[0]
+		if len(splitArgs) > 1 {
+			commit.Name = splitArgs[1]
+		}
+	case item.action == "merge" && (strings.HasPrefix(item.args, "-C ") || strings.HasPrefix(item.args, "-c ")):
+		// e.g. 'merge -C 1d7a4c2 feature # Merge branch 'feature''
+		splitArgs := strings.SplitN(item.args, " ", 3)
+		if len(splitArgs) > 1 {
+			commit.Sha = splitArgs[1]
+		}
+		if len(splitArgs) > 2 {
+			commit.Name = splitArgs[2]
+		}
+	default:
+		commit.Name = item.args
+	}
+
+	return commit
+}
+
+func (c *GitCommand) rebaseTodoPath() string {
+	return filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
+}
+
+func (c *GitCommand) readRebaseTodo() ([]string, []todoItem, error) {
+	bytes, err := ioutil.ReadFile(c.rebaseTodoPath())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := strings.Split(string(bytes), "\n")
+	return lines, parseTodoItems(lines), nil
+}
+
+func (c *GitCommand) writeRebaseTodo(lines []string) error {
+	return ioutil.WriteFile(c.rebaseTodoPath(), []byte(strings.Join(lines, "\n")), 0644)
+}
+
+// todoItemAtIndex returns the todo item at the given index in the commits
+// panel. We have the most recent commit at the top whereas the todo file has
+// it at the bottom, so we count from the end
+func (c *GitCommand) todoItemAtIndex(items []todoItem, index int) (todoItem, error) {
+	itemIndex := len(items) - 1 - index
+	if itemIndex < 0 || itemIndex >= len(items) {
+		return todoItem{}, errors.New(c.Tr.SLocalize("TodoItemNotFound"))
+	}
+	return items[itemIndex], nil
+}
+
+// EditRebaseTodo sets the action at a given index in the git-rebase-todo file
+func (c *GitCommand) EditRebaseTodo(index int, action string) error {
+	lines, items, err := c.readRebaseTodo()
+	if err != nil {
+		return err
+	}
+
+	item, err := c.todoItemAtIndex(items, index)
+	if err != nil {
+		return err
+	}
+	if !models.IsCommitTodoAction(item.action) {
+		return errors.New(c.Tr.TemplateLocalize("CantChangeTodoAction", i18n.Teml{"action": item.action}))
+	}
+
+	args := item.args
+	if action != item.action {
+		// the flag of 'fixup -C' means nothing to the other actions
+		args = item.commitArgs()
+	}
+	lines[item.lineIndex] = action + " " + args
+
+	return c.writeRebaseTodo(lines)
+}
+
+// MoveTodoDown moves a rebase todo item down by one position
+func (c *GitCommand) MoveTodoDown(index int) error {
+	lines, items, err := c.readRebaseTodo()
+	if err != nil {
+		return err
+	}
+
+	item, err := c.todoItemAtIndex(items, index)
+	if err != nil {
+		return err
+	}
+	// the item below it in the commits panel is the one before it in the file
+	itemBelow, err := c.todoItemAtIndex(items, index+1)
+	if err != nil {
+		return err
+	}
+	for _, action := range []string{item.action, itemBelow.action} {
+		if labelTodoActions[action] {
+			return errors.New(c.Tr.TemplateLocalize("CantMoveTodoAction", i18n.Teml{"action": action}))
+		}
+	}
+
+	lines[item.lineIndex], lines[itemBelow.lineIndex] = lines[itemBelow.lineIndex], lines[item.lineIndex]
+
+	return c.writeRebaseTodo(lines)
+}
+
+// InsertTodoLine adds a line like 'exec make test' or 'break' to the todo, to
+// be done straight after the item at the given index. In the commits panel,
+// it'll appear above that item
+func (c *GitCommand) InsertTodoLine(index int, line string) error {
+	lines, items, err := c.readRebaseTodo()
+	if err != nil {
+		return err
+	}
+
+	item, err := c.todoItemAtIndex(items, index)
+	if err != nil {
+		return err
+	}
+
+	return c.writeRebaseTodo(insertLine(lines, item.lineIndex+1, line))
+}
+
+// DeleteTodoLine removes a line that doesn't pick a commit, like an 'exec' or
+// a 'break', from the todo
+func (c *GitCommand) DeleteTodoLine(index int) error {
+	lines, items, err := c.readRebaseTodo()
+	if err != nil {
+		return err
+	}
+
+	item, err := c.todoItemAtIndex(items, index)
+	if err != nil {
+		return err
+	}
+	if models.IsCommitTodoAction(item.action) || labelTodoActions[item.action] {
+		return errors.New(c.Tr.TemplateLocalize("CantChangeTodoAction", i18n.Teml{"action": item.action}))
+	}
+
+	return c.writeRebaseTodo(append(lines[:item.lineIndex], lines[item.lineIndex+1:]...))
+}
+
+// AddExecToEachTodoCommit runs the command after each commit left in the todo,
+// like 'git rebase --exec' does
+func (c *GitCommand) AddExecToEachTodoCommit(command string) error {
+	lines, _, err := c.readRebaseTodo()
+	if err != nil {
+		return err
+	}
+
+	return c.writeRebaseTodo(addExecToEachCommit(lines, command))
+}
+
+// addExecToEachCommit adds an exec line after each commit that's kept. As
+// with 'git rebase --exec', squashes and fixups are treated as part of the
+// commit they're squashed into, so the command runs after the last of them
+func addExecToEachCommit(lines []string, command string) []string {
+	items := parseTodoItems(lines)
+	execLine := "exec " + command
+
+	// going backwards so that inserting lines doesn't move the ones to come
+	for i := len(items) - 1; i >= 0; i-- {
+		item := items[i]
+		if !models.IsCommitTodoAction(item.action) || item.action == "drop" {
+			continue
+		}
+		if i+1 < len(items) && (items[i+1].action == "squash" || items[i+1].action == "fixup") {
+			continue
+		}
+		lines = insertLine(lines, item.lineIndex+1, execLine)
+	}
+
+	return lines
+}
+
+func insertLine(lines []string, index int, line string) []string {
+	result := make([]string, 0, len(lines)+1)
+	result = append(result, lines[:index]...)
+	result = append(result, line)
+	return append(result, lines[index:]...)
+}
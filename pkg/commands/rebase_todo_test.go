@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestTodoItemCommit is a function.
+func TestTodoItemCommit(t *testing.T) {
+	todo := strings.Join([]string{
+		"label onto",
+		"",
+		"# Branch feature",
+		"reset onto",
+		"p 1234567 feature commit",
+		"exec make test",
+		"fixup -C 89abcde amend! feature commit",
+		"label feature",
+		"",
+		"reset onto",
+		"merge -C abcdef0 feature # Merge branch 'feature'",
+		"break",
+		"",
+		"# Rebase 1234567..abcdef0 onto 7654321 (6 commands)",
+	}, "\n")
+
+	commits := []*models.Commit{}
+	for _, item := range parseTodoItems(strings.Split(todo, "\n")) {
+		commits = append(commits, item.commit())
+	}
+
+	expected := []*models.Commit{
+		{Status: "rebasing", Action: "label", Name: "onto"},
+		{Status: "rebasing", Action: "reset", Name: "onto"},
+		{Status: "rebasing", Action: "pick", Sha: "1234567", Name: "feature commit"},
+		{Status: "rebasing", Action: "exec", Name: "make test"},
+		{Status: "rebasing", Action: "fixup", Sha: "89abcde", Name: "amend! feature commit"},
+		{Status: "rebasing", Action: "label", Name: "feature"},
+		{Status: "rebasing", Action: "reset", Name: "onto"},
+		{Status: "rebasing", Action: "merge", Sha: "abcdef0", Name: "feature # Merge branch 'feature'"},
+		{Status: "rebasing", Action: "break"},
+	}
+
+	assert.EqualValues(t, expected, commits)
+	assert.False(t, commits[2].IsTodo())
+	assert.True(t, commits[3].IsTodo())
+}
+
+// TestAddExecToEachCommit is a function.
+func TestAddExecToEachCommit(t *testing.T) {
+	type scenario struct {
+		testName string
+		todo     []string
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"picks",
+			[]string{"pick a1 one", "pick b2 two", ""},
+			[]string{"pick a1 one", "exec make test", "pick b2 two", "exec make test", ""},
+		},
+		{
+			"squashes and fixups belong to the commit before them",
+			[]string{"pick a1 one", "squash b2 two", "fixup c3 three", "pick d4 four"},
+			[]string{"pick a1 one", "squash b2 two", "fixup c3 three", "exec make test", "pick d4 four", "exec make test"},
+		},
+		{
+			"drops and other lines are left alone",
+			[]string{"pick a1 one", "drop b2 two", "break", "# comment"},
+			[]string{"pick a1 one", "exec make test", "drop b2 two", "break", "# comment"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, addExecToEachCommit(s.todo, "make test"))
+		})
+	}
+}
@@ -2,10 +2,8 @@ package commands
 
 import (
 	"fmt"
-	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
 
 	"github.com/go-errors/errors"
@@ -44,10 +42,34 @@ func (c *GitCommand) MoveCommitDown(commits []*models.Commit, index int) error {
 }
 
 func (c *GitCommand) InteractiveRebase(commits []*models.Commit, index int, action string) error {
-	todo, sha, err := c.GenerateGenericRebaseTodo(commits, index, action)
+	return c.InteractiveRebaseRange(commits, index, index, action)
+}
+
+// InteractiveRebaseRange applies the action to each commit from start to end
+// inclusive, in one rebase
+func (c *GitCommand) InteractiveRebaseRange(commits []*models.Commit, start int, end int, action string) error {
+	todo, sha, err := c.GenerateRangeRebaseTodo(commits, start, end, action)
+	if err != nil {
+		return err
+	}
+
+	cmd, err := c.PrepareInteractiveRebaseCommand(sha, todo, true)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
+// InteractiveRebaseWithExec runs the command after the selected commit and
+// each commit above it, stopping the rebase if it fails, e.g. to check that
+// the tests pass at every commit
+func (c *GitCommand) InteractiveRebaseWithExec(commits []*models.Commit, index int, command string) error {
+	todo, sha, err := c.GenerateGenericRebaseTodo(commits, index, "pick")
 	if err != nil {
 		return err
 	}
+	todo = strings.Join(addExecToEachCommit(strings.Split(todo, "\n"), command), "\n")
 
 	cmd, err := c.PrepareInteractiveRebaseCommand(sha, todo, true)
 	if err != nil {
@@ -60,7 +82,7 @@ func (c *GitCommand) InteractiveRebase(commits []*models.Commit, index int, acti
 // PrepareInteractiveRebaseCommand returns the cmd for an interactive rebase
 // we tell git to run lazygit to edit the todo list, and we pass the client
 // lazygit a todo string to write to the todo file
-func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string, overrideEditor bool) (*exec.Cmd, error) {
+func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string, overrideEditor bool, flags ...string) (*exec.Cmd, error) {
 	ex := c.OSCommand.GetLazygitPath()
 
 	debug := "FALSE"
@@ -68,7 +90,12 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 		debug = "TRUE"
 	}
 
-	cmdStr := fmt.Sprintf("git rebase --interactive --autostash --keep-empty %s", baseSha)
+	flagsStr := ""
+	for _, flag := range flags {
+		flagsStr += " " + flag
+	}
+
+	cmdStr := fmt.Sprintf("git rebase --interactive --autostash --keep-empty%s %s", flagsStr, baseSha)
 	c.Log.WithField("command", cmdStr).Info("RunCommand")
 	splitCmd := str.ToArgv(cmdStr)
 
@@ -98,7 +125,13 @@ func (c *GitCommand) PrepareInteractiveRebaseCommand(baseSha string, todo string
 }
 
 func (c *GitCommand) GenerateGenericRebaseTodo(commits []*models.Commit, actionIndex int, action string) (string, string, error) {
-	baseIndex := actionIndex + 1
+	return c.GenerateRangeRebaseTodo(commits, actionIndex, actionIndex, action)
+}
+
+// GenerateRangeRebaseTodo returns the todo for a rebase that applies the action
+// to each commit from start to end inclusive, along with the sha to rebase onto
+func (c *GitCommand) GenerateRangeRebaseTodo(commits []*models.Commit, start int, end int, action string) (string, string, error) {
+	baseIndex := end + 1
 
 	if len(commits) <= baseIndex {
 		return "", "", errors.New(c.Tr.SLocalize("CannotRebaseOntoFirstCommit"))
@@ -115,9 +148,9 @@ func (c *GitCommand) GenerateGenericRebaseTodo(commits []*models.Commit, actionI
 	todo := ""
 	for i, commit := range commits[0:baseIndex] {
 		var commitAction string
-		if i == actionIndex {
+		if i >= start && i <= end {
 			commitAction = action
-		} else if commit.IsMerge {
+		} else if commit.IsMerge() {
 			// your typical interactive rebase will actually drop merge commits by default. Damn git CLI, you scary!
 			// doing this means we don't need to worry about rebasing over merges which always causes problems.
 			// you typically shouldn't be doing rebases that pass over merge commits anyway.
@@ -140,57 +173,6 @@ func (c *GitCommand) AmendTo(sha string) error {
 	return c.SquashAllAboveFixupCommits(sha)
 }
 
-// EditRebaseTodo sets the action at a given index in the git-rebase-todo file
-func (c *GitCommand) EditRebaseTodo(index int, action string) error {
-	fileName := filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
-	bytes, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return err
-	}
-
-	content := strings.Split(string(bytes), "\n")
-	commitCount := c.getTodoCommitCount(content)
-
-	// we have the most recent commit at the bottom whereas the todo file has
-	// it at the bottom, so we need to subtract our index from the commit count
-	contentIndex := commitCount - 1 - index
-	splitLine := strings.Split(content[contentIndex], " ")
-	content[contentIndex] = action + " " + strings.Join(splitLine[1:], " ")
-	result := strings.Join(content, "\n")
-
-	return ioutil.WriteFile(fileName, []byte(result), 0644)
-}
-
-func (c *GitCommand) getTodoCommitCount(content []string) int {
-	// count lines that are not blank and are not comments
-	commitCount := 0
-	for _, line := range content {
-		if line != "" && !strings.HasPrefix(line, "#") {
-			commitCount++
-		}
-	}
-	return commitCount
-}
-
-// MoveTodoDown moves a rebase todo item down by one position
-func (c *GitCommand) MoveTodoDown(index int) error {
-	fileName := filepath.Join(c.DotGitDir, "rebase-merge/git-rebase-todo")
-	bytes, err := ioutil.ReadFile(fileName)
-	if err != nil {
-		return err
-	}
-
-	content := strings.Split(string(bytes), "\n")
-	commitCount := c.getTodoCommitCount(content)
-	contentIndex := commitCount - 1 - index
-
-	rearrangedContent := append(content[0:contentIndex-1], content[contentIndex], content[contentIndex-1])
-	rearrangedContent = append(rearrangedContent, content[contentIndex+1:]...)
-	result := strings.Join(rearrangedContent, "\n")
-
-	return ioutil.WriteFile(fileName, []byte(result), 0644)
-}
-
 // SquashAllAboveFixupCommits squashes all fixup! commits above the given one
 func (c *GitCommand) SquashAllAboveFixupCommits(sha string) error {
 	return c.runSkipEditorCommand(
@@ -242,6 +224,56 @@ func (c *GitCommand) RebaseBranch(branchName string) error {
 	return c.OSCommand.RunPreparedCommand(cmd)
 }
 
+// RebaseOnto moves the commits after upstream onto the new base, e.g. to move
+// a branch that was stacked on another one after that one was squash merged
+func (c *GitCommand) RebaseOnto(newBase string, upstream string) error {
+	cmd, err := c.PrepareInteractiveRebaseCommand(upstream, "", false, "--onto", newBase)
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
+// GetCommitsSince returns a one-line description of each commit on the checked
+// out branch after the given ref, newest first, i.e. the commits that a rebase
+// with that ref as the upstream would move
+func (c *GitCommand) GetCommitsSince(ref string) ([]string, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git log --oneline --no-decorate --no-color %s..HEAD", ref)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
+
+// RebaseBranchWithMerges rebases onto a branch like RebaseBranch, but recreates
+// any merges on the way rather than flattening them
+func (c *GitCommand) RebaseBranchWithMerges(branchName string) error {
+	cmd, err := c.PrepareInteractiveRebaseCommand(branchName, "", false, "--rebase-merges")
+	if err != nil {
+		return err
+	}
+
+	return c.OSCommand.RunPreparedCommand(cmd)
+}
+
+// HasMergesSince tells us whether there are any merge commits on the checked
+// out branch since it diverged from the given ref
+func (c *GitCommand) HasMergesSince(ref string) (bool, error) {
+	output, err := c.OSCommand.RunCommandWithOutput("git rev-list --merges --count %s..HEAD", c.OSCommand.Quote(ref))
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(output) != "0", nil
+}
+
 // GenericMerge takes a commandType of "merge" or "rebase" and a command of "abort", "skip" or "continue"
 // By default we skip the editor in the case where a commit will be made
 func (c *GitCommand) GenericMergeOrRebaseAction(commandType string, command string) error {
@@ -262,13 +294,13 @@ func (c *GitCommand) GenericMergeOrRebaseAction(commandType string, command stri
 	// sometimes we need to do a sequence of things in a rebase but the user needs to
 	// fix merge conflicts along the way. When this happens we queue up the next step
 	// so that after the next successful rebase continue we can continue from where we left off
-	if commandType == "rebase" && command == "continue" && c.onSuccessfulContinue != nil {
-		f := c.onSuccessfulContinue
-		c.onSuccessfulContinue = nil
+	if commandType == "rebase" && command == "continue" && c.mergeState.onSuccessfulContinue != nil {
+		f := c.mergeState.onSuccessfulContinue
+		c.mergeState.onSuccessfulContinue = nil
 		return f()
 	}
 	if command == "abort" {
-		c.onSuccessfulContinue = nil
+		c.mergeState.onSuccessfulContinue = nil
 	}
 	return nil
 }
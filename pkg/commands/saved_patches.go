@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/i18n"
+)
+
+// custom patches are lost when you reset them or quit lazygit, so we let you
+// save them under a name in the .git directory, for applying again later
+
+const savedPatchExtension = ".patch"
+
+// savedPatchesDir is in the common .git directory so that the patches are
+// shared between linked worktrees
+func (c *GitCommand) savedPatchesDir() string {
+	dotGitDir := c.DotGitDir
+	if commonDir, err := ioutil.ReadFile(filepath.Join(dotGitDir, "commondir")); err == nil {
+		path := strings.TrimSpace(string(commonDir))
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dotGitDir, path)
+		}
+		dotGitDir = path
+	}
+
+	return filepath.Join(dotGitDir, "lazygit", "patches")
+}
+
+func (c *GitCommand) savedPatchPath(name string) string {
+	return filepath.Join(c.savedPatchesDir(), name+savedPatchExtension)
+}
+
+// ValidateSavedPatchName makes sure the name can be used as a file name
+func (c *GitCommand) ValidateSavedPatchName(name string) error {
+	if strings.TrimSpace(name) == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return errors.New(c.Tr.TemplateLocalize("InvalidSavedPatchName", i18n.Teml{"name": name}))
+	}
+	return nil
+}
+
+// SavedPatchExists tells us whether saving a patch with this name would
+// overwrite another
+func (c *GitCommand) SavedPatchExists(name string) bool {
+	_, err := os.Stat(c.savedPatchPath(name))
+	return err == nil
+}
+
+// SavePatch saves the patch under the given name, overwriting any patch
+// already saved with that name
+func (c *GitCommand) SavePatch(name string, patch string) error {
+	if err := c.ValidateSavedPatchName(name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.savedPatchesDir(), 0755); err != nil {
+		return errors.Wrap(err, 0)
+	}
+
+	if err := ioutil.WriteFile(c.savedPatchPath(name), []byte(patch), 0644); err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+// GetSavedPatches returns the saved patches, most recently saved first
+func (c *GitCommand) GetSavedPatches() ([]*models.SavedPatch, error) {
+	fileInfos, err := ioutil.ReadDir(c.savedPatchesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []*models.SavedPatch{}, nil
+		}
+		return nil, errors.Wrap(err, 0)
+	}
+
+	savedPatches := []*models.SavedPatch{}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || !strings.HasSuffix(fileInfo.Name(), savedPatchExtension) {
+			continue
+		}
+		savedPatches = append(savedPatches, &models.SavedPatch{
+			Name:    strings.TrimSuffix(fileInfo.Name(), savedPatchExtension),
+			ModTime: fileInfo.ModTime(),
+		})
+	}
+
+	sort.SliceStable(savedPatches, func(i, j int) bool {
+		return savedPatches[i].ModTime.After(savedPatches[j].ModTime)
+	})
+
+	return savedPatches, nil
+}
+
+// ReadSavedPatch returns the content of a saved patch
+func (c *GitCommand) ReadSavedPatch(name string) (string, error) {
+	content, err := ioutil.ReadFile(c.savedPatchPath(name))
+	if err != nil {
+		return "", errors.Wrap(err, 0)
+	}
+	return string(content), nil
+}
+
+// DeleteSavedPatch deletes a saved patch
+func (c *GitCommand) DeleteSavedPatch(name string) error {
+	if err := os.Remove(c.savedPatchPath(name)); err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+// ExportSavedPatch writes the saved patch to the given path as a mailbox file
+// like the ones git format-patch creates, so that it can be applied with git am
+func (c *GitCommand) ExportSavedPatch(name string, path string) error {
+	patch, err := c.ReadSavedPatch(name)
+	if err != nil {
+		return err
+	}
+
+	// git apply --stat gives us the same diffstat that git format-patch includes
+	diffStat, _ := c.OSCommand.RunCommandWithOutput("git apply --stat %s", c.OSCommand.Quote(c.savedPatchPath(name)))
+
+	author := fmt.Sprintf("%s <%s>", c.GetConfigValue("user.name"), c.GetConfigValue("user.email"))
+	mbox := formatPatchAsMbox(name, author, time.Now(), diffStat, patch)
+
+	if err := ioutil.WriteFile(path, []byte(mbox), 0644); err != nil {
+		return errors.Wrap(err, 0)
+	}
+	return nil
+}
+
+func formatPatchAsMbox(subject string, author string, date time.Time, diffStat string, patch string) string {
+	// git format-patch uses this fixed line to mark the start of each message
+	header := fmt.Sprintf(
+		"From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001\nFrom: %s\nDate: %s\nSubject: [PATCH] %s\n\n---\n",
+		author,
+		date.Format("Mon, 2 Jan 2006 15:04:05 -0700"),
+		subject,
+	)
+
+	body := strings.TrimRight(patch, "\n") + "\n"
+	if diffStat != "" {
+		body = strings.TrimRight(diffStat, "\n") + "\n\n" + body
+	}
+
+	return header + body + "-- \nlazygit\n\n"
+}
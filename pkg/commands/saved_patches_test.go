@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestGitCommandSavedPatches is a function.
+func TestGitCommandSavedPatches(t *testing.T) {
+	dotGitDir, err := ioutil.TempDir("", "lazygit-saved-patches")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dotGitDir)
+
+	gitCmd := NewDummyGitCommand()
+	gitCmd.DotGitDir = dotGitDir
+
+	savedPatches, err := gitCmd.GetSavedPatches()
+	assert.NoError(t, err)
+	assert.Empty(t, savedPatches)
+
+	assert.NoError(t, gitCmd.SavePatch("backport", "the patch"))
+	assert.True(t, gitCmd.SavedPatchExists("backport"))
+	assert.False(t, gitCmd.SavedPatchExists("other"))
+
+	savedPatches, err = gitCmd.GetSavedPatches()
+	assert.NoError(t, err)
+	assert.Len(t, savedPatches, 1)
+	assert.EqualValues(t, "backport", savedPatches[0].Name)
+
+	content, err := gitCmd.ReadSavedPatch("backport")
+	assert.NoError(t, err)
+	assert.EqualValues(t, "the patch", content)
+
+	assert.Error(t, gitCmd.SavePatch("../escape", "the patch"))
+	assert.Error(t, gitCmd.SavePatch(" ", "the patch"))
+
+	assert.NoError(t, gitCmd.DeleteSavedPatch("backport"))
+	assert.False(t, gitCmd.SavedPatchExists("backport"))
+}
+
+// TestFormatPatchAsMbox is a function.
+func TestFormatPatchAsMbox(t *testing.T) {
+	date := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	patch := "diff --git a/apple b/apple\n--- a/apple\n+++ b/apple\n@@ -1 +1 @@\n-orange\n+grape\n\n"
+	diffStat := " apple | 2 +-\n 1 file changed, 1 insertion(+), 1 deletion(-)\n"
+
+	expected := `From 0000000000000000000000000000000000000000 Mon Sep 17 00:00:00 2001
+From: Jesse <jesse@example.com>
+Date: Thu, 4 Mar 2021 05:06:07 +0000
+Subject: [PATCH] backport
+
+---
+ apple | 2 +-
+ 1 file changed, 1 insertion(+), 1 deletion(-)
+
+diff --git a/apple b/apple
+--- a/apple
++++ b/apple
+@@ -1 +1 @@
+-orange
++grape
+-- 
+lazygit
+
+`
+
+	assert.EqualValues(t, expected, formatPatchAsMbox("backport", "Jesse <jesse@example.com>", date, diffStat, patch))
+}
@@ -6,14 +6,22 @@ import (
 	gogit "github.com/go-git/go-git/v5"
 )
 
-// RebaseMode returns "" for non-rebase mode, "normal" for normal rebase
-// and "interactive" for interactive rebase
+// RebaseMode returns "" for non-rebase mode, "normal" for normal rebase,
+// "interactive" for interactive rebase and "am" when applying patches with
+// git am, which shares the rebase-apply directory with a normal rebase
 func (c *GitCommand) RebaseMode() (string, error) {
 	exists, err := c.OSCommand.FileExists(filepath.Join(c.DotGitDir, "rebase-apply"))
 	if err != nil {
 		return "", err
 	}
 	if exists {
+		applying, err := c.OSCommand.FileExists(filepath.Join(c.DotGitDir, "rebase-apply", "applying"))
+		if err != nil {
+			return "", err
+		}
+		if applying {
+			return "am", nil
+		}
 		return "normal", nil
 	}
 	exists, err = c.OSCommand.FileExists(filepath.Join(c.DotGitDir, "rebase-merge"))
@@ -24,8 +32,13 @@ func (c *GitCommand) RebaseMode() (string, error) {
 	}
 }
 
+// WorkingTreeState returns "rebasing", "merging", "applying" (for git am) or
+// "normal"
 func (c *GitCommand) WorkingTreeState() string {
 	rebaseMode, _ := c.RebaseMode()
+	if rebaseMode == "am" {
+		return "applying"
+	}
 	if rebaseMode != "" {
 		return "rebasing"
 	}
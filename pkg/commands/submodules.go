@@ -2,8 +2,11 @@ package commands
 
 import (
 	"bufio"
+	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 )
@@ -77,3 +80,67 @@ func (c *GitCommand) SubmoduleReset(config *models.SubmoduleConfig) error {
 func (c *GitCommand) SubmoduleUpdateAll() error {
 	return c.OSCommand.RunCommand("git submodule update --force")
 }
+
+func (c *GitCommand) SubmoduleInit(config *models.SubmoduleConfig) error {
+	return c.OSCommand.RunCommand("git submodule init -- %s", c.OSCommand.Quote(config.Path))
+}
+
+// SubmoduleUpdate checks out the recorded commit in the submodule, initializing
+// it first if need be. If recursive is true we do the same for any submodules
+// the submodule has of its own
+func (c *GitCommand) SubmoduleUpdate(config *models.SubmoduleConfig, recursive bool) error {
+	command := "git submodule update --init"
+
+	if recursive {
+		command = "git submodule update --init --recursive"
+	}
+
+	return c.OSCommand.RunCommand("%s -- %s", command, c.OSCommand.Quote(config.Path))
+}
+
+// SubmoduleSync updates the submodule's remote url to match what's in .gitmodules
+func (c *GitCommand) SubmoduleSync(config *models.SubmoduleConfig) error {
+	return c.OSCommand.RunCommand("git submodule sync -- %s", c.OSCommand.Quote(config.Path))
+}
+
+func (c *GitCommand) SubmoduleAdd(name string, path string, url string) error {
+	return c.OSCommand.RunCommand(
+		"git submodule add --name %s -- %s %s",
+		c.OSCommand.Quote(name),
+		c.OSCommand.Quote(url),
+		c.OSCommand.Quote(path),
+	)
+}
+
+// SubmoduleDelete removes the submodule from the working tree and from .gitmodules,
+// along with the copy of its repo that git keeps in .git/modules. Otherwise git
+// would complain if you later added a submodule with the same name
+func (c *GitCommand) SubmoduleDelete(config *models.SubmoduleConfig) error {
+	// we check this before doing anything so we don't leave things half-deleted
+	modulesDir, err := submoduleModulesDir(c.DotGitDir, config.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := c.OSCommand.RunCommand("git submodule deinit --force -- %s", c.OSCommand.Quote(config.Path)); err != nil {
+		return err
+	}
+
+	if err := c.OSCommand.RunCommand("git rm --force -r -- %s", c.OSCommand.Quote(config.Path)); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(modulesDir)
+}
+
+// submoduleModulesDir returns where git keeps the submodule's repo. The name
+// comes from .gitmodules, which anybody can commit to, so we make sure it can't
+// point us outside of .git/modules
+func submoduleModulesDir(dotGitDir string, name string) (string, error) {
+	modulesRoot := filepath.Join(dotGitDir, "modules")
+	dir := filepath.Join(modulesRoot, name)
+	if name == "" || filepath.IsAbs(name) || !strings.HasPrefix(dir, modulesRoot+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid submodule name: %s", name)
+	}
+	return dir, nil
+}
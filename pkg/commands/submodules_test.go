@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSubmoduleModulesDir is a function.
+func TestSubmoduleModulesDir(t *testing.T) {
+	type scenario struct {
+		testName      string
+		name          string
+		expectedDir   string
+		expectedError bool
+	}
+
+	scenarios := []scenario{
+		{
+			"simple name",
+			"mysubmodule",
+			filepath.Join(".git", "modules", "mysubmodule"),
+			false,
+		},
+		{
+			"nested name",
+			"libs/mysubmodule",
+			filepath.Join(".git", "modules", "libs", "mysubmodule"),
+			false,
+		},
+		{
+			"name that climbs out of .git/modules",
+			"../../..",
+			"",
+			true,
+		},
+		{
+			"name that climbs out part way through",
+			"libs/../../hooks",
+			"",
+			true,
+		},
+		{
+			"name that is the modules directory itself",
+			"libs/..",
+			"",
+			true,
+		},
+		{
+			"absolute name",
+			"/tmp/mysubmodule",
+			"",
+			true,
+		},
+		{
+			"empty name",
+			"",
+			"",
+			true,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			dir, err := submoduleModulesDir(".git", s.name)
+			assert.EqualValues(t, s.expectedDir, dir)
+			assert.EqualValues(t, s.expectedError, err != nil)
+		})
+	}
+}
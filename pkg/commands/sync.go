@@ -13,11 +13,17 @@ func (c *GitCommand) usingGpg() bool {
 		return false
 	}
 
-	gpgsign, _ := c.getLocalGitConfig("commit.gpgsign")
-	if gpgsign == "" {
-		gpgsign, _ = c.getGlobalGitConfig("commit.gpgsign")
+	return c.gitConfigEnabled("commit.gpgsign")
+}
+
+// gitConfigEnabled tells us whether the given boolean git config key is set to
+// true, locally or globally
+func (c *GitCommand) gitConfigEnabled(key string) bool {
+	value, _ := c.getLocalGitConfig(key)
+	if value == "" {
+		value, _ = c.getGlobalGitConfig(key)
 	}
-	value := strings.ToLower(gpgsign)
+	value = strings.ToLower(value)
 
 	return value == "true" || value == "1" || value == "yes" || value == "on"
 }
@@ -35,7 +41,7 @@ func (c *GitCommand) Push(branchName string, force bool, upstream string, args s
 	}
 
 	cmd := fmt.Sprintf("git push --follow-tags %s %s %s", forceFlag, setUpstreamArg, args)
-	return c.OSCommand.DetectUnamePass(cmd, promptUserForCredential)
+	return c.OSCommand.RunCommandWithCredentials(cmd, promptUserForCredential)
 }
 
 type FetchOptions struct {
@@ -55,20 +61,20 @@ func (c *GitCommand) Fetch(opts FetchOptions) error {
 		command = fmt.Sprintf("%s %s", command, opts.BranchName)
 	}
 
-	return c.OSCommand.DetectUnamePass(command, func(question string) string {
+	return c.OSCommand.RunCommandWithCredentials(command, func(question string) string {
 		if opts.PromptUserForCredential != nil {
 			return opts.PromptUserForCredential(question)
 		}
-		return "\n"
+		return ""
 	})
 }
 
 func (c *GitCommand) FastForward(branchName string, remoteName string, remoteBranchName string, promptUserForCredential func(string) string) error {
 	command := fmt.Sprintf("git fetch %s %s:%s", remoteName, remoteBranchName, branchName)
-	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+	return c.OSCommand.RunCommandWithCredentials(command, promptUserForCredential)
 }
 
 func (c *GitCommand) FetchRemote(remoteName string, promptUserForCredential func(string) string) error {
 	command := fmt.Sprintf("git fetch %s", remoteName)
-	return c.OSCommand.DetectUnamePass(command, promptUserForCredential)
+	return c.OSCommand.RunCommandWithCredentials(command, promptUserForCredential)
 }
@@ -1,13 +1,70 @@
 package commands
 
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
 func (c *GitCommand) CreateLightweightTag(tagName string, commitSha string) error {
 	return c.OSCommand.RunCommand("git tag %s %s", tagName, commitSha)
 }
 
+// CreateAnnotatedTag creates a tag with the given message. If the tag is to be
+// signed we return a subprocess like we do for commits, so that the user can
+// enter their gpg passphrase
+func (c *GitCommand) CreateAnnotatedTag(tagName string, commitSha string, message string, sign bool) (*exec.Cmd, error) {
+	command := fmt.Sprintf("git tag %s %s -m %s %s", annotatedTagFlag(sign), tagName, c.OSCommand.Quote(message), commitSha)
+	if c.usingTagGpg(sign) {
+		return c.OSCommand.ShellCommandFromString(command), nil
+	}
+
+	return nil, c.OSCommand.RunCommand(command)
+}
+
+// PrepareAnnotatedTagSubProcess prepares a subprocess for creating an annotated
+// tag whose message is written in the user's editor
+func (c *GitCommand) PrepareAnnotatedTagSubProcess(tagName string, commitSha string, sign bool) *exec.Cmd {
+	args := []string{"tag", annotatedTagFlag(sign), tagName}
+	if commitSha != "" {
+		args = append(args, commitSha)
+	}
+	return c.OSCommand.PrepareSubProcess("git", args...)
+}
+
+func annotatedTagFlag(sign bool) string {
+	if sign {
+		return "--sign"
+	}
+	return "--annotate"
+}
+
+// usingTagGpg tells us whether creating a tag will involve gpg, either because
+// we've asked for it to be signed or because the user's config says all
+// annotated tags should be signed
+func (c *GitCommand) usingTagGpg(sign bool) bool {
+	if c.Config.GetUserConfig().GetBool("git.overrideGpg") {
+		return false
+	}
+
+	return sign || c.gitConfigEnabled("tag.gpgsign")
+}
+
+// ShowTagCmdStr shows an annotated tag's tagger and message, followed by the commit it points to
+func (c *GitCommand) ShowTagCmdStr(tagName string) string {
+	return fmt.Sprintf("git show --no-patch --color=%s %s", c.colorArg(), tagName)
+}
+
 func (c *GitCommand) DeleteTag(tagName string) error {
 	return c.OSCommand.RunCommand("git tag -d %s", tagName)
 }
 
+// DeleteRemoteTag deletes the tag on the given remote. We use the full ref so
+// that we don't delete a branch that happens to have the same name
+func (c *GitCommand) DeleteRemoteTag(remoteName string, tagName string) error {
+	return c.OSCommand.RunCommand("git push %s --delete refs/tags/%s", remoteName, strings.TrimPrefix(tagName, "refs/tags/"))
+}
+
 func (c *GitCommand) PushTag(remoteName string, tagName string) error {
 	return c.OSCommand.RunCommand("git push %s %s", remoteName, tagName)
 }
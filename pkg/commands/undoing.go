@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// Quick summary of how this all works:
+// when you want to undo or redo, we start from the top of the reflog and work
+// down until we've reached the last user-initiated reflog entry that hasn't already been undone
+// we then do the reverse of what that reflog describes.
+// When we do this, we create a new reflog entry, and tag it as either an undo or redo
+// Then, next time we want to undo, we'll use those entries to know which user-initiated
+// actions we can skip. E.g. if I do do three things, A, B, and C, and hit undo twice,
+// the reflog will read UUCBA, and when I read the first two undos, I know to skip the following
+// two user actions, meaning we end up undoing reflog entry C. Redoing works in a similar way.
+
+const (
+	REFLOG_CHECKOUT = iota
+	REFLOG_COMMIT
+	REFLOG_REBASE
+	REFLOG_CURRENT_REBASE
+)
+
+const (
+	UNDO_REFLOG_ACTION = "GIT_REFLOG_ACTION=[lazygit undo]"
+	REDO_REFLOG_ACTION = "GIT_REFLOG_ACTION=[lazygit redo]"
+)
+
+type ReflogAction struct {
+	Kind int // one of REFLOG_CHECKOUT, REFLOG_REBASE, and REFLOG_COMMIT
+	From string
+	To   string
+}
+
+// ParseReflogForActions goes through the reflog maintaining a counter that represents how many
+// undos/redos/user actions we've seen. when we hit a user action we call the callback specifying
+// what the counter is up to and the nature of the action.
+// If we find ourselves mid-rebase, we just return because undo/redo mid rebase
+// requires knowledge of previous TODO file states, which you can't just get from the reflog.
+// Though we might support this later, hence the use of the REFLOG_CURRENT_REBASE action kind.
+func ParseReflogForActions(reflogCommits []*models.Commit, onUserAction func(counter int, action ReflogAction) (bool, error)) error {
+	counter := 0
+	rebaseFinishCommitSha := ""
+	var action *ReflogAction
+	for reflogCommitIdx, reflogCommit := range reflogCommits {
+		action = nil
+
+		prevCommitSha := ""
+		if len(reflogCommits)-1 >= reflogCommitIdx+1 {
+			prevCommitSha = reflogCommits[reflogCommitIdx+1].Sha
+		}
+
+		if rebaseFinishCommitSha == "" {
+			if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit undo\]`); ok {
+				counter++
+			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^\[lazygit redo\]`); ok {
+				counter--
+			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase -i \(abort\)|^rebase -i \(finish\)`); ok {
+				rebaseFinishCommitSha = reflogCommit.Sha
+			} else if ok, match := utils.FindStringSubmatch(reflogCommit.Name, `^checkout: moving from ([\S]+) to ([\S]+)`); ok {
+				action = &ReflogAction{Kind: REFLOG_CHECKOUT, From: match[1], To: match[2]}
+			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^commit|^reset: moving to|^pull`); ok {
+				action = &ReflogAction{Kind: REFLOG_COMMIT, From: prevCommitSha, To: reflogCommit.Sha}
+			} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase -i \(start\)`); ok {
+				// if we're here then we must be currently inside an interactive rebase
+				action = &ReflogAction{Kind: REFLOG_CURRENT_REBASE, From: prevCommitSha}
+			}
+		} else if ok, _ := utils.FindStringSubmatch(reflogCommit.Name, `^rebase -i \(start\)`); ok {
+			action = &ReflogAction{Kind: REFLOG_REBASE, From: prevCommitSha, To: rebaseFinishCommitSha}
+			rebaseFinishCommitSha = ""
+		}
+
+		if action != nil {
+			if action.Kind != REFLOG_CURRENT_REBASE && action.From == action.To {
+				// if we're going from one place to the same place we'll ignore the action.
+				continue
+			}
+			ok, err := onUserAction(counter, *action)
+			if ok {
+				return err
+			}
+			counter--
+		}
+	}
+	return nil
+}
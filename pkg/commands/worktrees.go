@@ -0,0 +1,23 @@
+package commands
+
+// AddWorktree checks out an existing branch into a new worktree at the given path
+func (c *GitCommand) AddWorktree(path string, branchName string) error {
+	return c.OSCommand.RunCommand("git worktree add %s %s", c.OSCommand.Quote(path), c.OSCommand.Quote(branchName))
+}
+
+// RemoveWorktree removes the worktree at the given path, with --force if you set the force arg to true.
+// Without force, git refuses to remove a worktree that has uncommitted changes
+func (c *GitCommand) RemoveWorktree(path string, force bool) error {
+	command := "git worktree remove"
+
+	if force {
+		command = "git worktree remove --force"
+	}
+
+	return c.OSCommand.RunCommand("%s %s", command, c.OSCommand.Quote(path))
+}
+
+// PruneWorktrees cleans up the administrative files of worktrees whose directories no longer exist
+func (c *GitCommand) PruneWorktrees() error {
+	return c.OSCommand.RunCommand("git worktree prune")
+}
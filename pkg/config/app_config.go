@@ -248,6 +248,7 @@ func GetDefaultConfig() []byte {
   sidePanelWidth: 0.3333
   expandFocusedSidePanel: false
   mainPanelSplitMode: 'flexible' # one of 'horizontal' | 'flexible' | 'vertical'
+  showFileTree: false # show the files and commit files panels as a tree of directories
   theme:
     lightTheme: false
     activeBorderColor:
@@ -277,6 +278,10 @@ git:
   branchLogCmd: "git log --graph --color=always --abbrev-commit --decorate --date=relative --pretty=medium {{branchName}} --"
   overrideGpg: false # prevents lazygit from spawning a separate process when using GPG
   disableForcePushing: false
+forge:
+  provider: ''
+  token: ''
+  apiUrl: ''
 update:
   method: prompt # can be: prompt | background | never
   days: 14 # how often a update is checked for
@@ -299,6 +304,7 @@ keybinding:
     nextPage: '.'
     gotoTop: '<'
     gotoBottom: '>'
+    toggleRangeSelect: 'V'
     prevBlock: '<left>'
     nextBlock: '<right>'
     prevBlock-alt: 'h'
@@ -306,6 +312,7 @@ keybinding:
     nextMatch: 'n'
     prevMatch: 'N'
     startSearch: '/'
+    startFuzzyFilter: '<c-f>'
     optionMenu: 'x'
     optionMenu-alt1: '?'
     select: '<space>'
@@ -338,6 +345,7 @@ keybinding:
     diffingMenu: 'W'
     diffingMenu-alt: '<c-e>'
     copyToClipboard: '<c-o>'
+    commandLogMenu: '@'
   status:
     checkForUpdate: 'u'
     recentRepos: '<enter>'
@@ -353,8 +361,16 @@ keybinding:
     toggleStagedAll: 'a'
     viewResetOptions: 'D'
     fetch: 'f'
+    viewBlame: 'B'
+    toggleTreeView: '` + "`" + `'
+    openMergeEditor: 'M'
+  submodules:
+    init: 'i'
+    update: 'u'
+    sync: 's'
   branches:
     createPullRequest: 'o'
+    viewPullRequests: 'G'
     checkoutBranchByName: 'c'
     forceCheckoutBranch: 'F'
     rebaseBranch: 'r'
@@ -365,6 +381,8 @@ keybinding:
     pushTag: 'P'
     setUpstream: 'u'
     fetchRemote: 'f'
+    createWorktree: 'w'
+    pruneWorktrees: 'p'
   commits:
     squashDown: 's'
     renameCommit: 'r'
@@ -384,15 +402,25 @@ keybinding:
     tagCommit: 'T'
     checkoutCommit: '<space>'
     resetCherryPick: '<c-R>'
+    viewBisectOptions: 'b'
+    importPatches: 'I'
+    formatPatch: 'X'
+    addTodoLine: 'E'
+    markCommitAsUpstream: 'B'
+    openSearchMenu: '<c-g>'
   stash:
     popStash: 'g'
   commitFiles:
     checkoutCommitFile: 'c'
+    viewBlame: 'B'
+    toggleTreeView: '` + "`" + `'
   main:
     toggleDragSelect: 'v'
     toggleDragSelect-alt: 'V'
     toggleSelectHunk: 'a'
     pickBothHunks: 'b'
+    blameAtParent: 'b'
+    openMergeEditor: 'M'
 `)
 }
 
@@ -8,6 +8,24 @@ import (
 func (gui *Gui) mainSectionChildren() []*boxlayout.Box {
 	currentWindow := gui.currentWindow()
 
+	// the merge editor shows ours and theirs alongside the result
+	if gui.mergeEditorOpen() {
+		return []*boxlayout.Box{
+			{
+				Window: "main",
+				Weight: 1,
+			},
+			{
+				Window: "secondary",
+				Weight: 1,
+			},
+			{
+				Window: "mergeResult",
+				Weight: 1,
+			},
+		}
+	}
+
 	// if we're not in split mode we can just show the one main panel. Likewise if
 	// the main panel is focused and we're in full-screen mode
 	if !gui.isMainPanelSplit() || (gui.State.ScreenMode == SCREEN_FULL && currentWindow == "main") {
@@ -80,6 +98,19 @@ func (gui *Gui) infoSectionChildren(informationStr string, appStatus string) []*
 		}
 	}
 
+	if gui.State.FuzzyFilter.active() {
+		return []*boxlayout.Box{
+			{
+				Window: "filterPrefix",
+				Size:   len(FUZZY_FILTER_PREFIX),
+			},
+			{
+				Window: "filter",
+				Weight: 1,
+			},
+		}
+	}
+
 	result := []*boxlayout.Box{}
 
 	if len(appStatus) > 0 {
@@ -131,27 +162,7 @@ func (gui *Gui) getWindowDimensions(informationStr string, appStatus string) map
 						Weight:              sideSectionWeight,
 						ConditionalChildren: gui.sidePanelChildren,
 					},
-					{
-						ConditionalDirection: func(width int, height int) int {
-							mainPanelSplitMode := gui.Config.GetUserConfig().GetString("gui.mainPanelSplitMode")
-
-							switch mainPanelSplitMode {
-							case "vertical":
-								return boxlayout.ROW
-							case "horizontal":
-								return boxlayout.COLUMN
-							default:
-								if width < 160 && height > 30 { // 2 80 character width panels
-									return boxlayout.ROW
-								} else {
-									return boxlayout.COLUMN
-								}
-							}
-						},
-						Direction: boxlayout.COLUMN,
-						Weight:    mainSectionWeight,
-						Children:  gui.mainSectionChildren(),
-					},
+					gui.mainSectionBox(mainSectionWeight),
 				},
 			},
 			{
@@ -165,6 +176,50 @@ func (gui *Gui) getWindowDimensions(informationStr string, appStatus string) map
 	return boxlayout.ArrangeWindows(root, 0, 0, width, height)
 }
 
+// mainSectionBox contains the main panels along with the command log, if it's
+// being shown
+func (gui *Gui) mainSectionBox(weight int) *boxlayout.Box {
+	mainPanelsBox := &boxlayout.Box{
+		ConditionalDirection: func(width int, height int) int {
+			mainPanelSplitMode := gui.Config.GetUserConfig().GetString("gui.mainPanelSplitMode")
+
+			switch mainPanelSplitMode {
+			case "vertical":
+				return boxlayout.ROW
+			case "horizontal":
+				return boxlayout.COLUMN
+			default:
+				if width < 160 && height > 30 { // 2 80 character width panels
+					return boxlayout.ROW
+				} else {
+					return boxlayout.COLUMN
+				}
+			}
+		},
+		Direction: boxlayout.COLUMN,
+		Weight:    weight,
+		Children:  gui.mainSectionChildren(),
+	}
+
+	if !gui.ShowCommandLog || weight == 0 {
+		return mainPanelsBox
+	}
+
+	mainPanelsBox.Weight = 3
+
+	return &boxlayout.Box{
+		Direction: boxlayout.ROW,
+		Weight:    weight,
+		Children: []*boxlayout.Box{
+			mainPanelsBox,
+			{
+				Window: "commandLog",
+				Weight: 1,
+			},
+		},
+	}
+}
+
 // The stash window by default only contains one line so that it's not hogging
 // too much space, but if you access it it should take up some space. This is
 // the default behaviour when accordian mode is NOT in effect. If it is in effect
@@ -0,0 +1,113 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// git has nothing to show for a binary file beyond 'Binary files differ', so we
+// show a summary of how the file has changed instead. There are no lines to
+// stage, but the file can still be staged as a whole from the files panel
+
+// fileIsBinary tells us whether git treats the changes to a file as binary.
+// We remember the answer until the next time we refresh the files, so that
+// moving through the files panel doesn't cost an extra git command per file
+func (gui *Gui) fileIsBinary(file *models.File) bool {
+	if isBinary, ok := gui.State.BinaryFiles[file.Name]; ok {
+		return isBinary
+	}
+
+	isBinary := gui.GitCommand.WorktreeFileIsBinary(file, !file.HasUnstagedChanges && file.HasStagedChanges)
+	gui.State.BinaryFiles[file.Name] = isBinary
+	return isBinary
+}
+
+// selectBinaryFile shows the summary of a binary file's unstaged changes, with
+// its staged changes below if it has both
+func (gui *Gui) selectBinaryFile(file *models.File) error {
+	cached := !file.HasUnstagedChanges && file.HasStagedChanges
+
+	refreshOpts := refreshMainOpts{main: &viewUpdateOpts{
+		title: gui.Tr.SLocalize("UnstagedChanges"),
+		task:  gui.createRenderStringTask(gui.binaryFileSummaryString(gui.GitCommand.GetBinaryFileSummary(file, cached))),
+	}}
+
+	if file.HasStagedChanges && file.HasUnstagedChanges {
+		refreshOpts.secondary = &viewUpdateOpts{
+			title: gui.Tr.SLocalize("StagedChanges"),
+			task:  gui.createRenderStringTask(gui.binaryFileSummaryString(gui.GitCommand.GetBinaryFileSummary(file, true))),
+		}
+	} else if cached {
+		refreshOpts.main.title = gui.Tr.SLocalize("StagedChanges")
+	}
+
+	return gui.refreshMainViews(refreshOpts)
+}
+
+func (gui *Gui) binaryFileSummaryString(summary *commands.BinaryFileSummary) string {
+	none := gui.Tr.SLocalize("BinaryFileNone")
+
+	formatSize := func(version commands.BinaryFileVersion) string {
+		if version.Size < 0 {
+			return none
+		}
+		return formatFileSize(version.Size)
+	}
+
+	sizeChange := ""
+	if summary.Before.Size >= 0 && summary.After.Size >= 0 && summary.Before.Size != summary.After.Size {
+		difference := summary.After.Size - summary.Before.Size
+		if difference > 0 {
+			sizeChange = utils.ColoredString(" (+"+formatFileSize(difference)+")", color.FgGreen)
+		} else {
+			sizeChange = utils.ColoredString(" (-"+formatFileSize(-difference)+")", color.FgRed)
+		}
+	}
+
+	lines := []string{
+		utils.ColoredString(gui.Tr.TemplateLocalize("BinaryFileTitle", Teml{"name": summary.Name}), color.Bold),
+		"",
+		fmt.Sprintf("%s %s -> %s%s", padLabel(gui.Tr.SLocalize("BinaryFileSize")), formatSize(summary.Before), formatSize(summary.After), sizeChange),
+	}
+
+	if summary.IsImage {
+		formatDimensions := func(version commands.BinaryFileVersion) string {
+			if version.Size < 0 {
+				return none
+			}
+			if version.Dimensions == "" {
+				// we can only decode some image formats
+				return "?"
+			}
+			return version.Dimensions
+		}
+
+		lines = append(lines, fmt.Sprintf("%s %s -> %s", padLabel(gui.Tr.SLocalize("BinaryFileDimensions")), formatDimensions(summary.Before), formatDimensions(summary.After)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func padLabel(label string) string {
+	return utils.WithPadding(label+":", 12)
+}
+
+func formatFileSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / unit
+	for _, suffix := range []string{"KB", "MB", "GB"} {
+		if value < unit || suffix == "GB" {
+			return fmt.Sprintf("%.1f %s", value, suffix)
+		}
+		value /= unit
+	}
+	return ""
+}
@@ -0,0 +1,310 @@
+package gui
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// refForLog returns the ref whose history we show in the commits panel. While
+// bisecting, HEAD jumps around between candidates, so we show the history of
+// the ref that was checked out when the bisect started instead
+func (gui *Gui) refForLog() string {
+	if gui.State.Modes.Bisecting.Active() {
+		return gui.State.Modes.Bisecting.Info.Start
+	}
+
+	return "HEAD"
+}
+
+func (gui *Gui) bisectStatusString() string {
+	info := gui.State.Modes.Bisecting.Info
+
+	if culprit := info.Culprit(); culprit != "" {
+		return gui.Tr.TemplateLocalize("BisectFoundCulprit", Teml{"term": info.NewTerm, "sha": culprit[:8]})
+	}
+
+	if len(info.Candidates) > 0 {
+		return gui.Tr.TemplateLocalize("BisectingCandidatesLeft", Teml{"count": len(info.Candidates)})
+	}
+
+	return gui.Tr.SLocalize("Bisecting")
+}
+
+func (gui *Gui) handleCreateBisectMenu() error {
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if !gui.State.Modes.Bisecting.Active() {
+		menuItems := []*menuItem{
+			{
+				displayString: gui.bisectMarkDisplayString(commit, "bad"),
+				onPress: func() error {
+					return gui.startBisectAndMark(commit.Sha, "bad")
+				},
+			},
+			{
+				displayString: gui.bisectMarkDisplayString(commit, "good"),
+				onPress: func() error {
+					return gui.startBisectAndMark(commit.Sha, "good")
+				},
+			},
+		}
+
+		return gui.createMenu(gui.Tr.SLocalize("BisectOptionsTitle"), menuItems, createMenuOptions{showCancel: true})
+	}
+
+	info := gui.State.Modes.Bisecting.Info
+	menuItems := []*menuItem{
+		{
+			displayString: gui.bisectMarkDisplayString(commit, info.NewTerm),
+			onPress: func() error {
+				return gui.bisectMark(commit.Sha, info.NewTerm)
+			},
+		},
+		{
+			displayString: gui.bisectMarkDisplayString(commit, info.OldTerm),
+			onPress: func() error {
+				return gui.bisectMark(commit.Sha, info.OldTerm)
+			},
+		},
+		{
+			displayString: gui.bisectMarkDisplayString(commit, "skip"),
+			onPress: func() error {
+				return gui.bisectMark(commit.Sha, "skip")
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("BisectRun"),
+			onPress:       gui.handleBisectRun,
+		},
+		{
+			displayString: gui.Tr.SLocalize("BisectReset"),
+			onPress:       gui.handleResetBisect,
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("BisectOptionsTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) bisectMarkDisplayString(commit *models.Commit, term string) string {
+	return gui.Tr.TemplateLocalize(
+		"BisectMark",
+		Teml{
+			"sha":  commit.ShortSha(),
+			"term": term,
+		},
+	)
+}
+
+func (gui *Gui) startBisectAndMark(sha string, term string) error {
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("BisectAction"))
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("BisectingStatus"), func() error {
+		if err := gitCommand.BisectStart(); err != nil {
+			return err
+		}
+
+		if err := gitCommand.BisectMark(sha, term); err != nil {
+			return err
+		}
+
+		return gui.afterBisectCommand()
+	})
+}
+
+func (gui *Gui) bisectMark(sha string, term string) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("BisectingStatus"), func() error {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("BisectAction")).BisectMark(sha, term); err != nil {
+			return err
+		}
+
+		return gui.afterBisectCommand()
+	})
+}
+
+// afterBisectCommand refreshes everything (given git has probably checked out
+// another commit) and lets the user know if we've found the culprit
+func (gui *Gui) afterBisectCommand() error {
+	if err := gui.refreshSidePanels(refreshOptions{mode: SYNC}); err != nil {
+		return err
+	}
+
+	culprit := gui.State.Modes.Bisecting.Info.Culprit()
+	if culprit == "" {
+		return nil
+	}
+
+	message, err := gui.GitCommand.GetCommitMessage(culprit)
+	if err != nil {
+		return err
+	}
+	subject := strings.Split(message, "\n")[0]
+
+	prompt := gui.Tr.TemplateLocalize(
+		"BisectCompletePrompt",
+		Teml{
+			"term":   gui.State.Modes.Bisecting.Info.NewTerm,
+			"commit": fmt.Sprintf("%s %s", culprit, subject),
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:         gui.Tr.SLocalize("BisectCompleteTitle"),
+		prompt:        prompt,
+		handleConfirm: gui.resetBisect,
+	})
+}
+
+func (gui *Gui) handleResetBisect() error {
+	return gui.ask(askOpts{
+		title:         gui.Tr.SLocalize("BisectReset"),
+		prompt:        gui.Tr.SLocalize("BisectResetPrompt"),
+		handleConfirm: gui.resetBisect,
+	})
+}
+
+func (gui *Gui) resetBisect() error {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("BisectReset")).BisectReset(); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
+}
+
+func (gui *Gui) handleBisectRun() error {
+	return gui.prompt(gui.Tr.SLocalize("BisectRunPrompt"), "", func(script string) error {
+		return gui.WithWaitingStatus(gui.Tr.SLocalize("BisectRunningStatus"), func() error {
+			return gui.runBisectScript(script)
+		})
+	})
+}
+
+// runBisectScript streams the output of `git bisect run` into the main view.
+// If the user moves on to another item we stop rendering the output, but the
+// command itself keeps going because killing it halfway through would leave
+// the bisect somewhere arbitrary
+func (gui *Gui) runBisectScript(script string) error {
+	cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.BisectRunCmdStr(script))
+	r, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	output := newBisectRunOutput()
+	gui.g.Update(func(*gocui.Gui) error {
+		return gui.refreshMainViews(refreshMainOpts{
+			main: &viewUpdateOpts{
+				title: gui.Tr.SLocalize("BisectRunTitle"),
+				task:  gui.createRunFunctionTask(gui.renderBisectRunOutput(output)),
+			},
+		})
+	})
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		output.append(scanner.Text())
+	}
+	output.finish()
+
+	runErr := cmd.Wait()
+
+	if err := gui.afterBisectCommand(); err != nil {
+		return err
+	}
+
+	// the script's exit codes are how commits get marked so we only hear about
+	// errors where git itself gave up, and those are explained in the output
+	if runErr != nil && gui.State.Modes.Bisecting.Info.Culprit() == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("BisectRunFailed"))
+	}
+
+	return nil
+}
+
+func (gui *Gui) renderBisectRunOutput(output *bisectRunOutput) func(chan struct{}) error {
+	return func(stop chan struct{}) error {
+		mainView := gui.getMainView()
+		gui.g.Update(func(*gocui.Gui) error {
+			mainView.Clear()
+			return nil
+		})
+
+		rendered := 0
+		for {
+			lines, finished := output.linesFrom(rendered)
+			if len(lines) > 0 {
+				rendered += len(lines)
+				gui.g.Update(func(*gocui.Gui) error {
+					for _, line := range lines {
+						fmt.Fprintln(mainView, line)
+					}
+					return nil
+				})
+			}
+
+			if finished {
+				return nil
+			}
+
+			select {
+			case <-stop:
+				return nil
+			case <-output.notify:
+			}
+		}
+	}
+}
+
+// bisectRunOutput collects the lines output by `git bisect run` as they come in
+type bisectRunOutput struct {
+	mutex    sync.Mutex
+	lines    []string
+	finished bool
+	notify   chan struct{}
+}
+
+func newBisectRunOutput() *bisectRunOutput {
+	return &bisectRunOutput{notify: make(chan struct{}, 1)}
+}
+
+func (o *bisectRunOutput) append(line string) {
+	o.mutex.Lock()
+	o.lines = append(o.lines, line)
+	o.mutex.Unlock()
+	o.wake()
+}
+
+func (o *bisectRunOutput) finish() {
+	o.mutex.Lock()
+	o.finished = true
+	o.mutex.Unlock()
+	o.wake()
+}
+
+func (o *bisectRunOutput) wake() {
+	select {
+	case o.notify <- struct{}{}:
+	default:
+	}
+}
+
+// linesFrom returns the lines from the given index onwards, and whether the command has finished
+func (o *bisectRunOutput) linesFrom(index int) ([]string, bool) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	lines := make([]string, len(o.lines)-index)
+	copy(lines, o.lines[index:])
+	return lines, o.finished
+}
@@ -0,0 +1,292 @@
+package gui
+
+import (
+	"sync"
+
+	"github.com/golang-collections/collections/stack"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+)
+
+// blameHistoryEntry is somewhere we've been while walking back through a file's history
+type blameHistoryEntry struct {
+	ref             string
+	path            string
+	selectedLineIdx int
+}
+
+// blameLines holds the lines of the blamed file that have been loaded so far.
+// We only load as much of the file as the user has looked at, so this can be
+// shorter than the file itself
+type blameLines struct {
+	mutex sync.Mutex
+	lines []*models.BlameLine
+}
+
+func newBlameLines() *blameLines {
+	return &blameLines{}
+}
+
+func (l *blameLines) append(line *models.BlameLine) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.lines = append(l.lines, line)
+}
+
+func (l *blameLines) len() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return len(l.lines)
+}
+
+// get returns nil if the line hasn't been loaded yet
+func (l *blameLines) get(index int) *models.BlameLine {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if index < 0 || index >= len(l.lines) {
+		return nil
+	}
+	return l.lines[index]
+}
+
+func (gui *Gui) handleBlameFile() error {
+	file := gui.getSelectedFile()
+	if file == nil {
+		return nil
+	}
+
+	if !file.Tracked || file.Deleted {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CannotBlameFile"))
+	}
+
+	// in the case of a rename we want the new name
+	return gui.enterBlame("", file.Name)
+}
+
+func (gui *Gui) handleBlameCommitFile() error {
+	commitFile := gui.getSelectedCommitFile()
+	if commitFile == nil {
+		return nil
+	}
+
+	if commitFile.ChangeStatus == "D" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CannotBlameFile"))
+	}
+
+	return gui.enterBlame(commitFile.Parent, commitFile.Name)
+}
+
+func (gui *Gui) enterBlame(ref string, path string) error {
+	state := gui.State.Panels.Blame
+	state.Ref = ref
+	state.Path = path
+	state.SelectedLineIdx = 0
+	state.History = stack.New()
+
+	if err := gui.switchContext(gui.Contexts.Blame.Context); err != nil {
+		return err
+	}
+
+	return gui.refreshBlamePanel()
+}
+
+func (gui *Gui) refreshBlamePanel() error {
+	state := gui.State.Panels.Blame
+	state.Lines = newBlameLines()
+
+	mainView := gui.getMainView()
+	mainView.SelBgColor = theme.GocuiSelectedLineBgColor
+
+	// we set the origin before loading anything so that the task loads enough
+	// lines to get to the selected one
+	_, height := mainView.Size()
+	origin := state.SelectedLineIdx - height/2
+	if origin < 0 {
+		origin = 0
+	}
+	if err := mainView.SetOrigin(0, origin); err != nil {
+		return err
+	}
+	if err := mainView.SetCursor(0, state.SelectedLineIdx-origin); err != nil {
+		return err
+	}
+
+	title := gui.Tr.TemplateLocalize("BlameTitle", Teml{"path": state.Path})
+	if state.Ref != "" {
+		title = gui.Tr.TemplateLocalize("BlameTitleAtRef", Teml{"path": state.Path, "ref": state.Ref})
+	}
+
+	cmd := gui.OSCommand.ExecutableFromString(gui.GitCommand.BlameCmdStr(state.Ref, state.Path))
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title:     title,
+			noWrap:    true,
+			highlight: true,
+			task:      gui.createRunFormattedCommandTask(cmd, gui.blameFormatter(state.Lines)),
+		},
+	})
+}
+
+// blameFormatter returns a function for formatting the porcelain output of git
+// blame, which collects the lines in the given struct as it goes
+func (gui *Gui) blameFormatter(lines *blameLines) func(string) (string, bool) {
+	parser := commands.NewBlameParser()
+	var previousCommit *models.BlameCommit
+
+	return func(line string) (string, bool) {
+		blameLine, ok := parser.ParseLine(line)
+		if !ok {
+			// this is probably git telling us something went wrong, so we'll show it as is
+			return line, true
+		}
+		if blameLine == nil {
+			return "", false
+		}
+
+		startsBlock := blameLine.Commit != previousCommit
+		previousCommit = blameLine.Commit
+		lines.append(blameLine)
+
+		return presentation.GetBlameLineDisplayString(blameLine, startsBlock), true
+	}
+}
+
+func (gui *Gui) getSelectedBlameLine() *models.BlameLine {
+	state := gui.State.Panels.Blame
+
+	return state.Lines.get(state.SelectedLineIdx)
+}
+
+func (gui *Gui) handleBlamePrevLine(g *gocui.Gui, v *gocui.View) error {
+	return gui.handleBlameCycleLine(-1)
+}
+
+func (gui *Gui) handleBlameNextLine(g *gocui.Gui, v *gocui.View) error {
+	return gui.handleBlameCycleLine(1)
+}
+
+func (gui *Gui) handleBlameCycleLine(change int) error {
+	state := gui.State.Panels.Blame
+	mainView := gui.getMainView()
+	_, height := mainView.Size()
+
+	newSelectedLineIdx := state.SelectedLineIdx + change
+	loadedCount := state.Lines.len()
+
+	// we haven't loaded any further than this yet, but the user is headed that
+	// way so we'll load some more
+	if newSelectedLineIdx+height/2 >= loadedCount {
+		gui.getManager(mainView).ReadLines(height)
+	}
+
+	// the selected line can be past the end of what's loaded if we've just
+	// blamed an earlier version of the file which turned out to be shorter
+	if newSelectedLineIdx >= loadedCount {
+		newSelectedLineIdx = loadedCount - 1
+	}
+
+	if newSelectedLineIdx < 0 {
+		return nil
+	}
+
+	state.SelectedLineIdx = newSelectedLineIdx
+
+	return gui.focusBlameSelection()
+}
+
+// focusBlameSelection scrolls the main view as little as possible to keep the selected line in view
+func (gui *Gui) focusBlameSelection() error {
+	mainView := gui.getMainView()
+	state := gui.State.Panels.Blame
+
+	_, height := mainView.Size()
+	_, origin := mainView.Origin()
+
+	newOrigin := origin
+	if state.SelectedLineIdx < origin {
+		newOrigin = state.SelectedLineIdx
+	} else if state.SelectedLineIdx > origin+height-1 {
+		newOrigin = state.SelectedLineIdx - height + 1
+	}
+
+	if err := mainView.SetOrigin(0, newOrigin); err != nil {
+		return err
+	}
+
+	return mainView.SetCursor(0, state.SelectedLineIdx-newOrigin)
+}
+
+func (gui *Gui) handleBlameEscape() error {
+	state := gui.State.Panels.Blame
+
+	if state.History.Len() > 0 {
+		entry := state.History.Pop().(*blameHistoryEntry)
+		state.Ref = entry.ref
+		state.Path = entry.path
+		state.SelectedLineIdx = entry.selectedLineIdx
+		return gui.refreshBlamePanel()
+	}
+
+	return gui.returnFromContext()
+}
+
+// handleBlameGoToCommit takes us to the commit that the selected line is
+// attributed to. If the commit isn't in the commits panel (e.g. because it's
+// on another branch) we show it in the sub-commits panel instead
+func (gui *Gui) handleBlameGoToCommit() error {
+	blameLine := gui.getSelectedBlameLine()
+	if blameLine == nil {
+		return nil
+	}
+
+	if blameLine.Commit.IsUncommitted() {
+		return gui.createErrorPanel(gui.Tr.SLocalize("BlameLineNotCommitted"))
+	}
+
+	for i, commit := range gui.State.Commits {
+		if commit.Sha == blameLine.Commit.Sha {
+			gui.State.Panels.Commits.SelectedLineIdx = i
+			return gui.switchContext(gui.Contexts.BranchCommits.Context)
+		}
+	}
+
+	return gui.switchToSubCommitsContext(blameLine.Commit.Sha)
+}
+
+// handleBlameAtParent blames the file again as it was before the selected line's
+// commit, so that we can see who was responsible for the line before that
+func (gui *Gui) handleBlameAtParent() error {
+	blameLine := gui.getSelectedBlameLine()
+	if blameLine == nil {
+		return nil
+	}
+
+	if blameLine.Commit.IsUncommitted() {
+		return gui.createErrorPanel(gui.Tr.SLocalize("BlameLineNotCommitted"))
+	}
+
+	if blameLine.Commit.Boundary || blameLine.Commit.PreviousSha == "" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoEarlierBlame"))
+	}
+
+	state := gui.State.Panels.Blame
+	state.History.Push(&blameHistoryEntry{
+		ref:             state.Ref,
+		path:            state.Path,
+		selectedLineIdx: state.SelectedLineIdx,
+	})
+
+	state.Ref = blameLine.Commit.PreviousSha
+	state.Path = blameLine.Commit.PreviousPath
+	// we can't know exactly where the line was before the commit changed it, but
+	// it's likely to be close to where the commit left it
+	state.SelectedLineIdx = blameLine.OriginalLineNumber - 1
+
+	return gui.refreshBlamePanel()
+}
@@ -48,7 +48,7 @@ func (gui *Gui) handleBranchSelect() error {
 // gui.refreshStatus is called at the end of this because that's when we can
 // be sure there is a state.Branches array to pick the current branch from
 func (gui *Gui) refreshBranches() {
-	reflogCommits := gui.State.FilteredReflogCommits
+	reflogCommits := gui.unfilteredReflogCommits()
 	if gui.State.Modes.Filtering.Active() {
 		// in filter mode we filter our reflog commits to just those containing the path
 		// however we need all the reflog entries to populate the recencies of our branches
@@ -66,6 +66,7 @@ func (gui *Gui) refreshBranches() {
 		_ = gui.surfaceError(err)
 	}
 	gui.State.Branches = builder.Build()
+	gui.reapplyFuzzyFilter(LOCAL_BRANCHES_CONTEXT_KEY)
 
 	if err := gui.postRefreshUpdate(gui.Contexts.Branches.Context); err != nil {
 		gui.Log.Error(err)
@@ -77,20 +78,35 @@ func (gui *Gui) refreshBranches() {
 // specific functions
 
 func (gui *Gui) handleBranchPress(g *gocui.Gui, v *gocui.View) error {
-	if gui.State.Panels.Branches.SelectedLineIdx == -1 {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
 		return nil
 	}
-	if gui.State.Panels.Branches.SelectedLineIdx == 0 {
+	// when we're fuzzy filtering the branches, the checked out branch isn't
+	// necessarily at the top
+	if branch.Name == gui.getCheckedOutBranch().Name {
 		return gui.createErrorPanel(gui.Tr.SLocalize("AlreadyCheckedOutBranch"))
 	}
-	branch := gui.getSelectedBranch()
 	return gui.handleCheckoutRef(branch.Name, handleCheckoutRefOptions{})
 }
 
 func (gui *Gui) handleCreatePullRequestPress(g *gocui.Gui, v *gocui.View) error {
-	pullRequest := commands.NewPullRequest(gui.GitCommand)
-
 	branch := gui.getSelectedBranch()
+
+	// if we can use the forge's API we create the pull request from here, otherwise
+	// we leave it to the browser
+	provider, err := gui.GitCommand.GetForge()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if provider != nil {
+		if !gui.GitCommand.CheckRemoteBranchExists(branch) {
+			return gui.createErrorPanel(gui.Tr.SLocalize("NoBranchOnRemote"))
+		}
+		return gui.createPullRequestWithForge(provider, branch)
+	}
+
+	pullRequest := commands.NewPullRequest(gui.GitCommand)
 	if err := pullRequest.Create(branch); err != nil {
 		return gui.surfaceError(err)
 	}
@@ -119,7 +135,7 @@ func (gui *Gui) handleForceCheckout(g *gocui.Gui, v *gocui.View) error {
 		title:  title,
 		prompt: message,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.Checkout(branch.Name, commands.CheckoutOptions{Force: true}); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("forceCheckout")).Checkout(branch.Name, commands.CheckoutOptions{Force: true}); err != nil {
 				_ = gui.surfaceError(err)
 			}
 			return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
@@ -148,8 +164,9 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 		gui.State.Panels.Commits.LimitCommits = true
 	}
 
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("checkout"))
 	return gui.WithWaitingStatus(waitingStatus, func() error {
-		if err := gui.GitCommand.Checkout(ref, cmdOptions); err != nil {
+		if err := gitCommand.Checkout(ref, cmdOptions); err != nil {
 			// note, this will only work for english-language git commands. If we force git to use english, and the error isn't this one, then the user will receive an english command they may not understand. I'm not sure what the best solution to this is. Running the command once in english and a second time in the native language is one option
 
 			if options.onRefNotFound != nil && strings.Contains(err.Error(), "did not match any file(s) known to git") {
@@ -163,15 +180,15 @@ func (gui *Gui) handleCheckoutRef(ref string, options handleCheckoutRefOptions)
 					title:  gui.Tr.SLocalize("AutoStashTitle"),
 					prompt: gui.Tr.SLocalize("AutoStashPrompt"),
 					handleConfirm: func() error {
-						if err := gui.GitCommand.StashSave(gui.Tr.SLocalize("StashPrefix") + ref); err != nil {
+						if err := gitCommand.StashSave(gui.Tr.SLocalize("StashPrefix") + ref); err != nil {
 							return gui.surfaceError(err)
 						}
-						if err := gui.GitCommand.Checkout(ref, cmdOptions); err != nil {
+						if err := gitCommand.Checkout(ref, cmdOptions); err != nil {
 							return gui.surfaceError(err)
 						}
 
 						onSuccess()
-						if err := gui.GitCommand.StashDo(0, "pop"); err != nil {
+						if err := gitCommand.StashDo(0, "pop"); err != nil {
 							if err := gui.refreshSidePanels(refreshOptions{mode: BLOCK_UI}); err != nil {
 								return err
 							}
@@ -211,11 +228,12 @@ func (gui *Gui) handleCheckoutByName(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) getCheckedOutBranch() *models.Branch {
-	if len(gui.State.Branches) == 0 {
+	branches := gui.unfilteredBranches()
+	if len(branches) == 0 {
 		return nil
 	}
 
-	return gui.State.Branches[0]
+	return branches[0]
 }
 
 func (gui *Gui) createNewBranchWithName(newBranchName string) error {
@@ -224,7 +242,7 @@ func (gui *Gui) createNewBranchWithName(newBranchName string) error {
 		return nil
 	}
 
-	if err := gui.GitCommand.NewBranch(newBranchName, branch.Name); err != nil {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("newBranch")).NewBranch(newBranchName, branch.Name); err != nil {
 		return gui.surfaceError(err)
 	}
 
@@ -236,19 +254,32 @@ func (gui *Gui) handleDeleteBranch(g *gocui.Gui, v *gocui.View) error {
 	return gui.deleteBranch(false)
 }
 
+// getSelectedBranches returns the branches in the selected range, or just the
+// selected branch if we're not selecting a range
+func (gui *Gui) getSelectedBranches() []*models.Branch {
+	if len(gui.State.Branches) == 0 || gui.State.Panels.Branches.SelectedLineIdx == -1 {
+		return nil
+	}
+
+	start, end := gui.State.Panels.Branches.GetSelectedRange()
+	return gui.State.Branches[start : end+1]
+}
+
 func (gui *Gui) deleteBranch(force bool) error {
-	selectedBranch := gui.getSelectedBranch()
-	if selectedBranch == nil {
+	selectedBranches := gui.getSelectedBranches()
+	if len(selectedBranches) == 0 {
 		return nil
 	}
 	checkedOutBranch := gui.getCheckedOutBranch()
-	if checkedOutBranch.Name == selectedBranch.Name {
-		return gui.createErrorPanel(gui.Tr.SLocalize("CantDeleteCheckOutBranch"))
+	for _, branch := range selectedBranches {
+		if checkedOutBranch.Name == branch.Name {
+			return gui.createErrorPanel(gui.Tr.SLocalize("CantDeleteCheckOutBranch"))
+		}
 	}
-	return gui.deleteNamedBranch(selectedBranch, force)
+	return gui.deleteNamedBranches(selectedBranches, force)
 }
 
-func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) error {
+func (gui *Gui) deleteNamedBranches(selectedBranches []*models.Branch, force bool) error {
 	title := gui.Tr.SLocalize("DeleteBranch")
 	var messageID string
 	if force {
@@ -256,10 +287,14 @@ func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) err
 	} else {
 		messageID = "DeleteBranchMessage"
 	}
+	branchNames := make([]string, len(selectedBranches))
+	for i, branch := range selectedBranches {
+		branchNames[i] = branch.Name
+	}
 	message := gui.Tr.TemplateLocalize(
 		messageID,
 		Teml{
-			"selectedBranchName": selectedBranch.Name,
+			"selectedBranchName": strings.Join(branchNames, ", "),
 		},
 	)
 
@@ -268,14 +303,29 @@ func (gui *Gui) deleteNamedBranch(selectedBranch *models.Branch, force bool) err
 		title:  title,
 		prompt: message,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.DeleteBranch(selectedBranch.Name, force); err != nil {
-				errMessage := err.Error()
-				if !force && strings.Contains(errMessage, "is not fully merged") {
-					return gui.deleteNamedBranch(selectedBranch, true)
+			gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("deleteBranch"))
+			// branches that aren't fully merged need forcing, which we ask about
+			// separately once the others are gone
+			unmergedBranches := []*models.Branch{}
+			for _, branch := range selectedBranches {
+				if err := gitCommand.DeleteBranch(branch.Name, force); err != nil {
+					errMessage := err.Error()
+					if !force && strings.Contains(errMessage, "is not fully merged") {
+						unmergedBranches = append(unmergedBranches, branch)
+						continue
+					}
+					_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES}})
+					return gui.createErrorPanel(errMessage)
 				}
-				return gui.createErrorPanel(errMessage)
 			}
-			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES}})
+			gui.State.Panels.Branches.CancelRangeSelect()
+			if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES}}); err != nil {
+				return err
+			}
+			if len(unmergedBranches) > 0 {
+				return gui.deleteNamedBranches(unmergedBranches, true)
+			}
+			return nil
 		},
 	})
 }
@@ -305,7 +355,7 @@ func (gui *Gui) mergeBranchIntoCheckedOutBranch(branchName string) error {
 		title:  gui.Tr.SLocalize("MergingTitle"),
 		prompt: prompt,
 		handleConfirm: func() error {
-			err := gui.GitCommand.Merge(branchName, commands.MergeOpts{})
+			err := gui.GitCommand.WithAction(gui.Tr.SLocalize("merge")).Merge(branchName, commands.MergeOpts{})
 			return gui.handleGenericMergeCommandResult(err)
 		},
 	})
@@ -334,6 +384,21 @@ func (gui *Gui) handleRebaseOntoBranch(selectedBranchName string) error {
 	if selectedBranchName == checkedOutBranch {
 		return gui.createErrorPanel(gui.Tr.SLocalize("CantRebaseOntoSelf"))
 	}
+
+	if gui.State.Modes.RebaseOnto.Active() {
+		return gui.handleRebaseOnto(selectedBranchName, selectedBranchName)
+	}
+
+	// if the branch has merges, you can choose whether to recreate them or
+	// flatten them, which is what git does by default
+	hasMerges, err := gui.GitCommand.HasMergesSince(selectedBranchName)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if hasMerges {
+		return gui.createRebaseWithMergesMenu(selectedBranchName)
+	}
+
 	prompt := gui.Tr.TemplateLocalize(
 		"ConfirmRebase",
 		Teml{
@@ -347,12 +412,40 @@ func (gui *Gui) handleRebaseOntoBranch(selectedBranchName string) error {
 		title:  gui.Tr.SLocalize("RebasingTitle"),
 		prompt: prompt,
 		handleConfirm: func() error {
-			err := gui.GitCommand.RebaseBranch(selectedBranchName)
+			err := gui.GitCommand.WithAction(gui.Tr.SLocalize("rebaseBranch")).RebaseBranch(selectedBranchName)
 			return gui.handleGenericMergeCommandResult(err)
 		},
 	})
 }
 
+func (gui *Gui) createRebaseWithMergesMenu(selectedBranchName string) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("RebaseFlatteningMerges"),
+			onPress: func() error {
+				err := gui.GitCommand.WithAction(gui.Tr.SLocalize("rebaseBranch")).RebaseBranch(selectedBranchName)
+				return gui.handleGenericMergeCommandResult(err)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("RebaseKeepingMerges"),
+			onPress: func() error {
+				err := gui.GitCommand.WithAction(gui.Tr.SLocalize("rebaseBranch")).RebaseBranchWithMerges(selectedBranchName)
+				return gui.handleGenericMergeCommandResult(err)
+			},
+		},
+	}
+
+	title := gui.Tr.TemplateLocalize(
+		"RebaseWithMergesTitle",
+		Teml{
+			"checkedOutBranch": gui.getCheckedOutBranch().Name,
+			"selectedBranch":   selectedBranchName,
+		},
+	)
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
 func (gui *Gui) handleFastForward(g *gocui.Gui, v *gocui.View) error {
 	branch := gui.getSelectedBranch()
 	if branch == nil {
@@ -387,10 +480,10 @@ func (gui *Gui) handleFastForward(g *gocui.Gui, v *gocui.View) error {
 	go func() {
 		_ = gui.createLoaderPanel(v, message)
 
-		if gui.State.Panels.Branches.SelectedLineIdx == 0 {
+		if branch.Name == gui.getCheckedOutBranch().Name {
 			_ = gui.pullWithMode("ff-only", PullFilesOptions{})
 		} else {
-			err := gui.GitCommand.FastForward(branch.Name, remoteName, remoteBranchName, gui.promptUserForCredential)
+			err := gui.GitCommand.WithAction(gui.Tr.SLocalize("FastForward")).FastForward(branch.Name, remoteName, remoteBranchName, gui.promptUserForCredential)
 			gui.handleCredentialsPopup(err)
 			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{BRANCHES}})
 		}
@@ -418,12 +511,13 @@ func (gui *Gui) handleRenameBranch(g *gocui.Gui, v *gocui.View) error {
 
 	promptForNewName := func() error {
 		return gui.prompt(gui.Tr.SLocalize("NewBranchNamePrompt")+" "+branch.Name+":", "", func(newBranchName string) error {
-			if err := gui.GitCommand.RenameBranch(branch.Name, newBranchName); err != nil {
+			gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("renameBranch"))
+			if err := gitCommand.RenameBranch(branch.Name, newBranchName); err != nil {
 				return gui.surfaceError(err)
 			}
 			// need to checkout so that the branch shows up in our reflog and therefore
 			// doesn't get lost among all the other branches when we switch to something else
-			if err := gui.GitCommand.Checkout(newBranchName, commands.CheckoutOptions{Force: false}); err != nil {
+			if err := gitCommand.Checkout(newBranchName, commands.CheckoutOptions{Force: false}); err != nil {
 				return gui.surfaceError(err)
 			}
 
@@ -448,10 +542,11 @@ func (gui *Gui) handleRenameBranch(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) currentBranch() *models.Branch {
-	if len(gui.State.Branches) == 0 {
+	branches := gui.unfilteredBranches()
+	if len(branches) == 0 {
 		return nil
 	}
-	return gui.State.Branches[0]
+	return branches[0]
 }
 
 func (gui *Gui) handleNewBranchOffCurrentItem() error {
@@ -475,7 +570,7 @@ func (gui *Gui) handleNewBranchOffCurrentItem() error {
 		prefilledName = item.ID()
 	}
 	return gui.prompt(message, prefilledName, func(response string) error {
-		if err := gui.GitCommand.NewBranch(response, item.ID()); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("newBranch")).NewBranch(response, item.ID()); err != nil {
 			return err
 		}
 
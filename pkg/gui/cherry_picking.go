@@ -131,7 +131,10 @@ func (gui *Gui) handleCopyCommitRange() error {
 	}
 
 	for index := startIndex; index <= selectedLineIdx; index++ {
-		gui.addCommitToCherryPickedCommits(index)
+		// mid-rebase, lines of the todo like 'exec' have no commit to copy
+		if commitsList[index].Sha != "" {
+			gui.addCommitToCherryPickedCommits(index)
+		}
 	}
 
 	return context.HandleRender()
@@ -148,7 +151,7 @@ func (gui *Gui) HandlePasteCommits() error {
 		prompt: gui.Tr.SLocalize("SureCherryPick"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("CherryPickingStatus"), func() error {
-				err := gui.GitCommand.CherryPickCommits(gui.State.Modes.CherryPicking.CherryPickedCommits)
+				err := gui.GitCommand.WithAction(gui.Tr.SLocalize("pasteCommits")).CherryPickCommits(gui.State.Modes.CherryPicking.CherryPickedCommits)
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
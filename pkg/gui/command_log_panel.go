@@ -0,0 +1,83 @@
+package gui
+
+import (
+	"path/filepath"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// the command log shows the commands we've run on the user's behalf, grouped by
+// the action that triggered them, so that it's clear what each action actually
+// did to the repo
+
+func (gui *Gui) handleCreateCommandLogMenu(g *gocui.Gui, v *gocui.View) error {
+	showCommandLogKey := "ShowCommandLog"
+	if gui.ShowCommandLog {
+		showCommandLogKey = "HideCommandLog"
+	}
+
+	showBackgroundCommandsKey := "ShowBackgroundCommands"
+	if gui.ShowBackgroundCommands {
+		showBackgroundCommandsKey = "HideBackgroundCommands"
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize(showCommandLogKey),
+			onPress: func() error {
+				gui.ShowCommandLog = !gui.ShowCommandLog
+				gui.renderCommandLog()
+				return nil
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize(showBackgroundCommandsKey),
+			onPress: func() error {
+				gui.ShowBackgroundCommands = !gui.ShowBackgroundCommands
+				gui.renderCommandLog()
+				return nil
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("CopyCommandLog"),
+			onPress: func() error {
+				return gui.OSCommand.CopyToClipboard(gui.commandLogText())
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("SaveCommandLog"),
+			onPress: func() error {
+				defaultPath := filepath.Join(gui.Config.GetUserConfigDir(), "command_log.txt")
+				return gui.prompt(gui.Tr.SLocalize("SaveCommandLogPrompt"), defaultPath, func(path string) error {
+					return gui.OSCommand.CreateFileWithContent(path, gui.commandLogText()+"\n")
+				})
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommandLogTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// renderCommandLog is safe to call from any goroutine
+func (gui *Gui) renderCommandLog() {
+	if !gui.ShowCommandLog {
+		return
+	}
+
+	gui.renderString("commandLog", presentation.GetCmdLogDisplayString(
+		gui.OSCommand.CmdLog.Actions(),
+		gui.ShowBackgroundCommands,
+		gui.Tr.SLocalize("BackgroundCommands"),
+	))
+}
+
+// commandLogText is the command log as we'd show it, minus the colors
+func (gui *Gui) commandLogText() string {
+	return utils.Decolorise(presentation.GetCmdLogDisplayString(
+		gui.OSCommand.CmdLog.Actions(),
+		gui.ShowBackgroundCommands,
+		gui.Tr.SLocalize("BackgroundCommands"),
+	))
+}
@@ -3,30 +3,52 @@ package gui
 import (
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 )
 
+func (gui *Gui) getSelectedCommitFileNode() *filetree.Node {
+	return gui.State.CommitFileManager.GetNodeAtIndex(gui.State.Panels.CommitFiles.SelectedLineIdx)
+}
+
+// getSelectedCommitFileNodes returns the nodes in the selected range, or just
+// the selected node if we're not selecting a range
+func (gui *Gui) getSelectedCommitFileNodes() []*filetree.Node {
+	start, end := gui.State.Panels.CommitFiles.GetSelectedRange()
+	nodes := []*filetree.Node{}
+	for i := start; i <= end; i++ {
+		if node := gui.State.CommitFileManager.GetNodeAtIndex(i); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// getSelectedCommitFile returns nil if a directory is selected
 func (gui *Gui) getSelectedCommitFile() *models.CommitFile {
-	selectedLine := gui.State.Panels.CommitFiles.SelectedLineIdx
-	if selectedLine == -1 || selectedLine > len(gui.State.CommitFiles)-1 {
+	node := gui.getSelectedCommitFileNode()
+	if node == nil {
 		return nil
 	}
 
-	return gui.State.CommitFiles[selectedLine]
+	return node.CommitFile()
 }
 
 func (gui *Gui) handleCommitFileSelect() error {
 	gui.handleEscapeLineByLinePanel()
 
-	commitFile := gui.getSelectedCommitFile()
-	if commitFile == nil {
+	node := gui.getSelectedCommitFileNode()
+	if node == nil {
 		return nil
 	}
 
-	to := commitFile.Parent
+	to := gui.State.Panels.CommitFiles.refName
 	from, reverse := gui.getFromAndReverseArgsForDiff(to)
 
+	// given that we pass the path along as a pathspec, this works for
+	// directories as well as files
 	cmd := gui.OSCommand.ExecutableFromString(
-		gui.GitCommand.ShowFileDiffCmdStr(from, to, reverse, commitFile.Name, false),
+		gui.GitCommand.ShowFileDiffCmdStr(from, to, reverse, node.Path, false),
 	)
 	task := gui.createRunPtyTask(cmd)
 
@@ -40,12 +62,13 @@ func (gui *Gui) handleCommitFileSelect() error {
 }
 
 func (gui *Gui) handleCheckoutCommitFile(g *gocui.Gui, v *gocui.View) error {
-	file := gui.getSelectedCommitFile()
-	if file == nil {
+	node := gui.getSelectedCommitFileNode()
+	if node == nil {
 		return nil
 	}
 
-	if err := gui.GitCommand.CheckoutFile(file.Parent, file.Name); err != nil {
+	// this checks out every file under a directory in one go
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("checkoutCommitFile")).CheckoutFile(gui.State.Panels.CommitFiles.refName, node.Path); err != nil {
 		return gui.surfaceError(err)
 	}
 
@@ -57,14 +80,18 @@ func (gui *Gui) handleDiscardOldFileChange(g *gocui.Gui, v *gocui.View) error {
 		return err
 	}
 
-	fileName := gui.State.CommitFiles[gui.State.Panels.CommitFiles.SelectedLineIdx].Name
+	file := gui.getSelectedCommitFile()
+	if file == nil {
+		return nil
+	}
+	fileName := file.Name
 
 	return gui.ask(askOpts{
 		title:  gui.Tr.SLocalize("DiscardFileChangesTitle"),
 		prompt: gui.Tr.SLocalize("DiscardFileChangesPrompt"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
-				if err := gui.GitCommand.DiscardOldFileChanges(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, fileName); err != nil {
+				if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("discardOldFileChange")).DiscardOldFileChanges(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, fileName); err != nil {
 					if err := gui.handleGenericMergeCommandResult(err); err != nil {
 						return err
 					}
@@ -88,7 +115,7 @@ func (gui *Gui) refreshCommitFilesView() error {
 	if err != nil {
 		return gui.surfaceError(err)
 	}
-	gui.State.CommitFiles = files
+	gui.State.CommitFileManager.SetCommitFiles(files)
 
 	return gui.postRefreshUpdate(gui.Contexts.CommitFiles.Context)
 }
@@ -112,8 +139,8 @@ func (gui *Gui) handleEditCommitFile(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
-	commitFile := gui.getSelectedCommitFile()
-	if commitFile == nil {
+	nodes := gui.getSelectedCommitFileNodes()
+	if len(nodes) == 0 {
 		return nil
 	}
 
@@ -124,8 +151,26 @@ func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
 			}
 		}
 
-		if err := gui.GitCommand.PatchManager.ToggleFileWhole(commitFile.Name); err != nil {
-			return err
+		if len(nodes) == 1 {
+			if err := gui.toggleNodeForPatch(nodes[0]); err != nil {
+				return err
+			}
+		} else {
+			// as with a directory, we treat the range as one group of files
+			commitFiles := []*models.CommitFile{}
+			seen := map[string]bool{}
+			for _, node := range nodes {
+				for _, commitFile := range node.CommitFiles() {
+					if !seen[commitFile.Name] {
+						seen[commitFile.Name] = true
+						commitFiles = append(commitFiles, commitFile)
+					}
+				}
+			}
+			if err := gui.toggleCommitFilesForPatch(commitFiles); err != nil {
+				return err
+			}
+			gui.State.Panels.CommitFiles.CancelRangeSelect()
 		}
 
 		if gui.GitCommand.PatchManager.IsEmpty() {
@@ -135,7 +180,7 @@ func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
 		return gui.refreshCommitFilesView()
 	}
 
-	if gui.GitCommand.PatchManager.Active() && gui.GitCommand.PatchManager.To != commitFile.Parent {
+	if gui.GitCommand.PatchManager.Active() && gui.GitCommand.PatchManager.To != gui.State.Panels.CommitFiles.refName {
 		return gui.ask(askOpts{
 			title:  gui.Tr.SLocalize("DiscardPatch"),
 			prompt: gui.Tr.SLocalize("DiscardPatchConfirm"),
@@ -149,6 +194,43 @@ func (gui *Gui) handleToggleFileForPatch(g *gocui.Gui, v *gocui.View) error {
 	return toggleTheFile()
 }
 
+// toggleNodeForPatch adds or removes a file from the patch. For a directory, we
+// add all of its files unless they're all in the patch already, in which case
+// we remove them all
+func (gui *Gui) toggleNodeForPatch(node *filetree.Node) error {
+	if node.IsLeaf() {
+		return gui.GitCommand.PatchManager.ToggleFileWhole(node.CommitFile().Name)
+	}
+
+	return gui.toggleCommitFilesForPatch(node.CommitFiles())
+}
+
+// toggleCommitFilesForPatch adds all of the files to the patch unless they're
+// all in it already, in which case it removes them all
+func (gui *Gui) toggleCommitFilesForPatch(commitFiles []*models.CommitFile) error {
+	allWhole := true
+	for _, commitFile := range commitFiles {
+		if commitFile.PatchStatus != patch.WHOLE {
+			allWhole = false
+			break
+		}
+	}
+
+	for _, commitFile := range commitFiles {
+		var err error
+		if allWhole {
+			err = gui.GitCommand.PatchManager.RemoveFile(commitFile.Name)
+		} else {
+			err = gui.GitCommand.PatchManager.AddFileWhole(commitFile.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (gui *Gui) startPatchManager() error {
 	canRebase := gui.State.Panels.CommitFiles.canRebase
 
@@ -164,11 +246,18 @@ func (gui *Gui) handleEnterCommitFile(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) enterCommitFile(selectedLineIdx int) error {
-	commitFile := gui.getSelectedCommitFile()
-	if commitFile == nil {
+	node := gui.getSelectedCommitFileNode()
+	if node == nil {
 		return nil
 	}
 
+	if !node.IsLeaf() {
+		gui.State.CommitFileManager.ToggleCollapsed(node.Path)
+		return gui.postRefreshUpdate(gui.Contexts.CommitFiles.Context)
+	}
+
+	commitFile := node.CommitFile()
+
 	enterTheFile := func(selectedLineIdx int) error {
 		if !gui.GitCommand.PatchManager.Active() {
 			if err := gui.startPatchManager(); err != nil {
@@ -217,3 +306,20 @@ func (gui *Gui) switchToCommitFilesContext(refName string, canRebase bool, conte
 
 	return gui.switchContext(gui.Contexts.CommitFiles.Context)
 }
+
+func (gui *Gui) handleToggleCommitFileTreeView(g *gocui.Gui, v *gocui.View) error {
+	selectedNode := gui.getSelectedCommitFileNode()
+
+	gui.State.CommitFileManager.ToggleShowTree()
+
+	// keep the same file selected, and if we had a directory selected, select
+	// its first file
+	if selectedNode != nil {
+		path := selectedNode.Leaves()[0].Path
+		if index, ok := gui.State.CommitFileManager.GetIndexForPath(path); ok {
+			gui.State.Panels.CommitFiles.SelectedLineIdx = index
+		}
+	}
+
+	return gui.postRefreshUpdate(gui.Contexts.CommitFiles.Context)
+}
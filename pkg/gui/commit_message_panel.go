@@ -35,7 +35,7 @@ func (gui *Gui) handleCommitConfirm(g *gocui.Gui, v *gocui.View) error {
 	if skipHookPrefix != "" && strings.HasPrefix(message, skipHookPrefix) {
 		flags = "--no-verify"
 	}
-	ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.Commit(message, flags))
+	ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.WithAction(gui.Tr.SLocalize("CommitChanges")).Commit(message, flags))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,104 @@
+package gui
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// searching commits narrows down the commits panel to the commits matching
+// everything you've asked for, e.g. the commits by a given author that added or
+// removed a given string. It works on top of filtering by path, so you can
+// search within a file's history
+
+func (gui *Gui) handleCreateCommitSearchMenu() error {
+	options := gui.State.Modes.CommitSearch.Options
+
+	// setOption asks for the new value, where leaving it blank stops searching
+	// by that criterion
+	setOption := func(titleKey string, value string, set func(options *commands.CommitSearchOptions, value string)) func() error {
+		return func() error {
+			return gui.prompt(gui.Tr.SLocalize(titleKey), value, func(response string) error {
+				set(&gui.State.Modes.CommitSearch.Options, strings.TrimSpace(response))
+				return gui.refreshCommitSearch()
+			})
+		}
+	}
+
+	valueString := func(value string) string {
+		return utils.ColoredString(value, color.FgCyan)
+	}
+
+	pickaxe, pickaxeRegex := options.Pickaxe, options.Pickaxe
+	if options.PickaxeRegex {
+		pickaxe = ""
+	} else {
+		pickaxeRegex = ""
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("searchCommitsByMessage"), valueString(options.Message)},
+			onPress: setOption("searchCommitsByMessage", options.Message, func(options *commands.CommitSearchOptions, value string) {
+				options.Message = value
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("searchCommitsByAuthor"), valueString(options.Author)},
+			onPress: setOption("searchCommitsByAuthor", options.Author, func(options *commands.CommitSearchOptions, value string) {
+				options.Author = value
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("searchCommitsByString"), valueString(pickaxe)},
+			onPress: setOption("searchCommitsByString", pickaxe, func(options *commands.CommitSearchOptions, value string) {
+				options.Pickaxe = value
+				options.PickaxeRegex = false
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("searchCommitsByRegex"), valueString(pickaxeRegex)},
+			onPress: setOption("searchCommitsByRegex", pickaxeRegex, func(options *commands.CommitSearchOptions, value string) {
+				options.Pickaxe = value
+				options.PickaxeRegex = value != ""
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("searchCommitsSince"), valueString(options.Since)},
+			onPress: setOption("searchCommitsSince", options.Since, func(options *commands.CommitSearchOptions, value string) {
+				options.Since = value
+			}),
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("searchCommitsUntil"), valueString(options.Until)},
+			onPress: setOption("searchCommitsUntil", options.Until, func(options *commands.CommitSearchOptions, value string) {
+				options.Until = value
+			}),
+		},
+	}
+
+	if gui.State.Modes.CommitSearch.Active() {
+		menuItems = append(menuItems, &menuItem{
+			displayStrings: []string{gui.Tr.SLocalize("exitCommitSearch")},
+			onPress:        gui.exitCommitSearchMode,
+		})
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("CommitSearchMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+// refreshCommitSearch reloads the commits for the new search, starting from the
+// top given the commit you had selected may no longer be there. We refresh
+// synchronously so that the selection fits the new commits before the commits
+// panel gets focus back
+func (gui *Gui) refreshCommitSearch() error {
+	gui.State.Panels.Commits.SelectedLineIdx = 0
+	return gui.refreshSidePanels(refreshOptions{mode: SYNC, scope: []int{COMMITS}})
+}
+
+func (gui *Gui) exitCommitSearchMode() error {
+	gui.State.Modes.CommitSearch = CommitSearch{}
+	return gui.refreshCommitSearch()
+}
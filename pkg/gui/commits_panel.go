@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"strconv"
 	"sync"
 
 	"github.com/jesseduffield/gocui"
@@ -10,7 +11,21 @@ import (
 
 // list panel functions
 
+// getSelectedLocalCommit returns the selected commit. Mid-rebase, lines of the
+// todo like 'exec' and 'break' have no commit to check out, reset to, tag and
+// so on, so we return nil for those. See getSelectedCommitsLine
 func (gui *Gui) getSelectedLocalCommit() *models.Commit {
+	commit := gui.getSelectedCommitsLine()
+	if commit == nil || commit.Sha == "" {
+		return nil
+	}
+
+	return commit
+}
+
+// getSelectedCommitsLine returns whatever is selected in the commits panel,
+// including lines of the rebase todo that don't pick a commit
+func (gui *Gui) getSelectedCommitsLine() *models.Commit {
 	selectedLine := gui.State.Panels.Commits.SelectedLineIdx
 	if selectedLine == -1 {
 		return nil
@@ -33,9 +48,12 @@ func (gui *Gui) handleCommitSelect() error {
 	gui.handleEscapeLineByLinePanel()
 
 	var task updateTask
-	commit := gui.getSelectedLocalCommit()
+	commit := gui.getSelectedCommitsLine()
 	if commit == nil {
 		task = gui.createRenderStringTask(gui.Tr.SLocalize("NoCommitsThisBranch"))
+	} else if commit.Sha == "" {
+		// a line of the rebase todo like 'exec make test' has no commit to show
+		task = gui.createRenderStringTask(commit.Description())
 	} else {
 		cmd := gui.OSCommand.ExecutableFromString(
 			gui.GitCommand.ShowCmdStr(commit.Sha, gui.State.Modes.Filtering.Path),
@@ -108,10 +126,27 @@ func (gui *Gui) refreshCommits() error {
 	return nil
 }
 
+// commitGraphLines returns the graph for the commits panel. When we're filtering
+// by path or searching git only gives us some of the commits, and when we're
+// fuzzy filtering we only show some of them, so there's no graph to draw
+func (gui *Gui) commitGraphLines() []string {
+	if gui.State.Modes.Filtering.Active() || gui.State.Modes.CommitSearch.Active() || gui.isFuzzyFiltering(BRANCH_COMMITS_CONTEXT_KEY) {
+		return nil
+	}
+
+	return gui.State.Panels.Commits.Graph.Lines(gui.State.Commits)
+}
+
 func (gui *Gui) refreshCommitsWithLimit() error {
 	gui.State.BranchCommitsMutex.Lock()
 	defer gui.State.BranchCommitsMutex.Unlock()
 
+	bisectInfo, err := gui.GitCommand.GetBisectInfo()
+	if err != nil {
+		return err
+	}
+	gui.State.Modes.Bisecting.Info = bisectInfo
+
 	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
 
 	commits, err := builder.GetCommits(
@@ -119,13 +154,15 @@ func (gui *Gui) refreshCommitsWithLimit() error {
 			Limit:                gui.State.Panels.Commits.LimitCommits,
 			FilterPath:           gui.State.Modes.Filtering.Path,
 			IncludeRebaseCommits: true,
-			RefName:              "HEAD",
+			RefName:              gui.refForLog(),
+			Search:               gui.State.Modes.CommitSearch.Options,
 		},
 	)
 	if err != nil {
 		return err
 	}
 	gui.State.Commits = commits
+	gui.reapplyFuzzyFilter(BRANCH_COMMITS_CONTEXT_KEY)
 
 	return gui.postRefreshUpdate(gui.Contexts.BranchCommits.Context)
 }
@@ -136,11 +173,12 @@ func (gui *Gui) refreshRebaseCommits() error {
 
 	builder := commands.NewCommitListBuilder(gui.Log, gui.GitCommand, gui.OSCommand, gui.Tr)
 
-	updatedCommits, err := builder.MergeRebasingCommits(gui.State.Commits)
+	updatedCommits, err := builder.MergeRebasingCommits(gui.unfilteredCommits())
 	if err != nil {
 		return err
 	}
 	gui.State.Commits = updatedCommits
+	gui.reapplyFuzzyFilter(BRANCH_COMMITS_CONTEXT_KEY)
 
 	return gui.postRefreshUpdate(gui.Contexts.BranchCommits.Context)
 }
@@ -166,10 +204,10 @@ func (gui *Gui) handleCommitSquashDown(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.ask(askOpts{
 		title:  gui.Tr.SLocalize("Squash"),
-		prompt: gui.Tr.SLocalize("SureSquashThisCommit"),
+		prompt: gui.commitRangePrompt("SureSquashThisCommit", "SureSquashTheseCommits"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("SquashingStatus"), func() error {
-				err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "squash")
+				err := gui.rebaseSelectedCommits(gui.Tr.SLocalize("squashDown"), "squash")
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -195,10 +233,10 @@ func (gui *Gui) handleCommitFixup(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.ask(askOpts{
 		title:  gui.Tr.SLocalize("Fixup"),
-		prompt: gui.Tr.SLocalize("SureFixupThisCommit"),
+		prompt: gui.commitRangePrompt("SureFixupThisCommit", "SureFixupTheseCommits"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("FixingStatus"), func() error {
-				err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "fixup")
+				err := gui.rebaseSelectedCommits(gui.Tr.SLocalize("fixupCommit"), "fixup")
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -233,7 +271,7 @@ func (gui *Gui) handleRenameCommit(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	return gui.prompt(gui.Tr.SLocalize("renameCommit"), message, func(response string) error {
-		if err := gui.GitCommand.RenameCommit(response); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("renameCommit")).RenameCommit(response); err != nil {
 			return gui.surfaceError(err)
 		}
 
@@ -254,7 +292,7 @@ func (gui *Gui) handleRenameCommitEditor(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	subProcess, err := gui.GitCommand.RewordCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx)
+	subProcess, err := gui.GitCommand.WithAction(gui.Tr.SLocalize("renameCommitEditor")).RewordCommit(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx)
 	if err != nil {
 		return gui.surfaceError(err)
 	}
@@ -266,14 +304,23 @@ func (gui *Gui) handleRenameCommitEditor(g *gocui.Gui, v *gocui.View) error {
 	return nil
 }
 
-// handleMidRebaseCommand sees if the selected commit is in fact a rebasing
-// commit meaning you are trying to edit the todo file rather than actually
+// handleMidRebaseCommand sees if the selected commits are in fact rebasing
+// commits meaning you are trying to edit the todo file rather than actually
 // begin a rebase. It then updates the todo file with that action
 func (gui *Gui) handleMidRebaseCommand(action string) (bool, error) {
-	selectedCommit := gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx]
-	if selectedCommit.Status != "rebasing" {
+	start, end := gui.State.Panels.Commits.GetSelectedRange()
+	rebasingCount := 0
+	for _, commit := range gui.State.Commits[start : end+1] {
+		if commit.Status == "rebasing" {
+			rebasingCount++
+		}
+	}
+	if rebasingCount == 0 {
 		return false, nil
 	}
+	if rebasingCount != end-start+1 {
+		return true, gui.createErrorPanel(gui.Tr.SLocalize("MixedRebasingRange"))
+	}
 
 	// for now we do not support setting 'reword' because it requires an editor
 	// and that means we either unconditionally wait around for the subprocess to ask for
@@ -283,13 +330,42 @@ func (gui *Gui) handleMidRebaseCommand(action string) (bool, error) {
 		return true, gui.createErrorPanel(gui.Tr.SLocalize("rewordNotSupported"))
 	}
 
-	if err := gui.GitCommand.EditRebaseTodo(gui.State.Panels.Commits.SelectedLineIdx, action); err != nil {
-		return false, gui.surfaceError(err)
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.TemplateLocalize("EditRebaseTodoAction", Teml{"action": action}))
+	// going from the bottom up so that dropping a line doesn't move the others
+	for i := end; i >= start; i-- {
+		var err error
+		if gui.State.Commits[i].IsTodo() && action == "drop" {
+			err = gitCommand.DeleteTodoLine(i)
+		} else {
+			err = gitCommand.EditRebaseTodo(i, action)
+		}
+		if err != nil {
+			_ = gui.refreshRebaseCommits()
+			return true, gui.surfaceError(err)
+		}
 	}
 
+	gui.State.Panels.Commits.CancelRangeSelect()
 	return true, gui.refreshRebaseCommits()
 }
 
+// rebaseSelectedCommits applies the action to the selected commits in one
+// interactive rebase
+func (gui *Gui) rebaseSelectedCommits(actionName string, action string) error {
+	start, end := gui.State.Panels.Commits.GetSelectedRange()
+	gui.State.Panels.Commits.CancelRangeSelect()
+	return gui.GitCommand.WithAction(actionName).InteractiveRebaseRange(gui.State.Commits, start, end, action)
+}
+
+// commitRangePrompt returns the prompt for acting on the selected commits,
+// which differs when there's more than one of them
+func (gui *Gui) commitRangePrompt(singleID string, rangeID string) string {
+	if start, end := gui.State.Panels.Commits.GetSelectedRange(); start != end {
+		return gui.Tr.TemplateLocalize(rangeID, Teml{"count": strconv.Itoa(end - start + 1)})
+	}
+	return gui.Tr.SLocalize(singleID)
+}
+
 func (gui *Gui) handleCommitDelete(g *gocui.Gui, v *gocui.View) error {
 	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
 		return err
@@ -305,10 +381,10 @@ func (gui *Gui) handleCommitDelete(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.ask(askOpts{
 		title:  gui.Tr.SLocalize("DeleteCommitTitle"),
-		prompt: gui.Tr.SLocalize("DeleteCommitPrompt"),
+		prompt: gui.commitRangePrompt("DeleteCommitPrompt", "DeleteCommitsPrompt"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("DeletingStatus"), func() error {
-				err := gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "drop")
+				err := gui.rebaseSelectedCommits(gui.Tr.SLocalize("deleteCommit"), "drop")
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -326,7 +402,7 @@ func (gui *Gui) handleCommitMoveDown(g *gocui.Gui, v *gocui.View) error {
 		if gui.State.Commits[index+1].Status != "rebasing" {
 			return nil
 		}
-		if err := gui.GitCommand.MoveTodoDown(index); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("moveDownCommit")).MoveTodoDown(index); err != nil {
 			return gui.surfaceError(err)
 		}
 		gui.State.Panels.Commits.SelectedLineIdx++
@@ -334,7 +410,7 @@ func (gui *Gui) handleCommitMoveDown(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("MovingStatus"), func() error {
-		err := gui.GitCommand.MoveCommitDown(gui.State.Commits, index)
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("moveDownCommit")).MoveCommitDown(gui.State.Commits, index)
 		if err == nil {
 			gui.State.Panels.Commits.SelectedLineIdx++
 		}
@@ -353,7 +429,7 @@ func (gui *Gui) handleCommitMoveUp(g *gocui.Gui, v *gocui.View) error {
 	}
 	selectedCommit := gui.State.Commits[index]
 	if selectedCommit.Status == "rebasing" {
-		if err := gui.GitCommand.MoveTodoDown(index - 1); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("moveUpCommit")).MoveTodoDown(index - 1); err != nil {
 			return gui.surfaceError(err)
 		}
 		gui.State.Panels.Commits.SelectedLineIdx--
@@ -361,7 +437,7 @@ func (gui *Gui) handleCommitMoveUp(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("MovingStatus"), func() error {
-		err := gui.GitCommand.MoveCommitDown(gui.State.Commits, index-1)
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("moveUpCommit")).MoveCommitDown(gui.State.Commits, index-1)
 		if err == nil {
 			gui.State.Panels.Commits.SelectedLineIdx--
 		}
@@ -383,7 +459,7 @@ func (gui *Gui) handleCommitEdit(g *gocui.Gui, v *gocui.View) error {
 	}
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
-		err = gui.GitCommand.InteractiveRebase(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, "edit")
+		err = gui.rebaseSelectedCommits(gui.Tr.SLocalize("editCommit"), "edit")
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -393,12 +469,17 @@ func (gui *Gui) handleCommitAmendTo(g *gocui.Gui, v *gocui.View) error {
 		return err
 	}
 
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.SLocalize("AmendCommitTitle"),
 		prompt: gui.Tr.SLocalize("AmendCommitPrompt"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("AmendingStatus"), func() error {
-				err := gui.GitCommand.AmendTo(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha)
+				err := gui.GitCommand.WithAction(gui.Tr.SLocalize("amendToCommit")).AmendTo(commit.Sha)
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -428,7 +509,12 @@ func (gui *Gui) handleCommitRevert(g *gocui.Gui, v *gocui.View) error {
 		return err
 	}
 
-	if err := gui.GitCommand.Revert(gui.State.Commits[gui.State.Panels.Commits.SelectedLineIdx].Sha); err != nil {
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("revertCommit")).Revert(commit.Sha); err != nil {
 		return gui.surfaceError(err)
 	}
 	gui.State.Panels.Commits.SelectedLineIdx++
@@ -476,7 +562,7 @@ func (gui *Gui) handleCreateFixupCommit(g *gocui.Gui, v *gocui.View) error {
 			},
 		),
 		handleConfirm: func() error {
-			if err := gui.GitCommand.CreateFixupCommit(commit.Sha); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("createFixupCommit")).CreateFixupCommit(commit.Sha); err != nil {
 				return gui.surfaceError(err)
 			}
 
@@ -505,7 +591,7 @@ func (gui *Gui) handleSquashAllAboveFixupCommits(g *gocui.Gui, v *gocui.View) er
 		),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("SquashingStatus"), func() error {
-				err := gui.GitCommand.SquashAllAboveFixupCommits(commit.Sha)
+				err := gui.GitCommand.WithAction(gui.Tr.SLocalize("squashAboveCommits")).SquashAllAboveFixupCommits(commit.Sha)
 				return gui.handleGenericMergeCommandResult(err)
 			})
 		},
@@ -513,24 +599,12 @@ func (gui *Gui) handleSquashAllAboveFixupCommits(g *gocui.Gui, v *gocui.View) er
 }
 
 func (gui *Gui) handleTagCommit(g *gocui.Gui, v *gocui.View) error {
-	// TODO: bring up menu asking if you want to make a lightweight or annotated tag
-	// if annotated, switch to a subprocess to create the message
-
 	commit := gui.getSelectedLocalCommit()
 	if commit == nil {
 		return nil
 	}
 
-	return gui.handleCreateLightweightTag(commit.Sha)
-}
-
-func (gui *Gui) handleCreateLightweightTag(commitSha string) error {
-	return gui.prompt(gui.Tr.SLocalize("TagNameTitle"), "", func(response string) error {
-		if err := gui.GitCommand.CreateLightweightTag(response, commitSha); err != nil {
-			return gui.surfaceError(err)
-		}
-		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{COMMITS, TAGS}})
-	})
+	return gui.createTagMenu(commit.Sha)
 }
 
 func (gui *Gui) handleCheckoutCommit(g *gocui.Gui, v *gocui.View) error {
@@ -16,7 +16,9 @@ const (
 const (
 	STATUS_CONTEXT_KEY              = "status"
 	FILES_CONTEXT_KEY               = "files"
+	SUBMODULES_CONTEXT_KEY          = "submodules"
 	LOCAL_BRANCHES_CONTEXT_KEY      = "localBranches"
+	WORKTREES_CONTEXT_KEY           = "worktrees"
 	REMOTES_CONTEXT_KEY             = "remotes"
 	REMOTE_BRANCHES_CONTEXT_KEY     = "remoteBranches"
 	TAGS_CONTEXT_KEY                = "tags"
@@ -29,17 +31,23 @@ const (
 	MAIN_MERGING_CONTEXT_KEY        = "merging"
 	MAIN_PATCH_BUILDING_CONTEXT_KEY = "patchBuilding"
 	MAIN_STAGING_CONTEXT_KEY        = "staging"
+	MAIN_BLAME_CONTEXT_KEY          = "blame"
+	MAIN_MERGE_EDITOR_CONTEXT_KEY   = "mergeEditor"
+	MERGE_RESULT_CONTEXT_KEY        = "mergeResult"
 	MENU_CONTEXT_KEY                = "menu"
 	CREDENTIALS_CONTEXT_KEY         = "credentials"
 	CONFIRMATION_CONTEXT_KEY        = "confirmation"
 	SEARCH_CONTEXT_KEY              = "search"
+	FUZZY_FILTER_CONTEXT_KEY        = "fuzzyFilter"
 	COMMIT_MESSAGE_CONTEXT_KEY      = "commitMessage"
 )
 
 var allContextKeys = []string{
 	STATUS_CONTEXT_KEY,
 	FILES_CONTEXT_KEY,
+	SUBMODULES_CONTEXT_KEY,
 	LOCAL_BRANCHES_CONTEXT_KEY,
+	WORKTREES_CONTEXT_KEY,
 	REMOTES_CONTEXT_KEY,
 	REMOTE_BRANCHES_CONTEXT_KEY,
 	TAGS_CONTEXT_KEY,
@@ -52,10 +60,14 @@ var allContextKeys = []string{
 	MAIN_MERGING_CONTEXT_KEY,
 	MAIN_PATCH_BUILDING_CONTEXT_KEY,
 	MAIN_STAGING_CONTEXT_KEY,
+	MAIN_BLAME_CONTEXT_KEY,
+	MAIN_MERGE_EDITOR_CONTEXT_KEY,
+	MERGE_RESULT_CONTEXT_KEY,
 	MENU_CONTEXT_KEY,
 	CREDENTIALS_CONTEXT_KEY,
 	CONFIRMATION_CONTEXT_KEY,
 	SEARCH_CONTEXT_KEY,
+	FUZZY_FILTER_CONTEXT_KEY,
 	COMMIT_MESSAGE_CONTEXT_KEY,
 }
 
@@ -151,8 +163,10 @@ type RemotesContextNode struct {
 type ContextTree struct {
 	Status        SimpleContextNode
 	Files         SimpleContextNode
+	Submodules    SimpleContextNode
 	Menu          SimpleContextNode
 	Branches      SimpleContextNode
+	Worktrees     SimpleContextNode
 	Remotes       RemotesContextNode
 	Tags          SimpleContextNode
 	BranchCommits SimpleContextNode
@@ -164,17 +178,23 @@ type ContextTree struct {
 	Staging       SimpleContextNode
 	PatchBuilding SimpleContextNode
 	Merging       SimpleContextNode
+	Blame         SimpleContextNode
+	MergeEditor   SimpleContextNode
+	MergeResult   SimpleContextNode
 	Credentials   SimpleContextNode
 	Confirmation  SimpleContextNode
 	CommitMessage SimpleContextNode
 	Search        SimpleContextNode
+	FuzzyFilter   SimpleContextNode
 }
 
 func (gui *Gui) allContexts() []Context {
 	return []Context{
 		gui.Contexts.Status.Context,
 		gui.Contexts.Files.Context,
+		gui.Contexts.Submodules.Context,
 		gui.Contexts.Branches.Context,
+		gui.Contexts.Worktrees.Context,
 		gui.Contexts.Remotes.Context,
 		gui.Contexts.Remotes.Branches.Context,
 		gui.Contexts.Tags.Context,
@@ -190,6 +210,9 @@ func (gui *Gui) allContexts() []Context {
 		gui.Contexts.Staging.Context,
 		gui.Contexts.Merging.Context,
 		gui.Contexts.PatchBuilding.Context,
+		gui.Contexts.Blame.Context,
+		gui.Contexts.MergeEditor.Context,
+		gui.Contexts.MergeResult.Context,
 		gui.Contexts.SubCommits.Context,
 	}
 }
@@ -207,6 +230,9 @@ func (gui *Gui) contextTree() ContextTree {
 		Files: SimpleContextNode{
 			Context: gui.filesListContext(),
 		},
+		Submodules: SimpleContextNode{
+			Context: gui.submodulesListContext(),
+		},
 		Menu: SimpleContextNode{
 			Context: gui.menuListContext(),
 		},
@@ -231,6 +257,9 @@ func (gui *Gui) contextTree() ContextTree {
 		Branches: SimpleContextNode{
 			Context: gui.branchesListContext(),
 		},
+		Worktrees: SimpleContextNode{
+			Context: gui.worktreesListContext(),
+		},
 		Tags: SimpleContextNode{
 			Context: gui.tagsListContext(),
 		},
@@ -282,6 +311,35 @@ func (gui *Gui) contextTree() ContextTree {
 				OnGetOptionsMap: gui.getMergingOptions,
 			},
 		},
+		Blame: SimpleContextNode{
+			Context: BasicContext{
+				OnFocus: func() error {
+					return nil
+				},
+				Kind:     MAIN_CONTEXT,
+				ViewName: "main",
+				Key:      MAIN_BLAME_CONTEXT_KEY,
+			},
+		},
+		MergeEditor: SimpleContextNode{
+			Context: BasicContext{
+				OnFocus: func() error {
+					return gui.refreshMergeEditor()
+				},
+				Kind:            MAIN_CONTEXT,
+				ViewName:        "main",
+				Key:             MAIN_MERGE_EDITOR_CONTEXT_KEY,
+				OnGetOptionsMap: gui.getMergeEditorOptions,
+			},
+		},
+		MergeResult: SimpleContextNode{
+			Context: BasicContext{
+				OnFocus:  gui.handleMergeResultFocused,
+				Kind:     PERSISTENT_POPUP,
+				ViewName: "mergeResult",
+				Key:      MERGE_RESULT_CONTEXT_KEY,
+			},
+		},
 		Credentials: SimpleContextNode{
 			Context: BasicContext{
 				OnFocus:  func() error { return gui.handleCredentialsViewFocused() },
@@ -314,6 +372,14 @@ func (gui *Gui) contextTree() ContextTree {
 				Key:      SEARCH_CONTEXT_KEY,
 			},
 		},
+		FuzzyFilter: SimpleContextNode{
+			Context: BasicContext{
+				OnFocus:  func() error { return nil },
+				Kind:     PERSISTENT_POPUP,
+				ViewName: "filter",
+				Key:      FUZZY_FILTER_CONTEXT_KEY,
+			},
+		},
 	}
 }
 
@@ -331,16 +397,31 @@ func (gui *Gui) initialViewContextMap() map[string]Context {
 		"commitMessage": gui.Contexts.CommitMessage.Context,
 		"main":          gui.Contexts.Normal.Context,
 		"secondary":     gui.Contexts.Normal.Context,
+		"mergeResult":   gui.Contexts.MergeResult.Context,
 	}
 }
 
 func (gui *Gui) viewTabContextMap() map[string][]tabContext {
 	return map[string][]tabContext{
+		"files": {
+			{
+				tab:      "Files",
+				contexts: []Context{gui.Contexts.Files.Context},
+			},
+			{
+				tab:      "Submodules",
+				contexts: []Context{gui.Contexts.Submodules.Context},
+			},
+		},
 		"branches": {
 			{
 				tab:      "Local Branches",
 				contexts: []Context{gui.Contexts.Branches.Context},
 			},
+			{
+				tab:      "Worktrees",
+				contexts: []Context{gui.Contexts.Worktrees.Context},
+			},
 			{
 				tab: "Remotes",
 				contexts: []Context{
@@ -480,6 +561,13 @@ func (gui *Gui) postRefreshUpdate(c Context) error {
 }
 
 func (gui *Gui) activateContext(c Context) error {
+	// we only filter a list while you're in its panel
+	if (c.GetKind() == SIDE_CONTEXT || c.GetKind() == MAIN_CONTEXT) && gui.State.FuzzyFilter.active() && !gui.isFuzzyFiltering(c.GetKey()) {
+		if err := gui.stopFuzzyFiltering(false); err != nil {
+			return err
+		}
+	}
+
 	viewName := c.GetViewName()
 	v, err := gui.g.View(viewName)
 	// if view no longer exists, pop again
@@ -619,7 +707,13 @@ func (gui *Gui) getFocusLayout() func(g *gocui.Gui) error {
 func (gui *Gui) onViewFocusChange() error {
 	currentView := gui.g.CurrentView()
 	for _, view := range gui.g.Views() {
-		view.Highlight = view.Name() != "main" && view == currentView
+		// the main view has no selected line, except when blaming where we
+		// select lines the same way we do in the side panels
+		view.Highlight = view == currentView && (view.Name() != "main" || gui.State.MainContext == MAIN_BLAME_CONTEXT_KEY)
+		// while you're typing a filter, we show which item you'll land on
+		if currentView != nil && currentView.Name() == "filter" && gui.State.FuzzyFilter.active() {
+			view.Highlight = view.Name() == gui.fuzzyFilterListContext().GetViewName()
+		}
 	}
 	return nil
 }
@@ -648,7 +742,7 @@ func (gui *Gui) changeMainViewsContext(contextKey string) {
 	}
 
 	switch contextKey {
-	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY:
+	case MAIN_NORMAL_CONTEXT_KEY, MAIN_PATCH_BUILDING_CONTEXT_KEY, MAIN_STAGING_CONTEXT_KEY, MAIN_MERGING_CONTEXT_KEY, MAIN_BLAME_CONTEXT_KEY, MAIN_MERGE_EDITOR_CONTEXT_KEY:
 		gui.getMainView().Context = contextKey
 		gui.getSecondaryView().Context = contextKey
 	default:
@@ -8,17 +8,26 @@ import (
 
 type credentials chan string
 
-// promptUserForCredential wait for a username or password input from the credentials popup
-func (gui *Gui) promptUserForCredential(passOrUname string) string {
+// promptUserForCredential asks the question that git or ssh asked us in the
+// credentials popup, and waits for the answer. The question is the last line of
+// the prompt, and anything before it, like the fingerprint of an unknown host,
+// goes in the main view
+func (gui *Gui) promptUserForCredential(prompt string) string {
+	lines := strings.Split(strings.TrimSpace(prompt), "\n")
+	question := strings.TrimSpace(lines[len(lines)-1])
+	details := strings.Join(lines[:len(lines)-1], "\n")
+
 	gui.credentials = make(chan string)
 	gui.g.Update(func(g *gocui.Gui) error {
 		credentialsView, _ := g.View("credentials")
-		if passOrUname == "username" {
-			credentialsView.Title = gui.Tr.SLocalize("CredentialsUsername")
+		credentialsView.Title = question
+		credentialsView.Mask = '*'
+		if isUnmaskedCredentialPrompt(question) {
 			credentialsView.Mask = 0
-		} else {
-			credentialsView.Title = gui.Tr.SLocalize("CredentialsPassword")
-			credentialsView.Mask = '*'
+		}
+
+		if details != "" {
+			_ = gui.renderStringSync("main", details)
 		}
 
 		if err := gui.switchContext(gui.Contexts.Credentials.Context); err != nil {
@@ -29,9 +38,16 @@ func (gui *Gui) promptUserForCredential(passOrUname string) string {
 		return nil
 	})
 
-	// wait for username/passwords input
-	userInput := <-gui.credentials
-	return userInput + "\n"
+	// wait for the answer
+	return <-gui.credentials
+}
+
+// isUnmaskedCredentialPrompt tells us whether the answer to the prompt is
+// something we can show as you type it, like a username or a yes/no answer,
+// rather than a password or passphrase. When in doubt, we mask it
+func isUnmaskedCredentialPrompt(question string) bool {
+	lowerQuestion := strings.ToLower(question)
+	return strings.HasPrefix(lowerQuestion, "username") || strings.Contains(lowerQuestion, "(yes/no")
 }
 
 func (gui *Gui) handleSubmitCredential(g *gocui.Gui, v *gocui.View) error {
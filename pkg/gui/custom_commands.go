@@ -24,7 +24,10 @@ type CustomCommandObjects struct {
 	SelectedStashEntry   *models.StashEntry
 	SelectedCommitFile   *models.CommitFile
 	CheckedOutBranch     *models.Branch
-	PromptResponses      []string
+	// SelectedItems holds the items in the selected range of the current side
+	// panel, or just the selected item if we're not selecting a range
+	SelectedItems   []interface{}
+	PromptResponses []string
 }
 
 func (gui *Gui) resolveTemplate(templateStr string, promptResponses []string) (string, error) {
@@ -40,6 +43,7 @@ func (gui *Gui) resolveTemplate(templateStr string, promptResponses []string) (s
 		SelectedCommitFile:   gui.getSelectedCommitFile(),
 		SelectedSubCommit:    gui.getSelectedSubCommit(),
 		CheckedOutBranch:     gui.currentBranch(),
+		SelectedItems:        gui.getSelectedItemsForCustomCommand(),
 		PromptResponses:      promptResponses,
 	}
 
@@ -58,6 +62,56 @@ func (gui *Gui) resolveTemplate(templateStr string, promptResponses []string) (s
 	return cmdStr, nil
 }
 
+func (gui *Gui) getSelectedItemsForCustomCommand() []interface{} {
+	items := []interface{}{}
+
+	context := gui.currentSideContext()
+	if context == nil {
+		return items
+	}
+
+	switch context.GetKey() {
+	case FILES_CONTEXT_KEY:
+		for _, file := range gui.getSelectedFiles() {
+			items = append(items, file)
+		}
+	case COMMIT_FILES_CONTEXT_KEY:
+		for _, node := range gui.getSelectedCommitFileNodes() {
+			for _, commitFile := range node.CommitFiles() {
+				items = append(items, commitFile)
+			}
+		}
+	case LOCAL_BRANCHES_CONTEXT_KEY:
+		for _, branch := range gui.getSelectedBranches() {
+			items = append(items, branch)
+		}
+	case TAGS_CONTEXT_KEY:
+		for _, tag := range gui.getSelectedTags() {
+			items = append(items, tag)
+		}
+	case BRANCH_COMMITS_CONTEXT_KEY:
+		if len(gui.State.Commits) > 0 {
+			start, end := gui.State.Panels.Commits.GetSelectedRange()
+			for _, commit := range gui.State.Commits[start : end+1] {
+				items = append(items, commit)
+			}
+		}
+	case STASH_CONTEXT_KEY:
+		if len(gui.State.StashEntries) > 0 {
+			start, end := gui.State.Panels.Stash.GetSelectedRange()
+			for _, stashEntry := range gui.State.StashEntries[start : end+1] {
+				items = append(items, stashEntry)
+			}
+		}
+	default:
+		if item, ok := context.GetSelectedItem(); ok {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
 func (gui *Gui) handleCustomCommandKeybinding(customCommand CustomCommand) func() error {
 	return func() error {
 		promptResponses := make([]string, len(customCommand.Prompts))
@@ -80,7 +134,7 @@ func (gui *Gui) handleCustomCommandKeybinding(customCommand CustomCommand) func(
 			return gui.WithWaitingStatus(loadingText, func() error {
 				gui.OSCommand.PrepareSubProcess(cmdStr)
 
-				if err := gui.OSCommand.RunCommand(cmdStr); err != nil {
+				if err := gui.OSCommand.WithAction(gui.Tr.SLocalize("executeCustomCommand")).RunCommand(cmdStr); err != nil {
 					return gui.surfaceError(err)
 				}
 				return gui.refreshSidePanels(refreshOptions{})
@@ -1,8 +1,12 @@
 package gui
 
 import (
+	"strconv"
+
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 )
 
 func (gui *Gui) submoduleFromFile(file *models.File) *models.SubmoduleConfig {
@@ -16,11 +20,21 @@ func (gui *Gui) submoduleFromFile(file *models.File) *models.SubmoduleConfig {
 }
 
 func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
-	file := gui.getSelectedFile()
-	if file == nil {
+	if gui.State.Panels.Files.IsSelectingRange() {
+		return gui.createDiscardRangeMenu(gui.getSelectedFiles())
+	}
+
+	node := gui.getSelectedFileNode()
+	if node == nil {
 		return nil
 	}
 
+	if !node.IsLeaf() {
+		return gui.createDiscardDirectoryMenu(node)
+	}
+
+	file := node.File()
+
 	var menuItems []*menuItem
 
 	submoduleConfigs := gui.State.SubmoduleConfigs
@@ -31,13 +45,14 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 			{
 				displayString: gui.Tr.SLocalize("submoduleStashAndReset"),
 				onPress: func() error {
-					if err := gui.GitCommand.UnStageFile(file.Name, file.Tracked); err != nil {
+					gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("submoduleStashAndReset"))
+					if err := gitCommand.UnStageFile(file.Names(), file.Tracked); err != nil {
 						return gui.surfaceError(err)
 					}
-					if err := gui.GitCommand.SubmoduleStash(submoduleConfig); err != nil {
+					if err := gitCommand.SubmoduleStash(submoduleConfig); err != nil {
 						return gui.surfaceError(err)
 					}
-					if err := gui.GitCommand.SubmoduleReset(submoduleConfig); err != nil {
+					if err := gitCommand.SubmoduleReset(submoduleConfig); err != nil {
 						return gui.surfaceError(err)
 					}
 					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
@@ -49,7 +64,7 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 			{
 				displayString: gui.Tr.SLocalize("discardAllChanges"),
 				onPress: func() error {
-					if err := gui.GitCommand.DiscardAllFileChanges(file); err != nil {
+					if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("discardAllChanges")).DiscardAllFileChanges(file); err != nil {
 						return gui.surfaceError(err)
 					}
 					return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
@@ -61,7 +76,7 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 			menuItems = append(menuItems, &menuItem{
 				displayString: gui.Tr.SLocalize("discardUnstagedChanges"),
 				onPress: func() error {
-					if err := gui.GitCommand.DiscardUnstagedFileChanges(file); err != nil {
+					if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("discardUnstagedChanges")).DiscardUnstagedFileChanges(file); err != nil {
 						return gui.surfaceError(err)
 					}
 
@@ -74,3 +89,82 @@ func (gui *Gui) handleCreateDiscardMenu(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.createMenu(file.Name, menuItems, createMenuOptions{showCancel: true})
 }
+
+func (gui *Gui) createDiscardDirectoryMenu(node *filetree.Node) error {
+	files := node.Files()
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("discardAllChanges"),
+			onPress: func() error {
+				if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("discardAllChanges")).DiscardAllDirChanges(node.Path, files); err != nil {
+					return gui.surfaceError(err)
+				}
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+			},
+		},
+	}
+
+	hasStagedChanges := node.AnyFile(func(f *models.File) bool { return f.HasStagedChanges })
+	hasUnstagedChanges := node.AnyFile(func(f *models.File) bool { return f.HasUnstagedChanges })
+	if hasStagedChanges && hasUnstagedChanges {
+		menuItems = append(menuItems, &menuItem{
+			displayString: gui.Tr.SLocalize("discardUnstagedChanges"),
+			onPress: func() error {
+				if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("discardUnstagedChanges")).DiscardUnstagedDirChanges(node.Path, files); err != nil {
+					return gui.surfaceError(err)
+				}
+
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+			},
+		})
+	}
+
+	return gui.createMenu(node.Path, menuItems, createMenuOptions{showCancel: true})
+}
+
+// createDiscardRangeMenu discards the changes of every file in the selected
+// range
+func (gui *Gui) createDiscardRangeMenu(files []*models.File) error {
+	discard := func(action string, discardFile func(*commands.GitCommand, *models.File) error) error {
+		gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize(action))
+		for _, file := range files {
+			if err := discardFile(gitCommand, file); err != nil {
+				return gui.surfaceError(err)
+			}
+		}
+		gui.State.Panels.Files.CancelRangeSelect()
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("discardAllChanges"),
+			onPress: func() error {
+				return discard("discardAllChanges", func(gitCommand *commands.GitCommand, file *models.File) error {
+					return gitCommand.DiscardAllFileChanges(file)
+				})
+			},
+		},
+	}
+
+	for _, file := range files {
+		if file.HasStagedChanges && file.HasUnstagedChanges {
+			menuItems = append(menuItems, &menuItem{
+				displayString: gui.Tr.SLocalize("discardUnstagedChanges"),
+				onPress: func() error {
+					return discard("discardUnstagedChanges", func(gitCommand *commands.GitCommand, file *models.File) error {
+						if !file.HasUnstagedChanges {
+							return nil
+						}
+						return gitCommand.DiscardUnstagedFileChanges(file)
+					})
+				},
+			})
+			break
+		}
+	}
+
+	title := gui.Tr.TemplateLocalize("SelectedFilesTitle", Teml{"count": strconv.Itoa(len(files))})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
@@ -1,145 +1,333 @@
 package gui
 
 import (
+	"errors"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/fsnotify/fsnotify"
-	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/sirupsen/logrus"
 )
 
-// macs for some bizarre reason cap the number of watchable files to 256.
-// there's no obvious platform agonstic way to check the situation of the user's
-// computer so we're just arbitrarily capping at 200. This isn't so bad because
-// file watching is only really an added bonus for faster refreshing.
-const MAX_WATCHED_FILES = 50
+// we watch the worktree and the parts of the .git directory that tell us about
+// the index, refs and rebases, so that changes made outside of lazygit, e.g. by
+// your editor or in another terminal, show up straight away. Ignored
+// directories aren't watched because they're often huge (think node_modules)
+// and there's a limit on how many directories we can watch. If we hit that
+// limit, we stop watching and fall back to refreshing the files on an interval
+
+// FILE_WATCHER_DEBOUNCE is how long we collect changes for after the first one
+// before refreshing, given that editors and formatters tend to write several
+// files in quick succession
+const FILE_WATCHER_DEBOUNCE = 200 * time.Millisecond
+
+// beyond this many changed files we just refresh rather than checking whether
+// they're all ignored
+const MAX_IGNORE_CHECKED_PATHS = 100
+
+// FILE_POLLING_INTERVAL is how often we refresh the files when we can't watch
+// them
+const FILE_POLLING_INTERVAL = 10 * time.Second
+
+var errWatchLimitReached = errors.New("reached the limit on watched directories")
 
 type fileWatcher struct {
-	Watcher          *fsnotify.Watcher
-	WatchedFilenames []string
-	Log              *logrus.Entry
-	Disabled         bool
+	watcher    *fsnotify.Watcher
+	log        *logrus.Entry
+	gitCommand *commands.GitCommand
+	// worktreeDir is empty in a bare repo
+	worktreeDir string
+	dotGitDir   string
+	// commonDir is where the refs live, which is the .git directory unless
+	// we're in a linked worktree
+	commonDir   string
+	ignoredDirs map[string]bool
 }
 
-func NewFileWatcher(log *logrus.Entry) *fileWatcher {
-	// TODO: get this going again, and ensure we don't see any crashes from it
-	return &fileWatcher{
-		Disabled: true,
+func newFileWatcher(log *logrus.Entry, gitCommand *commands.GitCommand) (*fileWatcher, error) {
+	dotGitDir, err := filepath.Abs(gitCommand.DotGitDir)
+	if err != nil {
+		return nil, err
 	}
 
-	watcher, err := fsnotify.NewWatcher()
+	commonDir := dotGitDir
+	if content, err := ioutil.ReadFile(filepath.Join(dotGitDir, "commondir")); err == nil {
+		commonDir = strings.TrimSpace(string(content))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(dotGitDir, commonDir)
+		}
+		commonDir = filepath.Clean(commonDir)
+	}
 
-	if err != nil {
-		log.Error(err)
-		return &fileWatcher{
-			Disabled: true,
+	worktreeDir := ""
+	if !gitCommand.IsBareRepo() {
+		if worktreeDir, err = os.Getwd(); err != nil {
+			return nil, err
 		}
 	}
 
-	return &fileWatcher{
-		Watcher:          watcher,
-		Log:              log,
-		WatchedFilenames: make([]string, 0, MAX_WATCHED_FILES),
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
 	}
+
+	return &fileWatcher{
+		watcher:     watcher,
+		log:         log,
+		gitCommand:  gitCommand,
+		worktreeDir: worktreeDir,
+		dotGitDir:   dotGitDir,
+		commonDir:   commonDir,
+		ignoredDirs: map[string]bool{},
+	}, nil
 }
 
-func (w *fileWatcher) watchingFilename(filename string) bool {
-	for _, watchedFilename := range w.WatchedFilenames {
-		if watchedFilename == filename {
-			return true
+// watchDirs adds the directories we care about to the watcher
+func (w *fileWatcher) watchDirs() error {
+	if w.worktreeDir != "" {
+		ignoredDirs, err := w.gitCommand.GetIgnoredDirectories()
+		if err != nil {
+			return err
+		}
+		for _, dir := range ignoredDirs {
+			w.ignoredDirs[filepath.Join(w.worktreeDir, dir)] = true
+		}
+
+		if err := w.watchDirRecursively(w.worktreeDir); err != nil {
+			return err
 		}
 	}
-	return false
-}
 
-func (w *fileWatcher) popOldestFilename() {
-	// shift the last off the array to make way for this one
-	oldestFilename := w.WatchedFilenames[0]
-	w.WatchedFilenames = w.WatchedFilenames[1:]
-	if err := w.Watcher.Remove(oldestFilename); err != nil {
-		// swallowing errors here because it doesn't really matter if we can't unwatch a file
-		w.Log.Error(err)
+	for _, dir := range []string{w.dotGitDir, w.commonDir} {
+		if err := w.watchDir(dir); err != nil {
+			return err
+		}
 	}
-}
 
-func (w *fileWatcher) watchFilename(filename string) {
-	if err := w.Watcher.Add(filename); err != nil {
-		// swallowing errors here because it doesn't really matter if we can't watch a file
-		w.Log.Error(err)
+	for _, dir := range []string{
+		filepath.Join(w.commonDir, "refs"),
+		filepath.Join(w.dotGitDir, "rebase-merge"),
+		filepath.Join(w.dotGitDir, "rebase-apply"),
+	} {
+		if err := w.watchDirRecursively(dir); err != nil {
+			return err
+		}
 	}
 
-	// assume we're watching it now to be safe
-	w.WatchedFilenames = append(w.WatchedFilenames, filename)
+	return nil
+}
+
+// watchDirRecursively watches the directory and those within it, other than
+// ignored ones and the .git directories of submodules
+func (w *fileWatcher) watchDirRecursively(root string) error {
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if path == root && os.IsNotExist(err) {
+				return nil
+			}
+			// a directory we can't read isn't worth stopping for
+			w.log.Warn(err)
+			return nil
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		if path != root && (info.Name() == ".git" || w.ignoredDirs[path] || path == w.dotGitDir) {
+			return filepath.SkipDir
+		}
+
+		return w.watchDir(path)
+	})
 }
 
-func (w *fileWatcher) addFilesToFileWatcher(files []*models.File) error {
-	if w.Disabled {
+func (w *fileWatcher) watchDir(dir string) error {
+	err := w.watcher.Add(dir)
+	if err == nil {
 		return nil
 	}
+	if errors.Is(err, syscall.ENOSPC) || errors.Is(err, syscall.EMFILE) {
+		return errWatchLimitReached
+	}
+	// the directory may have been removed since we found it, which is fine
+	w.log.Warn(err)
+	return nil
+}
 
-	if len(files) == 0 {
+// gitPathScopes returns what needs refreshing when a path in the .git directory
+// changes, given as a slash-separated path relative to the .git directory
+func gitPathScopes(path string) []int {
+	switch {
+	case strings.HasSuffix(path, ".lock"):
+		// git writes to a lock file and then renames it, and it's the rename
+		// we care about
 		return nil
+	case path == "index":
+		return []int{FILES}
+	case path == "HEAD":
+		return []int{COMMITS, BRANCHES, REFLOG, FILES}
+	case path == "packed-refs":
+		return []int{BRANCHES, TAGS, REMOTES}
+	case path == "refs/stash":
+		return []int{STASH}
+	case strings.HasPrefix(path, "refs/heads/"):
+		return []int{COMMITS, BRANCHES}
+	case strings.HasPrefix(path, "refs/remotes/"):
+		return []int{BRANCHES, REMOTES}
+	case strings.HasPrefix(path, "refs/tags/"):
+		return []int{TAGS}
+	case path == "MERGE_HEAD" || path == "CHERRY_PICK_HEAD" || path == "REVERT_HEAD" || path == "REBASE_HEAD",
+		strings.HasPrefix(path, "rebase-merge"), strings.HasPrefix(path, "rebase-apply"):
+		return []int{COMMITS, FILES}
+	case strings.HasPrefix(path, "BISECT_"):
+		return []int{COMMITS}
 	}
 
-	// watch the files for changes
-	dirName, err := os.Getwd()
-	if err != nil {
-		return err
+	return nil
+}
+
+// relativePath returns the slash-separated path relative to the directory, if
+// it's within it
+func relativePath(dir string, path string) (string, bool) {
+	relPath, err := filepath.Rel(dir, path)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
 	}
+	return filepath.ToSlash(relPath), true
+}
 
-	for _, file := range files[0:min(MAX_WATCHED_FILES, len(files))] {
-		if file.Deleted {
-			continue
-		}
-		filename := filepath.Join(dirName, file.Name)
-		if w.watchingFilename(filename) {
-			continue
-		}
-		if len(w.WatchedFilenames) > MAX_WATCHED_FILES {
-			w.popOldestFilename()
+// handleEvent returns what needs refreshing given the event, and the changed
+// path relative to the worktree if the event was in the worktree
+func (w *fileWatcher) handleEvent(event fsnotify.Event) ([]int, string) {
+	createdDir := false
+	if event.Op&fsnotify.Create != 0 {
+		info, err := os.Stat(event.Name)
+		createdDir = err == nil && info.IsDir()
+	}
+
+	for _, dir := range []string{w.dotGitDir, w.commonDir} {
+		if path, ok := relativePath(dir, event.Name); ok {
+			if createdDir {
+				w.watchNewDir(event.Name)
+			}
+			return gitPathScopes(path), ""
 		}
+	}
 
-		w.watchFilename(filename)
+	if w.worktreeDir != "" {
+		if path, ok := relativePath(w.worktreeDir, event.Name); ok && path != "." {
+			if createdDir && len(w.gitCommand.FilterIgnoredPaths([]string{path + "/"})) == 0 {
+				w.watchNewDir(event.Name)
+			}
+			return []int{FILES}, path
+		}
 	}
 
-	return nil
+	return nil, ""
 }
 
-func min(a int, b int) int {
-	if a < b {
-		return a
+// watchNewDir starts watching a directory that's been created, along with any
+// directories that were created within it before we got to it
+func (w *fileWatcher) watchNewDir(dir string) {
+	if err := w.watchDirRecursively(dir); err != nil {
+		w.log.Warn(err)
 	}
-	return b
 }
 
-// NOTE: given that we often edit files ourselves, this may make us end up refreshing files too often
-// TODO: consider watching the whole directory recursively (could be more expensive)
-func (gui *Gui) watchFilesForChanges() {
-	gui.fileWatcher = NewFileWatcher(gui.Log)
-	if gui.fileWatcher.Disabled {
-		return
+// anyNotIgnored tells us whether any of the changed worktree paths are ones
+// that git status would show
+func (w *fileWatcher) anyNotIgnored(paths map[string]bool) bool {
+	if len(paths) > MAX_IGNORE_CHECKED_PATHS {
+		return true
 	}
-	go func() {
-		for {
-			select {
-			// watch for events
-			case event := <-gui.fileWatcher.Watcher.Events:
-				if event.Op == fsnotify.Chmod {
-					// for some reason we pick up chmod events when they don't actually happen
-					continue
-				}
-				// only refresh if we're not already
-				if !gui.State.IsRefreshingFiles {
-					gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
-				}
 
-			// watch for errors
-			case err := <-gui.fileWatcher.Watcher.Errors:
-				if err != nil {
-					gui.Log.Error(err)
+	pathList := make([]string, 0, len(paths))
+	for path := range paths {
+		pathList = append(pathList, path)
+	}
+	return len(w.gitCommand.FilterIgnoredPaths(pathList)) < len(pathList)
+}
+
+// run passes on the changes it sees in batches until the stop channel is closed
+func (w *fileWatcher) run(stop chan struct{}, onChange func(scopes []int)) {
+	defer w.watcher.Close()
+
+	pendingScopes := map[int]bool{}
+	pendingPaths := map[string]bool{}
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-stop:
+			return
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op == fsnotify.Chmod {
+				// for some reason we pick up chmod events when they don't actually happen
+				continue
+			}
+			scopes, path := w.handleEvent(event)
+			if path != "" {
+				pendingPaths[path] = true
+			} else {
+				for _, scope := range scopes {
+					pendingScopes[scope] = true
 				}
 			}
+			if debounce == nil && (len(pendingScopes) > 0 || len(pendingPaths) > 0) {
+				debounce = time.After(FILE_WATCHER_DEBOUNCE)
+			}
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+			w.log.Error(err)
+		case <-debounce:
+			debounce = nil
+			if len(pendingPaths) > 0 && !pendingScopes[FILES] && w.anyNotIgnored(pendingPaths) {
+				pendingScopes[FILES] = true
+			}
+
+			scopes := []int{}
+			for scope := range pendingScopes {
+				scopes = append(scopes, scope)
+			}
+			pendingScopes = map[int]bool{}
+			pendingPaths = map[string]bool{}
+
+			if len(scopes) > 0 {
+				onChange(scopes)
+			}
 		}
+	}
+}
+
+// watchFilesForChanges refreshes whatever's changed on disk until the gui
+// stops, or if we can't watch the repo, refreshes the files on an interval
+func (gui *Gui) watchFilesForChanges() {
+	stop := gui.stopChan
+	go func() {
+		watcher, err := newFileWatcher(gui.Log, gui.GitCommand)
+		if err == nil {
+			err = watcher.watchDirs()
+			if err != nil {
+				watcher.watcher.Close()
+			}
+		}
+		if err != nil {
+			gui.Log.Warnf("not watching files for changes, falling back to polling: %s", err)
+			gui.goEvery(FILE_POLLING_INTERVAL, stop, gui.refreshFiles)
+			return
+		}
+
+		watcher.run(stop, func(scopes []int) {
+			_ = gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: scopes})
+		})
 	}()
 }
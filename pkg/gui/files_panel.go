@@ -8,33 +8,68 @@ import (
 	// "strings"
 
 	"fmt"
-	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/mgutz/str"
 )
 
 // list panel functions
 
+func (gui *Gui) getSelectedFileNode() *filetree.Node {
+	return gui.State.FileManager.GetNodeAtIndex(gui.State.Panels.Files.SelectedLineIdx)
+}
+
+// getSelectedFileNodes returns the nodes in the selected range, or just the
+// selected node if we're not selecting a range
+func (gui *Gui) getSelectedFileNodes() []*filetree.Node {
+	start, end := gui.State.Panels.Files.GetSelectedRange()
+	nodes := []*filetree.Node{}
+	for i := start; i <= end; i++ {
+		if node := gui.State.FileManager.GetNodeAtIndex(i); node != nil {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
+
+// getSelectedFiles returns the files in the selected range, including the files
+// within any directories in it
+func (gui *Gui) getSelectedFiles() []*models.File {
+	files := []*models.File{}
+	seen := map[string]bool{}
+	for _, node := range gui.getSelectedFileNodes() {
+		for _, file := range node.Files() {
+			if !seen[file.Name] {
+				seen[file.Name] = true
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// getSelectedFile returns nil if a directory is selected
 func (gui *Gui) getSelectedFile() *models.File {
-	selectedLine := gui.State.Panels.Files.SelectedLineIdx
-	if selectedLine == -1 {
+	node := gui.getSelectedFileNode()
+	if node == nil {
 		return nil
 	}
 
-	return gui.State.Files[selectedLine]
+	return node.File()
 }
 
 func (gui *Gui) selectFile(alreadySelected bool) error {
 	gui.getFilesView().FocusPoint(0, gui.State.Panels.Files.SelectedLineIdx)
 
-	file := gui.getSelectedFile()
-	if file == nil {
+	node := gui.getSelectedFileNode()
+	if node == nil {
 		return gui.refreshMainViews(refreshMainOpts{
 			main: &viewUpdateOpts{
 				title: "",
@@ -53,10 +88,19 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 		}
 	}
 
+	if !node.IsLeaf() {
+		return gui.selectDirectory(node)
+	}
+
+	file := node.File()
 	if file.HasInlineMergeConflicts {
 		return gui.refreshMergePanel()
 	}
 
+	if gui.fileIsBinary(file) {
+		return gui.selectBinaryFile(file)
+	}
+
 	cmdStr := gui.GitCommand.WorktreeFileDiffCmdStr(file, false, !file.HasUnstagedChanges && file.HasStagedChanges)
 	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
 
@@ -80,6 +124,35 @@ func (gui *Gui) selectFile(alreadySelected bool) error {
 	return gui.refreshMainViews(refreshOpts)
 }
 
+// selectDirectory shows the combined diff of a directory's files, with its
+// staged changes below its unstaged ones like we do for a single file
+func (gui *Gui) selectDirectory(node *filetree.Node) error {
+	hasUnstagedChanges := node.AnyFile(func(f *models.File) bool { return f.HasUnstagedChanges })
+	hasStagedChanges := node.AnyFile(func(f *models.File) bool { return f.HasStagedChanges })
+
+	cmdStr := gui.GitCommand.WorktreeDirDiffCmdStr(node.Path, false, !hasUnstagedChanges && hasStagedChanges)
+	cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+
+	refreshOpts := refreshMainOpts{main: &viewUpdateOpts{
+		title: gui.Tr.SLocalize("UnstagedChanges"),
+		task:  gui.createRunPtyTask(cmd),
+	}}
+
+	if hasStagedChanges && hasUnstagedChanges {
+		cmdStr := gui.GitCommand.WorktreeDirDiffCmdStr(node.Path, false, true)
+		cmd := gui.OSCommand.ExecutableFromString(cmdStr)
+
+		refreshOpts.secondary = &viewUpdateOpts{
+			title: gui.Tr.SLocalize("StagedChanges"),
+			task:  gui.createRunPtyTask(cmd),
+		}
+	} else if !hasUnstagedChanges {
+		refreshOpts.main.title = gui.Tr.SLocalize("StagedChanges")
+	}
+
+	return gui.refreshMainViews(refreshOpts)
+}
+
 func (gui *Gui) refreshFiles() error {
 	gui.State.RefreshingFilesMutex.Lock()
 	gui.State.IsRefreshingFiles = true
@@ -88,7 +161,7 @@ func (gui *Gui) refreshFiles() error {
 		gui.State.RefreshingFilesMutex.Unlock()
 	}()
 
-	selectedFile := gui.getSelectedFile()
+	selectedNode := gui.getSelectedFileNode()
 
 	filesView := gui.getFilesView()
 	if filesView == nil {
@@ -108,8 +181,8 @@ func (gui *Gui) refreshFiles() error {
 		}
 
 		if g.CurrentView() == filesView || (g.CurrentView() == gui.getMainView() && g.CurrentView().Context == MAIN_MERGING_CONTEXT_KEY) {
-			newSelectedFile := gui.getSelectedFile()
-			alreadySelected := selectedFile != nil && newSelectedFile != nil && newSelectedFile.Name == selectedFile.Name
+			newSelectedNode := gui.getSelectedFileNode()
+			alreadySelected := selectedNode != nil && newSelectedNode != nil && newSelectedNode.ID() == selectedNode.ID()
 			return gui.selectFile(alreadySelected)
 		}
 		return nil
@@ -121,7 +194,7 @@ func (gui *Gui) refreshFiles() error {
 // specific functions
 
 func (gui *Gui) stagedFiles() []*models.File {
-	files := gui.State.Files
+	files := gui.State.FileManager.GetAllFiles()
 	result := make([]*models.File, 0)
 	for _, file := range files {
 		if file.HasStagedChanges {
@@ -132,7 +205,7 @@ func (gui *Gui) stagedFiles() []*models.File {
 }
 
 func (gui *Gui) trackedFiles() []*models.File {
-	files := gui.State.Files
+	files := gui.State.FileManager.GetAllFiles()
 	result := make([]*models.File, 0, len(files))
 	for _, file := range files {
 		if file.Tracked {
@@ -148,7 +221,7 @@ func (gui *Gui) stageSelectedFile(g *gocui.Gui) error {
 		return nil
 	}
 
-	return gui.GitCommand.StageFile(file.Name)
+	return gui.GitCommand.WithAction(gui.Tr.SLocalize("resolveMergeConflicts")).StageFile(file.Name)
 }
 
 func (gui *Gui) handleEnterFile(g *gocui.Gui, v *gocui.View) error {
@@ -156,20 +229,21 @@ func (gui *Gui) handleEnterFile(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) enterFile(forceSecondaryFocused bool, selectedLineIdx int) error {
-	file := gui.getSelectedFile()
-	if file == nil {
+	node := gui.getSelectedFileNode()
+	if node == nil {
 		return nil
 	}
 
+	if !node.IsLeaf() {
+		gui.State.FileManager.ToggleCollapsed(node.Path)
+		return gui.postRefreshUpdate(gui.Contexts.Files.Context)
+	}
+
+	file := node.File()
+
 	submoduleConfigs := gui.State.SubmoduleConfigs
 	if file.IsSubmodule(submoduleConfigs) {
-		wd, err := os.Getwd()
-		if err != nil {
-			return err
-		}
-		gui.State.RepoPathStack = append(gui.State.RepoPathStack, wd)
-		submoduleConfig := file.SubmoduleConfig(submoduleConfigs)
-		return gui.dispatchSwitchToRepo(submoduleConfig.Path)
+		return gui.enterSubmodule(file.SubmoduleConfig(submoduleConfigs))
 	}
 
 	if file.HasInlineMergeConflicts {
@@ -184,25 +258,99 @@ func (gui *Gui) enterFile(forceSecondaryFocused bool, selectedLineIdx int) error
 }
 
 func (gui *Gui) handleFilePress() error {
-	file := gui.getSelectedFile()
-	if file == nil {
+	if gui.State.Panels.Files.IsSelectingRange() {
+		return gui.handleRangeFilePress()
+	}
+
+	node := gui.getSelectedFileNode()
+	if node == nil {
 		return nil
 	}
 
+	if !node.IsLeaf() {
+		return gui.handleDirectoryPress(node)
+	}
+
+	file := node.File()
 	if file.HasInlineMergeConflicts {
 		return gui.handleSwitchToMerge()
 	}
 
 	if file.HasUnstagedChanges {
-		if err := gui.GitCommand.StageFile(file.Name); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("toggleStaged")).StageFile(file.Name); err != nil {
 			return gui.surfaceError(err)
 		}
 	} else {
-		if err := gui.GitCommand.UnStageFile(file.Name, file.Tracked); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("toggleStaged")).UnStageFile(file.Names(), file.Tracked); err != nil {
+			return gui.surfaceError(err)
+		}
+	}
+
+	if err := gui.refreshSidePanels(refreshOptions{scope: []int{FILES}}); err != nil {
+		return err
+	}
+
+	return gui.selectFile(true)
+}
+
+// handleDirectoryPress stages everything in the directory unless it's all
+// staged already, in which case it unstages it all
+func (gui *Gui) handleDirectoryPress(node *filetree.Node) error {
+	if node.AnyFile(func(f *models.File) bool { return f.HasInlineMergeConflicts }) {
+		return gui.createErrorPanel(gui.Tr.SLocalize("DirectoryHasMergeConflicts"))
+	}
+
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("toggleStaged"))
+	if node.AnyFile(func(f *models.File) bool { return f.HasUnstagedChanges }) {
+		if err := gitCommand.StageFile(node.Path); err != nil {
+			return gui.surfaceError(err)
+		}
+	} else {
+		if err := gitCommand.UnstageDirectory(node.Path); err != nil {
+			return gui.surfaceError(err)
+		}
+	}
+
+	if err := gui.refreshSidePanels(refreshOptions{scope: []int{FILES}}); err != nil {
+		return err
+	}
+
+	return gui.selectFile(true)
+}
+
+// handleRangeFilePress stages the files in the selected range unless they're
+// all staged already, in which case it unstages them
+func (gui *Gui) handleRangeFilePress() error {
+	files := gui.getSelectedFiles()
+
+	for _, file := range files {
+		if file.HasInlineMergeConflicts {
+			return gui.createErrorPanel(gui.Tr.SLocalize("RangeHasMergeConflicts"))
+		}
+	}
+
+	anyUnstaged := false
+	for _, file := range files {
+		if file.HasUnstagedChanges {
+			anyUnstaged = true
+			break
+		}
+	}
+
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("toggleStaged"))
+	for _, file := range files {
+		var err error
+		if anyUnstaged {
+			err = gitCommand.StageFile(file.Name)
+		} else {
+			err = gitCommand.UnStageFile(file.Names(), file.Tracked)
+		}
+		if err != nil {
 			return gui.surfaceError(err)
 		}
 	}
 
+	gui.State.Panels.Files.CancelRangeSelect()
 	if err := gui.refreshSidePanels(refreshOptions{scope: []int{FILES}}); err != nil {
 		return err
 	}
@@ -210,8 +358,8 @@ func (gui *Gui) handleFilePress() error {
 	return gui.selectFile(true)
 }
 
-func (gui *Gui) allFilesStaged() bool {
-	for _, file := range gui.State.Files {
+func (gui *Gui) allFilesStaged(files []*models.File) bool {
+	for _, file := range files {
 		if file.HasUnstagedChanges {
 			return false
 		}
@@ -219,16 +367,39 @@ func (gui *Gui) allFilesStaged() bool {
 	return true
 }
 
+// toggleStagedFiles stages the given files unless they're all staged already,
+// in which case it unstages them. We use it when you've filtered the files, so
+// that the files you can't see are left alone
+func (gui *Gui) toggleStagedFiles(gitCommand *commands.GitCommand, files []*models.File) error {
+	allStaged := gui.allFilesStaged(files)
+	for _, file := range files {
+		var err error
+		if !allStaged && file.HasUnstagedChanges {
+			err = gitCommand.StageFile(file.Name)
+		} else if allStaged && file.HasStagedChanges {
+			err = gitCommand.UnStageFile(file.Names(), file.Tracked)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (gui *Gui) focusAndSelectFile() error {
 	return gui.selectFile(false)
 }
 
 func (gui *Gui) handleStageAll(g *gocui.Gui, v *gocui.View) error {
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("toggleStagedAll"))
+
 	var err error
-	if gui.allFilesStaged() {
-		err = gui.GitCommand.UnstageAll()
+	if gui.isFuzzyFiltering(FILES_CONTEXT_KEY) {
+		err = gui.toggleStagedFiles(gitCommand, gui.State.FileManager.GetFilteredFiles())
+	} else if gui.allFilesStaged(gui.State.FileManager.GetAllFiles()) {
+		err = gitCommand.UnstageAll()
 	} else {
-		err = gui.GitCommand.StageAll()
+		err = gitCommand.StageAll()
 	}
 	if err != nil {
 		_ = gui.surfaceError(err)
@@ -242,6 +413,10 @@ func (gui *Gui) handleStageAll(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleIgnoreFile(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.Panels.Files.IsSelectingRange() {
+		return gui.ignoreFiles(gui.getSelectedFiles())
+	}
+
 	file := gui.getSelectedFile()
 	if file == nil {
 		return nil
@@ -252,10 +427,11 @@ func (gui *Gui) handleIgnoreFile(g *gocui.Gui, v *gocui.View) error {
 			title:  gui.Tr.SLocalize("IgnoreTracked"),
 			prompt: gui.Tr.SLocalize("IgnoreTrackedPrompt"),
 			handleConfirm: func() error {
-				if err := gui.GitCommand.Ignore(file.Name); err != nil {
+				gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("ignoreFile"))
+				if err := gitCommand.Ignore(file.Name); err != nil {
 					return err
 				}
-				if err := gui.GitCommand.RemoveTrackedFiles(file.Name); err != nil {
+				if err := gitCommand.RemoveTrackedFiles(file.Name); err != nil {
 					return err
 				}
 				return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
@@ -263,13 +439,45 @@ func (gui *Gui) handleIgnoreFile(g *gocui.Gui, v *gocui.View) error {
 		})
 	}
 
-	if err := gui.GitCommand.Ignore(file.Name); err != nil {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("ignoreFile")).Ignore(file.Name); err != nil {
 		return gui.surfaceError(err)
 	}
 
 	return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
 }
 
+// ignoreFiles ignores the files in the selected range, asking first if that
+// means we'll stop tracking some of them
+func (gui *Gui) ignoreFiles(files []*models.File) error {
+	ignore := func() error {
+		gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("ignoreFile"))
+		for _, file := range files {
+			if err := gitCommand.Ignore(file.Name); err != nil {
+				return gui.surfaceError(err)
+			}
+			if file.Tracked {
+				if err := gitCommand.RemoveTrackedFiles(file.Name); err != nil {
+					return gui.surfaceError(err)
+				}
+			}
+		}
+		gui.State.Panels.Files.CancelRangeSelect()
+		return gui.refreshSidePanels(refreshOptions{scope: []int{FILES}})
+	}
+
+	for _, file := range files {
+		if file.Tracked {
+			return gui.ask(askOpts{
+				title:         gui.Tr.SLocalize("IgnoreTracked"),
+				prompt:        gui.Tr.SLocalize("IgnoreTrackedPrompt"),
+				handleConfirm: ignore,
+			})
+		}
+	}
+
+	return ignore()
+}
+
 func (gui *Gui) handleWIPCommitPress(g *gocui.Gui, filesView *gocui.View) error {
 	skipHookPreifx := gui.Config.GetUserConfig().GetString("git.skipHookPrefix")
 	if skipHookPreifx == "" {
@@ -322,7 +530,7 @@ func (gui *Gui) promptToStageAllAndRetry(retry func() error) error {
 		title:  gui.Tr.SLocalize("NoFilesStagedTitle"),
 		prompt: gui.Tr.SLocalize("NoFilesStagedPrompt"),
 		handleConfirm: func() error {
-			if err := gui.GitCommand.StageAll(); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("CommitChanges")).StageAll(); err != nil {
 				return gui.surfaceError(err)
 			}
 			if err := gui.refreshFiles(); err != nil {
@@ -350,7 +558,7 @@ func (gui *Gui) handleAmendCommitPress() error {
 		prompt: gui.Tr.SLocalize("SureToAmend"),
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("AmendingStatus"), func() error {
-				ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.AmendHead())
+				ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.WithAction(gui.Tr.SLocalize("AmendLastCommit")).AmendHead())
 				if err != nil {
 					return err
 				}
@@ -416,32 +624,66 @@ func (gui *Gui) refreshStateFiles() error {
 	// keep track of where the cursor is currently and the current file names
 	// when we refresh, go looking for a matching name
 	// move the cursor to there.
+	selectedNode := gui.getSelectedFileNode()
 	selectedFile := gui.getSelectedFile()
 	prevSelectedLineIdx := gui.State.Panels.Files.SelectedLineIdx
 
 	// get files to stage
-	files := gui.GitCommand.GetStatusFiles(commands.GetStatusFileOptions{})
-	gui.State.Files = gui.GitCommand.MergeStatusFiles(gui.State.Files, files, selectedFile)
-
-	if err := gui.fileWatcher.addFilesToFileWatcher(files); err != nil {
-		return err
-	}
-
-	// let's try to find our file again and move the cursor to that
-	if selectedFile != nil {
-		for idx, f := range gui.State.Files {
-			selectedFileHasMoved := f.Matches(selectedFile) && idx != prevSelectedLineIdx
-			if selectedFileHasMoved {
+	status := gui.GitCommand.GetStatus(commands.GetStatusFileOptions{})
+	files := status.Files
+	gui.updateCheckedOutBranchStatus(status.Branch)
+	gui.State.FileManager.SetFiles(gui.GitCommand.MergeStatusFiles(gui.State.FileManager.GetAllFiles(), files, selectedFile))
+	gui.State.BinaryFiles = map[string]bool{}
+	gui.reapplyFuzzyFilter(FILES_CONTEXT_KEY)
+
+	// let's try to find our file (or directory) again and move the cursor to that
+	if selectedNode != nil {
+		for idx, node := range gui.State.FileManager.GetVisibleNodes() {
+			matches := node.Path == selectedNode.Path
+			if selectedFile != nil && node.IsLeaf() {
+				matches = node.File().Matches(selectedFile)
+			}
+			if matches && idx != prevSelectedLineIdx {
 				gui.State.Panels.Files.SelectedLineIdx = idx
 				break
 			}
 		}
 	}
 
-	gui.refreshSelectedLine(gui.State.Panels.Files, len(gui.State.Files))
+	gui.refreshSelectedLine(gui.State.Panels.Files, gui.State.FileManager.GetItemsLength())
 	return nil
 }
 
+// updateCheckedOutBranchStatus keeps the checked out branch's ahead/behind
+// counts up to date, given that git status tells us them for free, so that
+// the status panel is right without reloading the branches
+func (gui *Gui) updateCheckedOutBranchStatus(branchStatus commands.BranchStatus) {
+	currentBranch := gui.getCheckedOutBranch()
+	if currentBranch == nil || currentBranch.Name != branchStatus.Head || !branchStatus.HasAheadBehind {
+		return
+	}
+
+	currentBranch.Pushables = strconv.Itoa(branchStatus.Ahead)
+	currentBranch.Pullables = strconv.Itoa(branchStatus.Behind)
+}
+
+func (gui *Gui) handleToggleFileTreeView(g *gocui.Gui, v *gocui.View) error {
+	selectedNode := gui.getSelectedFileNode()
+
+	gui.State.FileManager.ToggleShowTree()
+
+	// keep the same file selected, and if we had a directory selected, select
+	// its first file
+	if selectedNode != nil {
+		path := selectedNode.Leaves()[0].Path
+		if index, ok := gui.State.FileManager.GetIndexForPath(path); ok {
+			gui.State.Panels.Files.SelectedLineIdx = index
+		}
+	}
+
+	return gui.postRefreshUpdate(gui.Contexts.Files.Context)
+}
+
 func (gui *Gui) refreshStateSubmoduleConfigs() error {
 	configs, err := gui.GitCommand.GetSubmoduleConfigs()
 	if err != nil {
@@ -478,7 +720,7 @@ func (gui *Gui) handlePullFiles(g *gocui.Gui, v *gocui.View) error {
 		}
 
 		return gui.prompt(gui.Tr.SLocalize("EnterUpstream"), "origin/"+currentBranch.Name, func(upstream string) error {
-			if err := gui.GitCommand.SetUpstreamBranch(upstream); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("pull")).SetUpstreamBranch(upstream); err != nil {
 				errorMessage := err.Error()
 				if strings.Contains(errorMessage, "does not exist") {
 					errorMessage = fmt.Sprintf("upstream branch %s not found.\nIf you expect it to exist, you should fetch (with 'f').\nOtherwise, you should push (with 'shift+P')", upstream)
@@ -513,7 +755,10 @@ func (gui *Gui) pullWithMode(mode string, opts PullFilesOptions) error {
 	gui.State.FetchMutex.Lock()
 	defer gui.State.FetchMutex.Unlock()
 
-	err := gui.GitCommand.Fetch(
+	// the fetch and the rebase or merge make up one pull in the command log
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("pull"))
+
+	err := gitCommand.Fetch(
 		commands.FetchOptions{
 			PromptUserForCredential: gui.promptUserForCredential,
 			RemoteName:              opts.RemoteName,
@@ -527,13 +772,13 @@ func (gui *Gui) pullWithMode(mode string, opts PullFilesOptions) error {
 
 	switch mode {
 	case "rebase":
-		err := gui.GitCommand.RebaseBranch("FETCH_HEAD")
+		err := gitCommand.RebaseBranch("FETCH_HEAD")
 		return gui.handleGenericMergeCommandResult(err)
 	case "merge":
-		err := gui.GitCommand.Merge("FETCH_HEAD", commands.MergeOpts{})
+		err := gitCommand.Merge("FETCH_HEAD", commands.MergeOpts{})
 		return gui.handleGenericMergeCommandResult(err)
 	case "ff-only":
-		err := gui.GitCommand.Merge("FETCH_HEAD", commands.MergeOpts{FastForwardOnly: true})
+		err := gitCommand.Merge("FETCH_HEAD", commands.MergeOpts{FastForwardOnly: true})
 		return gui.handleGenericMergeCommandResult(err)
 	default:
 		return gui.createErrorPanel(fmt.Sprintf("git pull mode '%s' unrecognised", mode))
@@ -546,7 +791,7 @@ func (gui *Gui) pushWithForceFlag(v *gocui.View, force bool, upstream string, ar
 	}
 	go func() {
 		branchName := gui.getCheckedOutBranch().Name
-		err := gui.GitCommand.Push(branchName, force, upstream, args, gui.promptUserForCredential)
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("push")).Push(branchName, force, upstream, args, gui.promptUserForCredential)
 		if err != nil && !force && strings.Contains(err.Error(), "Updates were rejected") {
 			forcePushDisabled := gui.Config.GetUserConfig().GetBool("git.disableForcePushing")
 			if forcePushDisabled {
@@ -634,7 +879,7 @@ func (gui *Gui) openFile(filename string) error {
 }
 
 func (gui *Gui) anyFilesWithMergeConflicts() bool {
-	for _, file := range gui.State.Files {
+	for _, file := range gui.State.FileManager.GetAllFiles() {
 		if file.HasMergeConflicts {
 			return true
 		}
@@ -654,13 +899,13 @@ func (gui *Gui) handleCreateStashMenu(g *gocui.Gui, v *gocui.View) error {
 		{
 			displayString: gui.Tr.SLocalize("stashAllChanges"),
 			onPress: func() error {
-				return gui.handleStashSave(gui.GitCommand.StashSave)
+				return gui.handleStashSave(gui.GitCommand.WithAction(gui.Tr.SLocalize("stashAllChanges")).StashSave)
 			},
 		},
 		{
 			displayString: gui.Tr.SLocalize("stashStagedChanges"),
 			onPress: func() error {
-				return gui.handleStashSave(gui.GitCommand.StashSaveStagedChanges)
+				return gui.handleStashSave(gui.GitCommand.WithAction(gui.Tr.SLocalize("stashStagedChanges")).StashSaveStagedChanges)
 			},
 		},
 	}
@@ -669,7 +914,7 @@ func (gui *Gui) handleCreateStashMenu(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleStashChanges(g *gocui.Gui, v *gocui.View) error {
-	return gui.handleStashSave(gui.GitCommand.StashSave)
+	return gui.handleStashSave(gui.GitCommand.WithAction(gui.Tr.SLocalize("stashAllChanges")).StashSave)
 }
 
 func (gui *Gui) handleCreateResetToUpstreamMenu(g *gocui.Gui, v *gocui.View) error {
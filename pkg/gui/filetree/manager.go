@@ -0,0 +1,181 @@
+package filetree
+
+import "github.com/jesseduffield/lazygit/pkg/commands/models"
+
+// tree holds the nodes we display for a list of items, either as a flat list
+// or as a tree of directories, some of which may be collapsed. Collapsed
+// directories are remembered by path so that they stay collapsed when the
+// items are refreshed
+type tree struct {
+	items          []Item
+	showTree       bool
+	collapsedPaths map[string]bool
+	// filteredPaths are the only items we show when we're filtering, or nil
+	// if we're not
+	filteredPaths []string
+	visibleNodes  []*Node
+}
+
+func newTree(showTree bool) *tree {
+	return &tree{showTree: showTree, collapsedPaths: map[string]bool{}}
+}
+
+func (t *tree) setItems(items []Item) {
+	t.items = items
+	t.refresh()
+}
+
+func (t *tree) refresh() {
+	items := t.items
+	if t.filteredPaths != nil {
+		items = t.filteredItems()
+	}
+
+	var root *Node
+	if t.showTree {
+		root = buildTree(items)
+	} else {
+		root = buildFlatTree(items)
+	}
+	t.visibleNodes = root.flatten(t.collapsedPaths)
+}
+
+// SetFilter only shows the items with the given paths, in the given order
+// unless we're showing a tree. A nil slice shows every item again
+func (t *tree) SetFilter(paths []string) {
+	t.filteredPaths = paths
+	t.refresh()
+}
+
+func (t *tree) filteredItems() []Item {
+	itemsByPath := make(map[string]Item, len(t.items))
+	for _, item := range t.items {
+		itemsByPath[item.GetPath()] = item
+	}
+
+	items := make([]Item, 0, len(t.filteredPaths))
+	for _, path := range t.filteredPaths {
+		if item, ok := itemsByPath[path]; ok {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (t *tree) ShowingTree() bool {
+	return t.showTree
+}
+
+func (t *tree) ToggleShowTree() {
+	t.showTree = !t.showTree
+	t.refresh()
+}
+
+func (t *tree) IsCollapsed(path string) bool {
+	return t.collapsedPaths[path]
+}
+
+func (t *tree) ToggleCollapsed(path string) {
+	if t.collapsedPaths[path] {
+		delete(t.collapsedPaths, path)
+	} else {
+		t.collapsedPaths[path] = true
+	}
+	t.refresh()
+}
+
+// GetItemsLength returns the number of nodes we're displaying
+func (t *tree) GetItemsLength() int {
+	return len(t.visibleNodes)
+}
+
+func (t *tree) GetVisibleNodes() []*Node {
+	return t.visibleNodes
+}
+
+// GetNodeAtIndex returns the displayed node at the given index, or nil if
+// there isn't one
+func (t *tree) GetNodeAtIndex(index int) *Node {
+	if index < 0 || index >= len(t.visibleNodes) {
+		return nil
+	}
+	return t.visibleNodes[index]
+}
+
+// GetIndexForPath returns the index of the displayed node with the given path
+func (t *tree) GetIndexForPath(path string) (int, bool) {
+	for i, node := range t.visibleNodes {
+		if node.Path == path {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// FileManager is the tree for the files panel
+type FileManager struct {
+	*tree
+	files []*models.File
+}
+
+func NewFileManager(files []*models.File, showTree bool) *FileManager {
+	m := &FileManager{tree: newTree(showTree)}
+	m.SetFiles(files)
+	return m
+}
+
+func (m *FileManager) SetFiles(files []*models.File) {
+	m.files = files
+	items := make([]Item, len(files))
+	for i, file := range files {
+		items[i] = file
+	}
+	m.setItems(items)
+}
+
+// GetAllFiles returns every file, whether or not it's in a collapsed directory
+func (m *FileManager) GetAllFiles() []*models.File {
+	return m.files
+}
+
+// GetFilteredFiles returns the files we're showing when we're filtering,
+// whether or not they're in a collapsed directory, or every file if we're not
+func (m *FileManager) GetFilteredFiles() []*models.File {
+	if m.filteredPaths == nil {
+		return m.files
+	}
+
+	items := m.filteredItems()
+	files := make([]*models.File, len(items))
+	for i, item := range items {
+		files[i] = item.(*models.File)
+	}
+	return files
+}
+
+// CommitFileManager is the tree for the commit files panel
+type CommitFileManager struct {
+	*tree
+	commitFiles []*models.CommitFile
+}
+
+func NewCommitFileManager(commitFiles []*models.CommitFile, showTree bool) *CommitFileManager {
+	m := &CommitFileManager{tree: newTree(showTree)}
+	m.SetCommitFiles(commitFiles)
+	return m
+}
+
+func (m *CommitFileManager) SetCommitFiles(commitFiles []*models.CommitFile) {
+	m.commitFiles = commitFiles
+	items := make([]Item, len(commitFiles))
+	for i, commitFile := range commitFiles {
+		items[i] = commitFile
+	}
+	m.setItems(items)
+}
+
+// GetAllCommitFiles returns every commit file, whether or not it's in a
+// collapsed directory
+func (m *CommitFileManager) GetAllCommitFiles() []*models.CommitFile {
+	return m.commitFiles
+}
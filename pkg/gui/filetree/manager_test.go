@@ -0,0 +1,151 @@
+package filetree
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func files(names ...string) []*models.File {
+	result := make([]*models.File, len(names))
+	for i, name := range names {
+		result[i] = &models.File{Name: name}
+	}
+	return result
+}
+
+// render shows each visible node indented by its depth, with a trailing slash
+// for directories
+func render(nodes []*Node) []string {
+	lines := make([]string, len(nodes))
+	for i, node := range nodes {
+		line := strings.Repeat("  ", node.Depth) + node.Name
+		if !node.IsLeaf() {
+			line += "/"
+		}
+		lines[i] = line
+	}
+	return lines
+}
+
+// TestFileManager is a function.
+func TestFileManager(t *testing.T) {
+	type scenario struct {
+		testName       string
+		files          []*models.File
+		showTree       bool
+		collapsedPaths []string
+		expected       []string
+	}
+
+	scenarios := []scenario{
+		{
+			"Flat list keeps the original order",
+			files("b/x", "a", "b/a"),
+			false,
+			[]string{},
+			[]string{"b/x", "a", "b/a"},
+		},
+		{
+			"Tree puts directories first and sorts by name",
+			files("z", "b/x", "a", "b/a"),
+			true,
+			[]string{},
+			[]string{"b/", "  a", "  x", "a", "z"},
+		},
+		{
+			"Directories containing only a directory are combined",
+			files("pkg/gui/a.go", "pkg/gui/b.go", "pkg/commands/c.go", "docs/config/d.md"),
+			true,
+			[]string{},
+			[]string{"docs/config/", "  d.md", "pkg/", "  commands/", "    c.go", "  gui/", "    a.go", "    b.go"},
+		},
+		{
+			"Collapsed directories hide their contents",
+			files("pkg/gui/a.go", "pkg/commands/c.go", "x"),
+			true,
+			[]string{"pkg/gui"},
+			[]string{"pkg/", "  commands/", "    c.go", "  gui/", "x"},
+		},
+		{
+			"Renames go under the new path",
+			[]*models.File{{Name: "new/a", PreviousName: "old/a"}},
+			true,
+			[]string{},
+			[]string{"new/", "  a"},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			m := NewFileManager(s.files, s.showTree)
+			for _, path := range s.collapsedPaths {
+				m.ToggleCollapsed(path)
+			}
+			assert.EqualValues(t, s.expected, render(m.GetVisibleNodes()))
+		})
+	}
+}
+
+// TestFileManagerKeepsCollapsedPaths is a function.
+func TestFileManagerKeepsCollapsedPaths(t *testing.T) {
+	m := NewFileManager(files("a/x", "b/y"), true)
+	m.ToggleCollapsed("a")
+	assert.EqualValues(t, []string{"a/", "b/", "  y"}, render(m.GetVisibleNodes()))
+
+	m.SetFiles(files("a/x", "a/z", "b/y"))
+	assert.EqualValues(t, []string{"a/", "b/", "  y"}, render(m.GetVisibleNodes()))
+
+	m.ToggleCollapsed("a")
+	assert.EqualValues(t, []string{"a/", "  x", "  z", "b/", "  y"}, render(m.GetVisibleNodes()))
+}
+
+// TestFileManagerToggleShowTree is a function.
+func TestFileManagerToggleShowTree(t *testing.T) {
+	m := NewFileManager(files("a/x", "b"), false)
+	assert.EqualValues(t, 2, m.GetItemsLength())
+
+	m.ToggleShowTree()
+	assert.True(t, m.ShowingTree())
+	assert.EqualValues(t, 3, m.GetItemsLength())
+
+	index, ok := m.GetIndexForPath("a/x")
+	assert.True(t, ok)
+	assert.EqualValues(t, 1, index)
+	assert.EqualValues(t, "a/x", m.GetNodeAtIndex(index).File().Name)
+	assert.Nil(t, m.GetNodeAtIndex(0).File())
+	assert.Nil(t, m.GetNodeAtIndex(3))
+}
+
+// TestFileManagerSetFilter is a function.
+func TestFileManagerSetFilter(t *testing.T) {
+	m := NewFileManager(files("a/x", "b", "a/y"), false)
+	m.SetFilter([]string{"a/y", "a/x", "gone"})
+	assert.EqualValues(t, []string{"a/y", "a/x"}, render(m.GetVisibleNodes()))
+	assert.EqualValues(t, 3, len(m.GetAllFiles()))
+	assert.EqualValues(t, []*models.File{m.GetAllFiles()[2], m.GetAllFiles()[0]}, m.GetFilteredFiles())
+
+	m.ToggleShowTree()
+	assert.EqualValues(t, []string{"a/", "  x", "  y"}, render(m.GetVisibleNodes()))
+
+	m.SetFilter(nil)
+	assert.EqualValues(t, []string{"a/", "  x", "  y", "b"}, render(m.GetVisibleNodes()))
+	assert.EqualValues(t, m.GetAllFiles(), m.GetFilteredFiles())
+}
+
+// TestNodeFiles is a function.
+func TestNodeFiles(t *testing.T) {
+	m := NewFileManager([]*models.File{
+		{Name: "a/x", HasStagedChanges: true},
+		{Name: "a/b/y", HasUnstagedChanges: true},
+		{Name: "c"},
+	}, true)
+
+	dir := m.GetNodeAtIndex(0)
+	assert.EqualValues(t, "a", dir.Path)
+	assert.Len(t, dir.Files(), 2)
+	assert.True(t, dir.AnyFile(func(f *models.File) bool { return f.HasUnstagedChanges }))
+	assert.False(t, dir.AnyFile(func(f *models.File) bool { return f.Tracked }))
+}
@@ -0,0 +1,199 @@
+package filetree
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// Item is anything with a path that we want to show in a tree i.e. a file from
+// git status or a file from a commit
+type Item interface {
+	GetPath() string
+}
+
+// Node is either a file, in which case it has an Item, or a directory, in which
+// case it has children
+type Node struct {
+	Item     Item
+	Children []*Node
+	Path     string
+	// Name is what we display for the node. If a directory's only child is
+	// another directory we show the two as one node, so this can contain slashes
+	Name string
+	// Depth is how far the node should be indented
+	Depth int
+}
+
+func (n *Node) IsLeaf() bool {
+	return n.Item != nil
+}
+
+func (n *Node) ID() string {
+	return n.Path
+}
+
+func (n *Node) Description() string {
+	return n.Path
+}
+
+// File returns the node's file, or nil if the node is a directory or a commit file
+func (n *Node) File() *models.File {
+	file, _ := n.Item.(*models.File)
+	return file
+}
+
+// CommitFile returns the node's commit file, or nil if the node is a directory or a file
+func (n *Node) CommitFile() *models.CommitFile {
+	commitFile, _ := n.Item.(*models.CommitFile)
+	return commitFile
+}
+
+// Leaves returns the file nodes at or below this node
+func (n *Node) Leaves() []*Node {
+	if n.IsLeaf() {
+		return []*Node{n}
+	}
+
+	leaves := []*Node{}
+	for _, child := range n.Children {
+		leaves = append(leaves, child.Leaves()...)
+	}
+	return leaves
+}
+
+// Files returns the files at or below this node
+func (n *Node) Files() []*models.File {
+	files := []*models.File{}
+	for _, leaf := range n.Leaves() {
+		if file := leaf.File(); file != nil {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// CommitFiles returns the commit files at or below this node
+func (n *Node) CommitFiles() []*models.CommitFile {
+	commitFiles := []*models.CommitFile{}
+	for _, leaf := range n.Leaves() {
+		if commitFile := leaf.CommitFile(); commitFile != nil {
+			commitFiles = append(commitFiles, commitFile)
+		}
+	}
+	return commitFiles
+}
+
+// AnyFile tells us whether any file at or below this node satisfies the test
+func (n *Node) AnyFile(test func(*models.File) bool) bool {
+	for _, file := range n.Files() {
+		if test(file) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildFlatTree puts every item directly under the root, in the order given
+func buildFlatTree(items []Item) *Node {
+	root := &Node{Depth: -1}
+	for _, item := range items {
+		root.Children = append(root.Children, &Node{Item: item, Path: item.GetPath(), Name: item.GetPath()})
+	}
+	return root
+}
+
+// buildTree puts each item under a node for each of its directories, with
+// directories before files and everything else in alphabetical order
+func buildTree(items []Item) *Node {
+	root := &Node{Depth: -1}
+	dirs := map[string]*Node{"": root}
+
+	var getDir func(dirPath string) *Node
+	getDir = func(dirPath string) *Node {
+		if dir, ok := dirs[dirPath]; ok {
+			return dir
+		}
+		parentPath := path.Dir(dirPath)
+		if parentPath == "." {
+			parentPath = ""
+		}
+		parent := getDir(parentPath)
+		dir := &Node{Path: dirPath, Name: path.Base(dirPath)}
+		parent.Children = append(parent.Children, dir)
+		dirs[dirPath] = dir
+		return dir
+	}
+
+	for _, item := range items {
+		itemPath := strings.TrimSuffix(item.GetPath(), "/")
+		dirPath := path.Dir(itemPath)
+		if dirPath == "." {
+			dirPath = ""
+		}
+		parent := getDir(dirPath)
+		parent.Children = append(parent.Children, &Node{Item: item, Path: item.GetPath(), Name: path.Base(itemPath)})
+	}
+
+	for _, child := range root.Children {
+		child.compress()
+	}
+	root.sort()
+	root.setDepth(-1)
+
+	return root
+}
+
+// compress merges directories which only contain a single directory, so that
+// deeply nested files don't take up a line for each level of nesting
+func (n *Node) compress() {
+	if n.IsLeaf() {
+		return
+	}
+
+	for len(n.Children) == 1 && !n.Children[0].IsLeaf() {
+		child := n.Children[0]
+		n.Path = child.Path
+		n.Name = n.Name + "/" + child.Name
+		n.Children = child.Children
+	}
+
+	for _, child := range n.Children {
+		child.compress()
+	}
+}
+
+func (n *Node) sort() {
+	sort.SliceStable(n.Children, func(i, j int) bool {
+		if n.Children[i].IsLeaf() != n.Children[j].IsLeaf() {
+			return !n.Children[i].IsLeaf()
+		}
+		return n.Children[i].Name < n.Children[j].Name
+	})
+
+	for _, child := range n.Children {
+		child.sort()
+	}
+}
+
+func (n *Node) setDepth(depth int) {
+	n.Depth = depth
+	for _, child := range n.Children {
+		child.setDepth(depth + 1)
+	}
+}
+
+// flatten returns the nodes below this one that can currently be seen, in the
+// order we display them
+func (n *Node) flatten(collapsedPaths map[string]bool) []*Node {
+	nodes := []*Node{}
+	for _, child := range n.Children {
+		nodes = append(nodes, child)
+		if !child.IsLeaf() && !collapsedPaths[child.Path] {
+			nodes = append(nodes, child.flatten(collapsedPaths)...)
+		}
+	}
+	return nodes
+}
@@ -1,6 +1,10 @@
 package gui
 
 func (gui *Gui) validateNotInFilterMode() (bool, error) {
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return ok, err
+	}
+
 	if gui.State.Modes.Filtering.Active() {
 		err := gui.ask(askOpts{
 			title:  gui.Tr.SLocalize("MustExitFilterModeTitle"),
@@ -12,6 +16,18 @@ func (gui *Gui) validateNotInFilterMode() (bool, error) {
 
 		return false, err
 	}
+
+	if gui.State.Modes.CommitSearch.Active() {
+		err := gui.ask(askOpts{
+			title:  gui.Tr.SLocalize("MustExitFilterModeTitle"),
+			prompt: gui.Tr.SLocalize("MustExitCommitSearchPrompt"),
+			handleConfirm: func() error {
+				return gui.exitCommitSearchMode()
+			},
+		})
+
+		return false, err
+	}
 	return true, nil
 }
 
@@ -13,16 +13,16 @@ func (gui *Gui) handleCreateFilteringMenuPanel(g *gocui.Gui, v *gocui.View) erro
 	}
 
 	fileName := ""
-	switch v.Name() {
-	case "files":
-		file := gui.getSelectedFile()
-		if file != nil {
-			fileName = file.Name
+	switch v.Context {
+	case FILES_CONTEXT_KEY:
+		node := gui.getSelectedFileNode()
+		if node != nil {
+			fileName = node.Path
 		}
-	case "commitFiles":
-		file := gui.getSelectedCommitFile()
-		if file != nil {
-			fileName = file.Name
+	case COMMIT_FILES_CONTEXT_KEY:
+		node := gui.getSelectedCommitFileNode()
+		if node != nil {
+			fileName = node.Path
 		}
 	}
 
@@ -0,0 +1,163 @@
+package gui
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// formatting patches turns commits into a patch series for a mailing list,
+// either as numbered files in a directory or as one mailbox in the clipboard.
+// The options are kept for the session so that rerolling a series is easy
+
+// formatPatchCommits returns the selected commits to format, newest first
+func (gui *Gui) formatPatchCommits() ([]*models.Commit, error) {
+	commits := gui.State.Commits
+	start, end := gui.State.Panels.Commits.GetSelectedRange()
+	if start < 0 || end >= len(commits) {
+		return nil, nil
+	}
+
+	for _, commit := range commits[start : end+1] {
+		if commit.Status == "rebasing" {
+			return nil, errors.New(gui.Tr.SLocalize("CantFormatRebasingCommits"))
+		}
+	}
+
+	return commits[start : end+1], nil
+}
+
+func (gui *Gui) handleCreateFormatPatchMenu() error {
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return err
+	}
+
+	return gui.createFormatPatchMenu(0)
+}
+
+// createFormatPatchMenu opens the menu with the given item selected, so that
+// changing an option leaves you where you were
+func (gui *Gui) createFormatPatchMenu(selectedLineIdx int) error {
+	commits, err := gui.formatPatchCommits()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(commits) == 0 {
+		return nil
+	}
+
+	opts := &gui.State.FormatPatchOptions
+
+	yesNo := func(value bool) string {
+		if value {
+			return gui.Tr.SLocalize("Yes")
+		}
+		return gui.Tr.SLocalize("No")
+	}
+
+	subjectPrefix := opts.SubjectPrefix
+	if subjectPrefix == "" {
+		subjectPrefix = gui.Tr.SLocalize("FormatPatchDefaultSubjectPrefix")
+	}
+
+	version := "1"
+	if opts.RerollCount > 0 {
+		version = strconv.Itoa(opts.RerollCount)
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{gui.Tr.SLocalize("FormatPatchToDirectory")},
+			onPress:        func() error { return gui.handleFormatPatchToDirectory(commits) },
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("FormatPatchToClipboard")},
+			onPress:        func() error { return gui.handleFormatPatchToClipboard(commits) },
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("FormatPatchCoverLetter"), yesNo(opts.CoverLetter)},
+			onPress: func() error {
+				opts.CoverLetter = !opts.CoverLetter
+				return gui.createFormatPatchMenu(2)
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("FormatPatchSubjectPrefix"), subjectPrefix},
+			onPress: func() error {
+				return gui.prompt(gui.Tr.SLocalize("FormatPatchSubjectPrefix"), opts.SubjectPrefix, func(prefix string) error {
+					opts.SubjectPrefix = strings.TrimSpace(prefix)
+					return gui.createFormatPatchMenu(3)
+				})
+			},
+		},
+		{
+			displayStrings: []string{gui.Tr.SLocalize("FormatPatchVersion"), version},
+			onPress: func() error {
+				return gui.prompt(gui.Tr.SLocalize("FormatPatchVersion"), version, func(value string) error {
+					rerollCount, err := strconv.Atoi(strings.TrimSpace(value))
+					if err != nil || rerollCount < 1 {
+						return gui.createErrorPanel(gui.Tr.SLocalize("InvalidFormatPatchVersion"))
+					}
+					if rerollCount == 1 {
+						// the first version of a series doesn't get a version number
+						rerollCount = 0
+					}
+					opts.RerollCount = rerollCount
+					return gui.createFormatPatchMenu(4)
+				})
+			},
+		},
+	}
+
+	commitRange := commits[0].ShortSha()
+	if len(commits) > 1 {
+		commitRange = commits[len(commits)-1].ShortSha() + ".." + commitRange
+	}
+	title := gui.Tr.TemplateLocalize("FormatPatchTitle", Teml{"commits": commitRange})
+	if err := gui.createMenu(title, menuItems, createMenuOptions{showCancel: true}); err != nil {
+		return err
+	}
+	gui.State.Panels.Menu.SelectedLineIdx = selectedLineIdx
+	return nil
+}
+
+func (gui *Gui) handleFormatPatchToDirectory(commits []*models.Commit) error {
+	opts := &gui.State.FormatPatchOptions
+
+	directory := opts.OutputDirectory
+	if directory == "" {
+		directory = "."
+	}
+
+	return gui.prompt(gui.Tr.SLocalize("FormatPatchDirectoryTitle"), directory, func(directory string) error {
+		opts.OutputDirectory = strings.TrimSpace(directory)
+
+		paths, err := gui.GitCommand.WithAction(gui.Tr.SLocalize("FormatPatch")).FormatPatch(commits, *opts)
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		if err := gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}}); err != nil {
+			return err
+		}
+
+		return gui.ask(askOpts{
+			title:  gui.Tr.SLocalize("FormatPatchWrittenTitle"),
+			prompt: strings.Join(paths, "\n"),
+		})
+	})
+}
+
+func (gui *Gui) handleFormatPatchToClipboard(commits []*models.Commit) error {
+	mbox, err := gui.GitCommand.WithAction(gui.Tr.SLocalize("FormatPatch")).FormatPatchToString(commits, gui.State.FormatPatchOptions)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if err := gui.OSCommand.CopyToClipboard(mbox); err != nil {
+		return gui.surfaceError(err)
+	}
+	return nil
+}
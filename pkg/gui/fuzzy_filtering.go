@@ -0,0 +1,493 @@
+package gui
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// fuzzy filtering narrows a list down to the items that fuzzy match what you
+// type, best match first. Unlike searching, which moves between matches in
+// what's been rendered, the items that don't match are taken out of the list
+// until you stop filtering, so whatever you do in the panel acts on the items
+// you can see. We stop filtering when you leave the panel
+
+type fuzzyFilterState struct {
+	// contextKey is the context whose list we're filtering, or empty if we're
+	// not filtering
+	contextKey string
+	needle     string
+	// prevSelectedLineIdx is where we were before we started filtering, which
+	// we go back to when you stop filtering
+	prevSelectedLineIdx int
+	list                *fuzzyFilterableList
+	matchCount          int
+	// positions are the matched runes of each item we're showing, keyed by the
+	// text we matched against
+	positions map[string][]int
+}
+
+func (s *fuzzyFilterState) active() bool {
+	return s.contextKey != ""
+}
+
+// fuzzyFilterableList is a list as it stood before we filtered it, along with
+// how to narrow it down and how to put it back
+type fuzzyFilterableList struct {
+	// unfiltered is the full list, e.g. a []*models.Branch
+	unfiltered interface{}
+	// candidates are what we match against, one for each item
+	candidates []string
+	// show replaces the list with the items at the given indices of the full
+	// list, in that order
+	show func(indices []int)
+	// candidateForLine returns the candidate of the item on the given line
+	// once we're showing some of the items
+	candidateForLine func(lineIdx int) string
+	// restore puts the full list back and returns the line that the item on
+	// the given line is now on
+	restore func(selectedLineIdx int) int
+}
+
+func (gui *Gui) isFuzzyFilterable(contextKey string) bool {
+	switch contextKey {
+	case FILES_CONTEXT_KEY,
+		LOCAL_BRANCHES_CONTEXT_KEY,
+		REMOTE_BRANCHES_CONTEXT_KEY,
+		TAGS_CONTEXT_KEY,
+		BRANCH_COMMITS_CONTEXT_KEY,
+		REFLOG_COMMITS_CONTEXT_KEY,
+		STASH_CONTEXT_KEY:
+		return true
+	}
+	return false
+}
+
+// newSliceFilterableList handles the lists that are slices in our state. show
+// replaces the slice with the items at the given indices of the full list, and
+// restore puts the full list back
+func newSliceFilterableList(unfiltered interface{}, candidates []string, show func(indices []int), restore func()) *fuzzyFilterableList {
+	shownIndices := []int{}
+	return &fuzzyFilterableList{
+		unfiltered: unfiltered,
+		candidates: candidates,
+		show: func(indices []int) {
+			shownIndices = indices
+			show(indices)
+		},
+		candidateForLine: func(lineIdx int) string {
+			if lineIdx < 0 || lineIdx >= len(shownIndices) {
+				return ""
+			}
+			return candidates[shownIndices[lineIdx]]
+		},
+		restore: func(selectedLineIdx int) int {
+			restore()
+			if selectedLineIdx < 0 || selectedLineIdx >= len(shownIndices) {
+				return 0
+			}
+			return shownIndices[selectedLineIdx]
+		},
+	}
+}
+
+// fuzzyFilterableList wraps the context's list as it currently stands, which
+// must be the full list
+func (gui *Gui) fuzzyFilterableList(contextKey string) *fuzzyFilterableList {
+	switch contextKey {
+	case FILES_CONTEXT_KEY:
+		fileManager := gui.State.FileManager
+		files := fileManager.GetAllFiles()
+		candidates := make([]string, len(files))
+		for i, file := range files {
+			candidates[i] = file.Name
+		}
+		return &fuzzyFilterableList{
+			unfiltered: files,
+			candidates: candidates,
+			show: func(indices []int) {
+				paths := make([]string, len(indices))
+				for i, index := range indices {
+					paths[i] = candidates[index]
+				}
+				fileManager.SetFilter(paths)
+			},
+			candidateForLine: func(lineIdx int) string {
+				node := fileManager.GetNodeAtIndex(lineIdx)
+				if node == nil || !node.IsLeaf() {
+					return ""
+				}
+				return node.Path
+			},
+			restore: func(selectedLineIdx int) int {
+				node := fileManager.GetNodeAtIndex(selectedLineIdx)
+				fileManager.SetFilter(nil)
+				if node == nil {
+					return 0
+				}
+				index, _ := fileManager.GetIndexForPath(node.Path)
+				return index
+			},
+		}
+
+	case LOCAL_BRANCHES_CONTEXT_KEY:
+		branches := gui.State.Branches
+		candidates := make([]string, len(branches))
+		for i, branch := range branches {
+			candidates[i] = branch.Name
+		}
+		return newSliceFilterableList(branches, candidates, func(indices []int) {
+			filtered := make([]*models.Branch, len(indices))
+			for i, index := range indices {
+				filtered[i] = branches[index]
+			}
+			gui.State.Branches = filtered
+		}, func() { gui.State.Branches = branches })
+
+	case REMOTE_BRANCHES_CONTEXT_KEY:
+		remoteBranches := gui.State.RemoteBranches
+		candidates := make([]string, len(remoteBranches))
+		for i, remoteBranch := range remoteBranches {
+			candidates[i] = remoteBranch.Name
+		}
+		return newSliceFilterableList(remoteBranches, candidates, func(indices []int) {
+			filtered := make([]*models.RemoteBranch, len(indices))
+			for i, index := range indices {
+				filtered[i] = remoteBranches[index]
+			}
+			gui.State.RemoteBranches = filtered
+		}, func() { gui.State.RemoteBranches = remoteBranches })
+
+	case TAGS_CONTEXT_KEY:
+		tags := gui.State.Tags
+		candidates := make([]string, len(tags))
+		for i, tag := range tags {
+			candidates[i] = tag.Name
+		}
+		return newSliceFilterableList(tags, candidates, func(indices []int) {
+			filtered := make([]*models.Tag, len(indices))
+			for i, index := range indices {
+				filtered[i] = tags[index]
+			}
+			gui.State.Tags = filtered
+		}, func() { gui.State.Tags = tags })
+
+	case BRANCH_COMMITS_CONTEXT_KEY:
+		commits := gui.State.Commits
+		candidates := make([]string, len(commits))
+		for i, commit := range commits {
+			candidates[i] = commit.Name
+		}
+		return newSliceFilterableList(commits, candidates, func(indices []int) {
+			filtered := make([]*models.Commit, len(indices))
+			for i, index := range indices {
+				filtered[i] = commits[index]
+			}
+			gui.State.Commits = filtered
+		}, func() { gui.State.Commits = commits })
+
+	case REFLOG_COMMITS_CONTEXT_KEY:
+		reflogCommits := gui.State.FilteredReflogCommits
+		candidates := make([]string, len(reflogCommits))
+		for i, commit := range reflogCommits {
+			candidates[i] = commit.Name
+		}
+		return newSliceFilterableList(reflogCommits, candidates, func(indices []int) {
+			filtered := make([]*models.Commit, len(indices))
+			for i, index := range indices {
+				filtered[i] = reflogCommits[index]
+			}
+			gui.State.FilteredReflogCommits = filtered
+		}, func() { gui.State.FilteredReflogCommits = reflogCommits })
+
+	case STASH_CONTEXT_KEY:
+		stashEntries := gui.State.StashEntries
+		candidates := make([]string, len(stashEntries))
+		for i, stashEntry := range stashEntries {
+			candidates[i] = stashEntry.Name
+		}
+		return newSliceFilterableList(stashEntries, candidates, func(indices []int) {
+			filtered := make([]*models.StashEntry, len(indices))
+			for i, index := range indices {
+				filtered[i] = stashEntries[index]
+			}
+			gui.State.StashEntries = filtered
+		}, func() { gui.State.StashEntries = stashEntries })
+	}
+
+	return nil
+}
+
+func (gui *Gui) isFuzzyFiltering(contextKey string) bool {
+	return gui.State.FuzzyFilter.active() && gui.State.FuzzyFilter.contextKey == contextKey
+}
+
+// unfilteredItems returns the full list of the context if we're filtering it,
+// otherwise nil
+func (gui *Gui) unfilteredItems(contextKey string) interface{} {
+	if !gui.isFuzzyFiltering(contextKey) {
+		return nil
+	}
+	return gui.State.FuzzyFilter.list.unfiltered
+}
+
+// unfilteredBranches returns every branch, even when we're only showing some,
+// so that e.g. the checked-out branch is always the first one
+func (gui *Gui) unfilteredBranches() []*models.Branch {
+	if branches, ok := gui.unfilteredItems(LOCAL_BRANCHES_CONTEXT_KEY).([]*models.Branch); ok {
+		return branches
+	}
+	return gui.State.Branches
+}
+
+func (gui *Gui) unfilteredCommits() []*models.Commit {
+	if commits, ok := gui.unfilteredItems(BRANCH_COMMITS_CONTEXT_KEY).([]*models.Commit); ok {
+		return commits
+	}
+	return gui.State.Commits
+}
+
+func (gui *Gui) unfilteredReflogCommits() []*models.Commit {
+	if commits, ok := gui.unfilteredItems(REFLOG_COMMITS_CONTEXT_KEY).([]*models.Commit); ok {
+		return commits
+	}
+	return gui.State.FilteredReflogCommits
+}
+
+func (gui *Gui) fuzzyFilterListContext() *ListContext {
+	return gui.mustContextForContextKey(gui.State.FuzzyFilter.contextKey).(*ListContext)
+}
+
+func (gui *Gui) handleOpenFuzzyFilter(g *gocui.Gui, v *gocui.View) error {
+	listContext := gui.currentSideContext()
+	if listContext == nil || !gui.isFuzzyFilterable(listContext.GetKey()) {
+		return nil
+	}
+
+	state := &gui.State.FuzzyFilter
+	if !gui.isFuzzyFiltering(listContext.GetKey()) {
+		if listContext.GetKey() == BRANCH_COMMITS_CONTEXT_KEY && gui.State.Panels.Commits.LimitCommits {
+			// we usually lazyload these commits but we want to filter all of them
+			gui.State.Panels.Commits.LimitCommits = false
+			if err := gui.refreshSidePanels(refreshOptions{mode: SYNC, scope: []int{COMMITS}}); err != nil {
+				return err
+			}
+		}
+
+		if _, err := listContext.cancelRangeSelect(); err != nil {
+			return err
+		}
+
+		*state = fuzzyFilterState{
+			contextKey:          listContext.GetKey(),
+			prevSelectedLineIdx: listContext.GetPanelState().GetSelectedLineIdx(),
+			list:                gui.fuzzyFilterableList(listContext.GetKey()),
+		}
+		if err := gui.applyFuzzyFilter(); err != nil {
+			return err
+		}
+	}
+
+	if err := gui.renderStringSync("filter", state.needle); err != nil {
+		return err
+	}
+	// the filter view only gets its size once it's laid out, and until then
+	// the cursor can't move past its start
+	gui.g.Update(func(g *gocui.Gui) error {
+		gui.getFilterView().EditGotoToEndOfLine()
+		return nil
+	})
+
+	return gui.switchContext(gui.Contexts.FuzzyFilter.Context)
+}
+
+// applyFuzzyFilter shows the items of the full list that match the needle
+func (gui *Gui) applyFuzzyFilter() error {
+	state := &gui.State.FuzzyFilter
+	matches := utils.FuzzySearch(state.needle, state.list.candidates)
+
+	indices := make([]int, len(matches))
+	state.positions = make(map[string][]int, len(matches))
+	for i, match := range matches {
+		indices[i] = match.Index
+		state.positions[state.list.candidates[match.Index]] = match.Positions
+	}
+	state.matchCount = len(matches)
+	state.list.show(indices)
+
+	return gui.fuzzyFilterListContext().HandleRender()
+}
+
+// reapplyFuzzyFilter filters the context's list again after it's been
+// refreshed, given the refresh replaced what we were showing with the full list
+func (gui *Gui) reapplyFuzzyFilter(contextKey string) {
+	if !gui.isFuzzyFiltering(contextKey) {
+		return
+	}
+
+	gui.State.FuzzyFilter.list = gui.fuzzyFilterableList(contextKey)
+	if err := gui.applyFuzzyFilter(); err != nil {
+		gui.Log.Error(err)
+	}
+}
+
+// fuzzyFilterEditor is gocui's default editor, except that we filter the list
+// as you type
+func (gui *Gui) fuzzyFilterEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	if !gui.State.FuzzyFilter.active() || key == gocui.KeyTab {
+		return
+	}
+
+	gocui.DefaultEditor.Edit(v, key, ch, mod)
+
+	needle := v.Buffer()
+	if needle == gui.State.FuzzyFilter.needle {
+		return
+	}
+	gui.State.FuzzyFilter.needle = needle
+
+	// the best match is at the top
+	listContext := gui.fuzzyFilterListContext()
+	listContext.GetPanelState().SetSelectedLineIdx(0)
+	if err := gui.applyFuzzyFilter(); err != nil {
+		gui.Log.Error(err)
+		return
+	}
+	if err := listContext.HandleFocus(); err != nil {
+		gui.Log.Error(err)
+	}
+}
+
+// you can move through the list while you're typing
+func (gui *Gui) handleFuzzyFilterPrevLine(g *gocui.Gui, v *gocui.View) error {
+	return gui.fuzzyFilterListContext().handleLineChange(-1)
+}
+
+func (gui *Gui) handleFuzzyFilterNextLine(g *gocui.Gui, v *gocui.View) error {
+	return gui.fuzzyFilterListContext().handleLineChange(1)
+}
+
+func (gui *Gui) handleFuzzyFilterConfirm(g *gocui.Gui, v *gocui.View) error {
+	gui.renderFuzzyFilterStatus()
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) handleFuzzyFilterEscape(g *gocui.Gui, v *gocui.View) error {
+	if err := gui.returnFromContext(); err != nil {
+		return err
+	}
+	return gui.stopFuzzyFiltering(true)
+}
+
+// renderFuzzyFilterStatus shows what we're filtering by, once we're no longer
+// typing it
+func (gui *Gui) renderFuzzyFilterStatus() {
+	state := gui.State.FuzzyFilter
+	status := gui.Tr.TemplateLocalize(
+		"FuzzyFilterMatches",
+		Teml{
+			"count":  strconv.Itoa(state.matchCount),
+			"total":  strconv.Itoa(len(state.list.candidates)),
+			"needle": state.needle,
+		},
+	)
+	options := gui.Tr.TemplateLocalize(
+		"FuzzyFilterOptions",
+		Teml{
+			"filterKey": gui.getKeyDisplay("universal.startFuzzyFilter"),
+			"escapeKey": gui.getKeyDisplay("universal.return"),
+		},
+	)
+	gui.renderString("filter", status+" "+utils.ColoredString(options, theme.OptionsFgColor))
+}
+
+// stopFuzzyFiltering puts the full list back. If you've cancelled, we go back
+// to what was selected before you started filtering, otherwise we keep the item
+// you've got selected
+func (gui *Gui) stopFuzzyFiltering(cancelled bool) error {
+	state := gui.State.FuzzyFilter
+	if !state.active() {
+		return nil
+	}
+
+	listContext := gui.fuzzyFilterListContext()
+	gui.State.FuzzyFilter = fuzzyFilterState{}
+
+	panelState := listContext.GetPanelState()
+	selectedLineIdx := state.list.restore(panelState.GetSelectedLineIdx())
+	if cancelled {
+		selectedLineIdx = state.prevSelectedLineIdx
+	}
+	panelState.SetSelectedLineIdx(selectedLineIdx)
+
+	if err := listContext.HandleRender(); err != nil {
+		return err
+	}
+	if gui.currentContext().GetKey() != listContext.GetKey() {
+		return nil
+	}
+	return listContext.HandleFocus()
+}
+
+// highlightFuzzyMatches highlights the matched characters in the display
+// strings of the list we're filtering. The files panel may only show the last
+// part of a file's path, so we highlight what we can of it
+func (gui *Gui) highlightFuzzyMatches(displayStrings [][]string) {
+	state := gui.State.FuzzyFilter
+	for lineIdx, cells := range displayStrings {
+		candidate := state.list.candidateForLine(lineIdx)
+		positions := state.positions[candidate]
+		if len(positions) == 0 {
+			continue
+		}
+
+		if highlightCells(cells, candidate, positions) {
+			continue
+		}
+
+		slashIdx := strings.LastIndex(candidate, "/")
+		if slashIdx == -1 {
+			continue
+		}
+		offset := len([]rune(candidate[:slashIdx+1]))
+		namePositions := []int{}
+		for _, position := range positions {
+			if position >= offset {
+				namePositions = append(namePositions, position-offset)
+			}
+		}
+		highlightCells(cells, candidate[slashIdx+1:], namePositions)
+	}
+}
+
+// highlightCells highlights the match in the last cell it appears in
+func highlightCells(cells []string, match string, positions []int) bool {
+	for i := len(cells) - 1; i >= 0; i-- {
+		if highlighted, ok := utils.HighlightMatch(cells[i], match, positions); ok {
+			cells[i] = highlighted
+			return true
+		}
+	}
+	return false
+}
+
+// validateNotFuzzyFilteringCommits stops you doing things with the commits
+// that depend on where a commit is in the full list
+func (gui *Gui) validateNotFuzzyFilteringCommits() (bool, error) {
+	if gui.isFuzzyFiltering(BRANCH_COMMITS_CONTEXT_KEY) {
+		err := gui.ask(askOpts{
+			title:  gui.Tr.SLocalize("MustExitFilterModeTitle"),
+			prompt: gui.Tr.SLocalize("MustStopFuzzyFilteringPrompt"),
+			handleConfirm: func() error {
+				return gui.stopFuzzyFiltering(false)
+			},
+		})
+
+		return false, err
+	}
+	return true, nil
+}
@@ -113,13 +113,13 @@ func (gui *Gui) handleMouseDownMain(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	switch g.CurrentView().Name() {
-	case "files":
+	switch g.CurrentView().Context {
+	case FILES_CONTEXT_KEY:
 		// set filename, set primary/secondary selected, set line number, then switch context
 		// I'll need to know it was changed though.
 		// Could I pass something along to the context change?
 		return gui.enterFile(false, v.SelectedLineIdx())
-	case "commitFiles":
+	case COMMIT_FILES_CONTEXT_KEY:
 		return gui.enterCommitFile(v.SelectedLineIdx())
 	}
 
@@ -131,8 +131,8 @@ func (gui *Gui) handleMouseDownSecondary(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	switch g.CurrentView().Name() {
-	case "files":
+	switch g.CurrentView().Context {
+	case FILES_CONTEXT_KEY:
 		return gui.enterFile(true, v.SelectedLineIdx())
 	}
 
@@ -172,7 +172,7 @@ func (gui *Gui) fetch(canPromptForCredentials bool) (err error) {
 		fetchOpts.PromptUserForCredential = gui.promptUserForCredential
 	}
 
-	err = gui.GitCommand.Fetch(fetchOpts)
+	err = gui.GitCommand.WithAction(gui.Tr.SLocalize("fetch")).Fetch(fetchOpts)
 
 	if canPromptForCredentials && err != nil && strings.Contains(err.Error(), "exit status 128") {
 		gui.createErrorPanel(gui.Tr.SLocalize("PassUnameWrong"))
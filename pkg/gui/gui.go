@@ -26,6 +26,9 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/config"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
+	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation/graph"
 	"github.com/jesseduffield/lazygit/pkg/i18n"
 	"github.com/jesseduffield/lazygit/pkg/tasks"
 	"github.com/jesseduffield/lazygit/pkg/theme"
@@ -101,7 +104,6 @@ type Gui struct {
 	statusManager        *statusManager
 	credentials          credentials
 	waitForIntro         sync.WaitGroup
-	fileWatcher          *fileWatcher
 	viewBufferManagerMap map[string]*tasks.ViewBufferManager
 	stopChan             chan struct{}
 
@@ -110,10 +112,20 @@ type Gui struct {
 	showRecentRepos   bool
 	Contexts          ContextTree
 	ViewTabContextMap map[string][]tabContext
+
+	// these live outside of the gui state so that they stick around when we
+	// switch repos
+	ShowCommandLog         bool
+	ShowBackgroundCommands bool
 }
 
 type listPanelState struct {
 	SelectedLineIdx int
+
+	// when selecting a range, the range runs from RangeStartIdx to the selected
+	// line, in whichever direction you've moved
+	SelectingRange bool
+	RangeStartIdx  int
 }
 
 func (h *listPanelState) SetSelectedLineIdx(value int) {
@@ -124,9 +136,40 @@ func (h *listPanelState) GetSelectedLineIdx() int {
 	return h.SelectedLineIdx
 }
 
+func (h *listPanelState) IsSelectingRange() bool {
+	return h.SelectingRange
+}
+
+// ToggleRangeSelect starts selecting a range from the selected line, or stops
+// selecting one
+func (h *listPanelState) ToggleRangeSelect() {
+	h.SelectingRange = !h.SelectingRange
+	h.RangeStartIdx = h.SelectedLineIdx
+}
+
+func (h *listPanelState) CancelRangeSelect() {
+	h.SelectingRange = false
+}
+
+// GetSelectedRange returns the first and last selected lines, which are both
+// the selected line when we're not selecting a range
+func (h *listPanelState) GetSelectedRange() (int, int) {
+	if !h.SelectingRange || h.RangeStartIdx == h.SelectedLineIdx {
+		return h.SelectedLineIdx, h.SelectedLineIdx
+	}
+	if h.RangeStartIdx < h.SelectedLineIdx {
+		return h.RangeStartIdx, h.SelectedLineIdx
+	}
+	return h.SelectedLineIdx, h.RangeStartIdx
+}
+
 type IListPanelState interface {
 	SetSelectedLineIdx(int)
 	GetSelectedLineIdx() int
+	IsSelectingRange() bool
+	ToggleRangeSelect()
+	CancelRangeSelect()
+	GetSelectedRange() (int, int)
 }
 
 // for now the staging panel state, unlike the other panel states, is going to be
@@ -143,16 +186,40 @@ type lineByLinePanelState struct {
 }
 
 type mergingPanelState struct {
-	ConflictIndex int
-	ConflictTop   bool
-	Conflicts     []commands.Conflict
-	EditHistory   *stack.Stack
+	ConflictIndex   int
+	ConflictSection int // one of commands.CONFLICT_OURS, CONFLICT_BASE and CONFLICT_THEIRS
+	Conflicts       []commands.Conflict
+	EditHistory     *stack.Stack
 
 	// UserScrolling tells us if the user has started scrolling through the file themselves
 	// in which case we won't auto-scroll to a conflict.
 	UserScrolling bool
 }
 
+type blamePanelState struct {
+	// Ref is the commit we're blaming the file at. It's blank when we're blaming
+	// the file in the working tree
+	Ref             string
+	Path            string
+	SelectedLineIdx int
+	Lines           *blameLines
+
+	// History holds the files we've blamed on the way to this one when walking
+	// back through a file's history, so that we can return to them
+	History *stack.Stack
+}
+
+type mergeEditorPanelState struct {
+	Path string
+	// File is nil when the merge editor isn't open
+	File          *mergeconflicts.File
+	ConflictIndex int
+	Resolution    *mergeconflicts.Resolution
+	// Side is one of commands.CONFLICT_OURS and CONFLICT_THEIRS
+	Side            int
+	SelectedLineIdx int
+}
+
 type filePanelState struct {
 	listPanelState
 }
@@ -162,6 +229,14 @@ type branchPanelState struct {
 	listPanelState
 }
 
+type worktreePanelState struct {
+	listPanelState
+}
+
+type submodulePanelState struct {
+	listPanelState
+}
+
 type remotePanelState struct {
 	listPanelState
 }
@@ -178,6 +253,9 @@ type commitPanelState struct {
 	listPanelState
 
 	LimitCommits bool
+	// Graph remembers the graph it has drawn so far so that when we load more
+	// commits we only need to draw the new ones
+	Graph *graph.Graph
 }
 
 type reflogCommitPanelState struct {
@@ -212,6 +290,8 @@ type commitFilesPanelState struct {
 type panelStates struct {
 	Files          *filePanelState
 	Branches       *branchPanelState
+	Worktrees      *worktreePanelState
+	Submodules     *submodulePanelState
 	Remotes        *remotePanelState
 	RemoteBranches *remoteBranchesState
 	Tags           *tagsPanelState
@@ -223,6 +303,8 @@ type panelStates struct {
 	LineByLine     *lineByLinePanelState
 	Merging        *mergingPanelState
 	CommitFiles    *commitFilesPanelState
+	Blame          *blamePanelState
+	MergeEditor    *mergeEditorPanelState
 }
 
 type searchingState struct {
@@ -255,6 +337,16 @@ func (m *Filtering) Active() bool {
 	return m.Path != ""
 }
 
+// CommitSearch narrows down the commits we show to those matching what you're
+// searching for, on top of any path we're filtering by
+type CommitSearch struct {
+	Options commands.CommitSearchOptions
+}
+
+func (m *CommitSearch) Active() bool {
+	return m.Options.Active()
+}
+
 type CherryPicking struct {
 	CherryPickedCommits []*models.Commit
 
@@ -266,19 +358,48 @@ func (m *CherryPicking) Active() bool {
 	return len(m.CherryPickedCommits) > 0
 }
 
+// Bisecting mirrors the state of the repo's bisect, so it is reloaded whenever
+// we refresh our commits rather than being carried over in resetState
+type Bisecting struct {
+	Info *models.BisectInfo
+}
+
+func (m *Bisecting) Active() bool {
+	return m.Info != nil && m.Info.Started()
+}
+
+// RebaseOnto holds the commit you've marked as the upstream for a 'git rebase
+// --onto', meaning the commits after it are the ones that'll move once you pick
+// a new base
+type RebaseOnto struct {
+	Upstream *models.Commit
+}
+
+func (m *RebaseOnto) Active() bool {
+	return m.Upstream != nil
+}
+
 type Modes struct {
 	Filtering     Filtering
+	CommitSearch  CommitSearch
 	CherryPicking CherryPicking
 	Diffing       Diffing
+	Bisecting     Bisecting
+	RebaseOnto    RebaseOnto
 }
 
 type guiState struct {
-	Files            []*models.File
+	// FileManager holds the files from git status, which we show as a flat list
+	// or as a tree
+	FileManager      *filetree.FileManager
+	BinaryFiles      map[string]bool // whether git treats each file's changes as binary, until we next refresh the files
 	SubmoduleConfigs []*models.SubmoduleConfig
 	Branches         []*models.Branch
 	Commits          []*models.Commit
 	StashEntries     []*models.StashEntry
-	CommitFiles      []*models.CommitFile
+	// CommitFileManager holds the files of whichever commit or stash entry
+	// we're looking at
+	CommitFileManager *filetree.CommitFileManager
 	// FilteredReflogCommits are the ones that appear in the reflog panel.
 	// when in filtering mode we only include the ones that match the given path
 	FilteredReflogCommits []*models.Commit
@@ -290,6 +411,9 @@ type guiState struct {
 	Remotes               []*models.Remote
 	RemoteBranches        []*models.RemoteBranch
 	Tags                  []*models.Tag
+	Worktrees             []*models.Worktree
+	Submodules            []*models.SubmoduleConfig      // like SubmoduleConfigs but with each submodule's status loaded
+	PullRequests          map[string]*models.PullRequest // open pull requests, keyed by their local branch
 	MenuItems             []*menuItem
 	Updating              bool
 	Panels                *panelStates
@@ -302,6 +426,7 @@ type guiState struct {
 	FetchMutex            sync.Mutex
 	BranchCommitsMutex    sync.Mutex
 	Searching             searchingState
+	FuzzyFilter           fuzzyFilterState
 	ScreenMode            int
 	SideView              *gocui.View
 	Ptmx                  *os.File
@@ -309,6 +434,8 @@ type guiState struct {
 	PrevMainHeight        int
 	OldInformation        string
 	StartupStage          int // one of INITIAL and COMPLETE. Allows us to not load everything at once
+	// FormatPatchOptions are the options last used for formatting patches
+	FormatPatchOptions commands.FormatPatchOptions
 
 	Modes Modes
 
@@ -326,10 +453,11 @@ type guiState struct {
 }
 
 func (gui *Gui) resetState() {
-	// we carry over the filter path and diff state
+	// we carry over the filter path, commit search and diff state
 	prevFiltering := Filtering{
 		Path: "",
 	}
+	prevCommitSearch := CommitSearch{}
 	prevDiff := Diffing{}
 	prevCherryPicking := CherryPicking{
 		CherryPickedCommits: make([]*models.Commit, 0),
@@ -338,6 +466,7 @@ func (gui *Gui) resetState() {
 	prevRepoPathStack := []string{}
 	if gui.State != nil {
 		prevFiltering = gui.State.Modes.Filtering
+		prevCommitSearch = gui.State.Modes.CommitSearch
 		prevDiff = gui.State.Modes.Diffing
 		prevCherryPicking = gui.State.Modes.CherryPicking
 		prevRepoPathStack = gui.State.RepoPathStack
@@ -345,12 +474,17 @@ func (gui *Gui) resetState() {
 
 	modes := Modes{
 		Filtering:     prevFiltering,
+		CommitSearch:  prevCommitSearch,
 		CherryPicking: prevCherryPicking,
 		Diffing:       prevDiff,
 	}
 
+	showFileTree := gui.Config.GetUserConfig().GetBool("gui.showFileTree")
+
 	gui.State = &guiState{
-		Files:                 make([]*models.File, 0),
+		FileManager:           filetree.NewFileManager(make([]*models.File, 0), showFileTree),
+		BinaryFiles:           map[string]bool{},
+		CommitFileManager:     filetree.NewCommitFileManager(make([]*models.CommitFile, 0), showFileTree),
 		Commits:               make([]*models.Commit, 0),
 		FilteredReflogCommits: make([]*models.Commit, 0),
 		ReflogCommits:         make([]*models.Commit, 0),
@@ -359,20 +493,29 @@ func (gui *Gui) resetState() {
 			// TODO: work out why some of these are -1 and some are 0. Last time I checked there was a good reason but I'm less certain now
 			Files:          &filePanelState{listPanelState{SelectedLineIdx: -1}},
 			Branches:       &branchPanelState{listPanelState{SelectedLineIdx: 0}},
+			Worktrees:      &worktreePanelState{listPanelState{SelectedLineIdx: 0}},
+			Submodules:     &submodulePanelState{listPanelState{SelectedLineIdx: -1}},
 			Remotes:        &remotePanelState{listPanelState{SelectedLineIdx: 0}},
 			RemoteBranches: &remoteBranchesState{listPanelState{SelectedLineIdx: -1}},
 			Tags:           &tagsPanelState{listPanelState{SelectedLineIdx: -1}},
-			Commits:        &commitPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, LimitCommits: true},
+			Commits:        &commitPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, LimitCommits: true, Graph: graph.NewGraph()},
 			ReflogCommits:  &reflogCommitPanelState{listPanelState{SelectedLineIdx: 0}},
 			SubCommits:     &subCommitPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, refName: ""},
 			CommitFiles:    &commitFilesPanelState{listPanelState: listPanelState{SelectedLineIdx: -1}, refName: ""},
 			Stash:          &stashPanelState{listPanelState{SelectedLineIdx: -1}},
 			Menu:           &menuPanelState{listPanelState: listPanelState{SelectedLineIdx: 0}, OnPress: nil},
 			Merging: &mergingPanelState{
-				ConflictIndex: 0,
-				ConflictTop:   true,
-				Conflicts:     []commands.Conflict{},
-				EditHistory:   stack.New(),
+				ConflictIndex:   0,
+				ConflictSection: commands.CONFLICT_OURS,
+				Conflicts:       []commands.Conflict{},
+				EditHistory:     stack.New(),
+			},
+			Blame: &blamePanelState{
+				Lines:   newBlameLines(),
+				History: stack.New(),
+			},
+			MergeEditor: &mergeEditorPanelState{
+				Side: commands.CONFLICT_OURS,
 			},
 		},
 		SideView:       nil,
@@ -403,8 +546,6 @@ func NewGui(log *logrus.Entry, gitCommand *commands.GitCommand, oSCommand *oscom
 	gui.Contexts = gui.contextTree()
 	gui.ViewTabContextMap = gui.viewTabContextMap()
 
-	gui.watchFilesForChanges()
-
 	gui.GenerateSentinelErrors()
 
 	return gui, nil
@@ -439,6 +580,8 @@ func (gui *Gui) Run() error {
 
 	gui.g = g // TODO: always use gui.g rather than passing g around everywhere
 
+	gui.OSCommand.CmdLog.SetOnChange(gui.renderCommandLog)
+
 	if err := gui.setColorScheme(); err != nil {
 		return err
 	}
@@ -456,7 +599,9 @@ func (gui *Gui) Run() error {
 		go gui.startBackgroundFetch()
 	}
 
-	gui.goEvery(time.Second*10, gui.stopChan, gui.refreshFiles)
+	go gui.startPullRequestPolling()
+
+	gui.watchFilesForChanges()
 
 	g.SetManager(gocui.ManagerFunc(gui.layout), gocui.ManagerFunc(gui.getFocusLayout()))
 
@@ -478,10 +623,6 @@ func (gui *Gui) RunWithSubprocesses() error {
 			}
 			gui.viewBufferManagerMap = map[string]*tasks.ViewBufferManager{}
 
-			if !gui.fileWatcher.Disabled {
-				gui.fileWatcher.Watcher.Close()
-			}
-
 			close(gui.stopChan)
 
 			switch err {
@@ -514,7 +655,11 @@ func (gui *Gui) runCommand() error {
 
 	fmt.Fprintf(os.Stdout, "\n%s\n\n", utils.ColoredString("+ "+strings.Join(gui.SubProcess.Args, " "), color.FgBlue))
 
-	if err := gui.SubProcess.Run(); err != nil {
+	start := time.Now()
+	err := gui.SubProcess.Run()
+	// the subprocess's output went straight to the terminal so we've got none to log
+	gui.OSCommand.WithAction(gui.Tr.SLocalize("RunSubprocessAction")).LogCommand(strings.Join(gui.SubProcess.Args, " "), start, "", err)
+	if err != nil {
 		// not handling the error explicitly because usually we're going to see it
 		// in the output anyway
 		gui.Log.Error(err)
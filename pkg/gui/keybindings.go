@@ -363,102 +363,140 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.commitChanges"),
 			Handler:     gui.wrappedHandler(gui.handleCommitPress),
 			Description: gui.Tr.SLocalize("CommitChanges"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.commitChangesWithoutHook"),
 			Handler:     gui.handleWIPCommitPress,
 			Description: gui.Tr.SLocalize("commitChangesWithoutHook"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.amendLastCommit"),
 			Handler:     gui.wrappedHandler(gui.handleAmendCommitPress),
 			Description: gui.Tr.SLocalize("AmendLastCommit"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.commitChangesWithEditor"),
 			Handler:     gui.wrappedHandler(gui.handleCommitEditorPress),
 			Description: gui.Tr.SLocalize("CommitChangesWithEditor"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("universal.select"),
 			Handler:     gui.wrappedHandler(gui.handleFilePress),
 			Description: gui.Tr.SLocalize("toggleStaged"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("universal.remove"),
 			Handler:     gui.handleCreateDiscardMenu,
 			Description: gui.Tr.SLocalize("viewDiscardOptions"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("universal.edit"),
 			Handler:     gui.handleFileEdit,
 			Description: gui.Tr.SLocalize("editFile"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("universal.openFile"),
 			Handler:     gui.handleFileOpen,
 			Description: gui.Tr.SLocalize("openFile"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.ignoreFile"),
 			Handler:     gui.handleIgnoreFile,
 			Description: gui.Tr.SLocalize("ignoreFile"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.refreshFiles"),
 			Handler:     gui.handleRefreshFiles,
 			Description: gui.Tr.SLocalize("refreshFiles"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.stashAllChanges"),
 			Handler:     gui.handleStashChanges,
 			Description: gui.Tr.SLocalize("stashAllChanges"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.viewStashOptions"),
 			Handler:     gui.handleCreateStashMenu,
 			Description: gui.Tr.SLocalize("viewStashOptions"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.toggleStagedAll"),
 			Handler:     gui.handleStageAll,
 			Description: gui.Tr.SLocalize("toggleStagedAll"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.viewResetOptions"),
 			Handler:     gui.handleCreateResetMenu,
 			Description: gui.Tr.SLocalize("viewResetOptions"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("universal.goInto"),
 			Handler:     gui.handleEnterFile,
 			Description: gui.Tr.SLocalize("StageLines"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("files.fetch"),
 			Handler:     gui.handleGitFetch,
 			Description: gui.Tr.SLocalize("fetch"),
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
+			Key:         gui.getKey("files.viewBlame"),
+			Handler:     gui.wrappedHandler(gui.handleBlameFile),
+			Description: gui.Tr.SLocalize("viewBlame"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
+			Key:         gui.getKey("files.openMergeEditor"),
+			Handler:     gui.wrappedHandler(gui.handleOpenMergeEditor),
+			Description: gui.Tr.SLocalize("OpenMergeEditor"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
+			Key:         gui.getKey("files.toggleTreeView"),
+			Handler:     gui.handleToggleFileTreeView,
+			Description: gui.Tr.SLocalize("toggleTreeView"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("universal.copyToClipboard"),
 			Handler:     gui.wrappedHandler(gui.handleCopySelectedSideContextItemToClipboard),
 			Description: gui.Tr.SLocalize("copyFileNameToClipboard"),
@@ -471,6 +509,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 		},
 		{
 			ViewName:    "files",
+			Contexts:    []string{FILES_CONTEXT_KEY},
 			Key:         gui.getKey("commits.viewResetOptions"),
 			Handler:     gui.handleCreateResetToUpstreamMenu,
 			Description: gui.Tr.SLocalize("viewResetToUpstreamOptions"),
@@ -489,6 +528,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreatePullRequestPress,
 			Description: gui.Tr.SLocalize("createPullRequest"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{LOCAL_BRANCHES_CONTEXT_KEY},
+			Key:         gui.getKey("branches.viewPullRequests"),
+			Handler:     gui.handleViewPullRequests,
+			Description: gui.Tr.SLocalize("viewPullRequests"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{LOCAL_BRANCHES_CONTEXT_KEY},
@@ -573,6 +619,41 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.wrappedHandler(gui.handleSwitchToSubCommits),
 			Description: gui.Tr.SLocalize("viewCommits"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{LOCAL_BRANCHES_CONTEXT_KEY},
+			Key:         gui.getKey("branches.createWorktree"),
+			Handler:     gui.handleCreateWorktreeFromBranch,
+			Description: gui.Tr.SLocalize("createWorktreeFromBranch"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{WORKTREES_CONTEXT_KEY},
+			Key:         gui.getKey("universal.select"),
+			Handler:     gui.wrappedHandler(gui.handleSwitchToWorktree),
+			Description: gui.Tr.SLocalize("switchToWorktree"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{WORKTREES_CONTEXT_KEY},
+			Key:         gui.getKey("universal.new"),
+			Handler:     gui.handleCreateWorktree,
+			Description: gui.Tr.SLocalize("createWorktree"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{WORKTREES_CONTEXT_KEY},
+			Key:         gui.getKey("universal.remove"),
+			Handler:     gui.handleRemoveWorktree,
+			Description: gui.Tr.SLocalize("removeWorktree"),
+		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{WORKTREES_CONTEXT_KEY},
+			Key:         gui.getKey("branches.pruneWorktrees"),
+			Handler:     gui.handlePruneWorktrees,
+			Description: gui.Tr.SLocalize("pruneWorktrees"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{TAGS_CONTEXT_KEY},
@@ -608,6 +689,13 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateResetToTagMenu,
 			Description: gui.Tr.SLocalize("viewResetOptions"),
 		},
+		{
+			ViewName:    "branches",
+			Contexts:    []string{TAGS_CONTEXT_KEY},
+			Key:         gui.getKey("branches.rebaseBranch"),
+			Handler:     gui.wrappedHandler(gui.handleRebaseOntoTag),
+			Description: gui.Tr.SLocalize("rebaseOntoTag"),
+		},
 		{
 			ViewName:    "branches",
 			Contexts:    []string{TAGS_CONTEXT_KEY},
@@ -615,6 +703,60 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.wrappedHandler(gui.handleSwitchToSubCommits),
 			Description: gui.Tr.SLocalize("viewCommits"),
 		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("universal.nextTab"),
+			Handler:     gui.handleNextTab,
+			Description: gui.Tr.SLocalize("nextTab"),
+		},
+		{
+			ViewName:    "files",
+			Key:         gui.getKey("universal.prevTab"),
+			Handler:     gui.handlePrevTab,
+			Description: gui.Tr.SLocalize("prevTab"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{SUBMODULES_CONTEXT_KEY},
+			Key:         gui.getKey("universal.goInto"),
+			Handler:     gui.wrappedHandler(gui.handleEnterSubmodule),
+			Description: gui.Tr.SLocalize("enterSubmodule"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{SUBMODULES_CONTEXT_KEY},
+			Key:         gui.getKey("submodules.init"),
+			Handler:     gui.handleSubmoduleInit,
+			Description: gui.Tr.SLocalize("initSubmodule"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{SUBMODULES_CONTEXT_KEY},
+			Key:         gui.getKey("submodules.update"),
+			Handler:     gui.handleCreateSubmoduleUpdateMenu,
+			Description: gui.Tr.SLocalize("viewSubmoduleUpdateOptions"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{SUBMODULES_CONTEXT_KEY},
+			Key:         gui.getKey("submodules.sync"),
+			Handler:     gui.handleSubmoduleSync,
+			Description: gui.Tr.SLocalize("syncSubmodule"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{SUBMODULES_CONTEXT_KEY},
+			Key:         gui.getKey("universal.new"),
+			Handler:     gui.handleAddSubmodule,
+			Description: gui.Tr.SLocalize("addSubmodule"),
+		},
+		{
+			ViewName:    "files",
+			Contexts:    []string{SUBMODULES_CONTEXT_KEY},
+			Key:         gui.getKey("universal.remove"),
+			Handler:     gui.handleRemoveSubmodule,
+			Description: gui.Tr.SLocalize("removeSubmodule"),
+		},
 		{
 			ViewName:    "branches",
 			Key:         gui.getKey("universal.nextTab"),
@@ -829,6 +971,48 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.wrappedHandler(gui.exitCherryPickingMode),
 			Description: gui.Tr.SLocalize("resetCherryPick"),
 		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{BRANCH_COMMITS_CONTEXT_KEY},
+			Key:         gui.getKey("commits.viewBisectOptions"),
+			Handler:     gui.wrappedHandler(gui.handleCreateBisectMenu),
+			Description: gui.Tr.SLocalize("viewBisectOptions"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{BRANCH_COMMITS_CONTEXT_KEY},
+			Key:         gui.getKey("commits.importPatches"),
+			Handler:     gui.wrappedHandler(gui.handleCreateImportPatchesMenu),
+			Description: gui.Tr.SLocalize("ImportPatches"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{BRANCH_COMMITS_CONTEXT_KEY},
+			Key:         gui.getKey("commits.formatPatch"),
+			Handler:     gui.wrappedHandler(gui.handleCreateFormatPatchMenu),
+			Description: gui.Tr.SLocalize("FormatPatch"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{BRANCH_COMMITS_CONTEXT_KEY},
+			Key:         gui.getKey("commits.markCommitAsUpstream"),
+			Handler:     gui.wrappedHandler(gui.handleMarkCommitAsUpstream),
+			Description: gui.Tr.SLocalize("markCommitAsUpstream"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{BRANCH_COMMITS_CONTEXT_KEY},
+			Key:         gui.getKey("commits.addTodoLine"),
+			Handler:     gui.wrappedHandler(gui.handleAddTodoLine),
+			Description: gui.Tr.SLocalize("AddTodoLine"),
+		},
+		{
+			ViewName:    "commits",
+			Contexts:    []string{BRANCH_COMMITS_CONTEXT_KEY},
+			Key:         gui.getKey("commits.openSearchMenu"),
+			Handler:     gui.wrappedHandler(gui.handleCreateCommitSearchMenu),
+			Description: gui.Tr.SLocalize("openCommitSearchMenu"),
+		},
 		{
 			ViewName:    "commits",
 			Contexts:    []string{REFLOG_COMMITS_CONTEXT_KEY},
@@ -1042,6 +1226,18 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleEnterCommitFile,
 			Description: gui.Tr.SLocalize("enterFile"),
 		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.viewBlame"),
+			Handler:     gui.wrappedHandler(gui.handleBlameCommitFile),
+			Description: gui.Tr.SLocalize("viewBlame"),
+		},
+		{
+			ViewName:    "commitFiles",
+			Key:         gui.getKey("commitFiles.toggleTreeView"),
+			Handler:     gui.handleToggleCommitFileTreeView,
+			Description: gui.Tr.SLocalize("toggleTreeView"),
+		},
 		{
 			ViewName:    "",
 			Key:         gui.getKey("universal.filteringMenu"),
@@ -1060,6 +1256,12 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handleCreateDiffingMenuPanel,
 			Description: gui.Tr.SLocalize("openDiffingMenu"),
 		},
+		{
+			ViewName:    "",
+			Key:         gui.getKey("universal.commandLogMenu"),
+			Handler:     gui.handleCreateCommandLogMenu,
+			Description: gui.Tr.SLocalize("openCommandLogMenu"),
+		},
 		{
 			ViewName: "secondary",
 			Key:      gocui.MouseWheelUp,
@@ -1331,7 +1533,7 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "main",
 			Contexts:    []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:         gui.getKey("main.pickBothHunks"),
-			Handler:     gui.handlePickBothHunks,
+			Handler:     gui.handlePickAllHunks,
 			Description: gui.Tr.SLocalize("PickBothHunks"),
 		},
 		{
@@ -1352,14 +1554,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			ViewName:    "main",
 			Contexts:    []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:         gui.getKey("universal.prevItem"),
-			Handler:     gui.handleSelectTop,
+			Handler:     gui.handleSelectPrevSection,
 			Description: gui.Tr.SLocalize("SelectTop"),
 		},
 		{
 			ViewName:    "main",
 			Contexts:    []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:         gui.getKey("universal.nextItem"),
-			Handler:     gui.handleSelectBottom,
+			Handler:     gui.handleSelectNextSection,
 			Description: gui.Tr.SLocalize("SelectBottom"),
 		},
 		{
@@ -1367,14 +1569,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts: []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:      gocui.MouseWheelUp,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectTop,
+			Handler:  gui.handleSelectPrevSection,
 		},
 		{
 			ViewName: "main",
 			Contexts: []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:      gocui.MouseWheelDown,
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectBottom,
+			Handler:  gui.handleSelectNextSection,
 		},
 		{
 			ViewName: "main",
@@ -1395,14 +1597,14 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Contexts: []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:      gui.getKey("universal.prevItem-alt"),
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectTop,
+			Handler:  gui.handleSelectPrevSection,
 		},
 		{
 			ViewName: "main",
 			Contexts: []string{MAIN_MERGING_CONTEXT_KEY},
 			Key:      gui.getKey("universal.nextItem-alt"),
 			Modifier: gocui.ModNone,
-			Handler:  gui.handleSelectBottom,
+			Handler:  gui.handleSelectNextSection,
 		},
 		{
 			ViewName:    "main",
@@ -1411,6 +1613,209 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Handler:     gui.handlePopFileSnapshot,
 			Description: gui.Tr.SLocalize("undo"),
 		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGING_CONTEXT_KEY},
+			Key:         gui.getKey("main.openMergeEditor"),
+			Handler:     gui.wrappedHandler(gui.handleOpenMergeEditor),
+			Description: gui.Tr.SLocalize("OpenMergeEditor"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:         gui.getKey("universal.return"),
+			Handler:     gui.wrappedHandler(gui.handleBlameEscape),
+			Description: gui.Tr.SLocalize("ExitBlame"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:         gui.getKey("universal.prevItem"),
+			Handler:     gui.handleBlamePrevLine,
+			Description: gui.Tr.SLocalize("PrevLine"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:         gui.getKey("universal.nextItem"),
+			Handler:     gui.handleBlameNextLine,
+			Description: gui.Tr.SLocalize("NextLine"),
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:      gui.getKey("universal.prevItem-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlamePrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:      gui.getKey("universal.nextItem-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlameNextLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:      gocui.MouseWheelUp,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlamePrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:      gocui.MouseWheelDown,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleBlameNextLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:         gui.getKey("universal.goInto"),
+			Handler:     gui.wrappedHandler(gui.handleBlameGoToCommit),
+			Description: gui.Tr.SLocalize("GoToBlamedCommit"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_BLAME_CONTEXT_KEY},
+			Key:         gui.getKey("main.blameAtParent"),
+			Handler:     gui.wrappedHandler(gui.handleBlameAtParent),
+			Description: gui.Tr.SLocalize("BlameAtParent"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.return"),
+			Handler:     gui.wrappedHandler(gui.handleMergeEditorEscape),
+			Description: gui.Tr.SLocalize("ReturnToFilesPanel"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.prevItem"),
+			Handler:     gui.handleMergeEditorPrevLine,
+			Description: gui.Tr.SLocalize("PrevLine"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.nextItem"),
+			Handler:     gui.handleMergeEditorNextLine,
+			Description: gui.Tr.SLocalize("NextLine"),
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:      gui.getKey("universal.prevItem-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleMergeEditorPrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:      gui.getKey("universal.nextItem-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleMergeEditorNextLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:      gocui.MouseWheelUp,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleMergeEditorPrevLine,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:      gocui.MouseWheelDown,
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleMergeEditorNextLine,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.prevBlock"),
+			Handler:     gui.handleMergeEditorSelectOurs,
+			Description: gui.Tr.SLocalize("MergeEditorSelectOurs"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.nextBlock"),
+			Handler:     gui.handleMergeEditorSelectTheirs,
+			Description: gui.Tr.SLocalize("MergeEditorSelectTheirs"),
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:      gui.getKey("universal.prevBlock-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleMergeEditorSelectOurs,
+		},
+		{
+			ViewName: "main",
+			Contexts: []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:      gui.getKey("universal.nextBlock-alt"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleMergeEditorSelectTheirs,
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.togglePanel"),
+			Handler:     gui.handleMergeEditorToggleSide,
+			Description: gui.Tr.SLocalize("MergeEditorToggleSide"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.select"),
+			Handler:     gui.handleMergeEditorPickLine,
+			Description: gui.Tr.SLocalize("MergeEditorPickLine"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("main.toggleSelectHunk"),
+			Handler:     gui.handleMergeEditorPickSide,
+			Description: gui.Tr.SLocalize("MergeEditorPickSide"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.edit"),
+			Handler:     gui.handleMergeEditorEditResult,
+			Description: gui.Tr.SLocalize("MergeEditorEditResult"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.confirm"),
+			Handler:     gui.handleMergeEditorApply,
+			Description: gui.Tr.SLocalize("MergeEditorApply"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.prevTab"),
+			Handler:     gui.handleMergeEditorPrevConflict,
+			Description: gui.Tr.SLocalize("PrevConflict"),
+		},
+		{
+			ViewName:    "main",
+			Contexts:    []string{MAIN_MERGE_EDITOR_CONTEXT_KEY},
+			Key:         gui.getKey("universal.nextTab"),
+			Handler:     gui.handleMergeEditorNextConflict,
+			Description: gui.Tr.SLocalize("NextConflict"),
+		},
+		{
+			ViewName:    "mergeResult",
+			Contexts:    []string{MERGE_RESULT_CONTEXT_KEY},
+			Key:         gui.getKey("universal.return"),
+			Handler:     gui.handleMergeResultDone,
+			Description: gui.Tr.SLocalize("MergeEditorDoneEditing"),
+		},
 		{
 			ViewName: "branches",
 			Contexts: []string{REMOTES_CONTEXT_KEY},
@@ -1500,6 +1905,30 @@ func (gui *Gui) GetInitialKeybindings() []*Binding {
 			Modifier: gocui.ModNone,
 			Handler:  gui.handleSearchEscape,
 		},
+		{
+			ViewName: "filter",
+			Key:      gui.getKey("universal.confirm"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleFuzzyFilterConfirm,
+		},
+		{
+			ViewName: "filter",
+			Key:      gui.getKey("universal.return"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleFuzzyFilterEscape,
+		},
+		{
+			ViewName: "filter",
+			Key:      gui.getKey("universal.prevItem"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleFuzzyFilterPrevLine,
+		},
+		{
+			ViewName: "filter",
+			Key:      gui.getKey("universal.nextItem"),
+			Modifier: gocui.ModNone,
+			Handler:  gui.handleFuzzyFilterNextLine,
+		},
 		{
 			ViewName: "confirmation",
 			Key:      gui.getKey("universal.prevItem"),
@@ -1575,6 +2004,7 @@ func (gui *Gui) keybindings() error {
 	}
 
 	tabClickBindings := map[string]func(int) error{
+		"files":    func(tabIndex int) error { return gui.onViewTabClick("files", tabIndex) },
 		"branches": func(tabIndex int) error { return gui.onViewTabClick("branches", tabIndex) },
 		"commits":  func(tabIndex int) error { return gui.onViewTabClick("commits", tabIndex) },
 	}
@@ -7,6 +7,7 @@ import (
 )
 
 const SEARCH_PREFIX = "search: "
+const FUZZY_FILTER_PREFIX = "filter: "
 const INFO_SECTION_PADDING = " "
 
 func (gui *Gui) informationStr() string {
@@ -121,6 +122,25 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		secondaryView.IgnoreCarriageReturns = true
 	}
 
+	if mergeResultView, err := setViewFromDimensions("mergeResult", "mergeResult", true); err != nil {
+		if err.Error() != "unknown view" {
+			return err
+		}
+		mergeResultView.FgColor = textColor
+		mergeResultView.Editable = true
+		mergeResultView.Editor = gocui.EditorFunc(gui.mergeResultEditor)
+	}
+
+	if commandLogView, err := setViewFromDimensions("commandLog", "commandLog", true); err != nil {
+		if err.Error() != "unknown view" {
+			return err
+		}
+		commandLogView.Title = gui.Tr.SLocalize("CommandLogTitle")
+		commandLogView.FgColor = textColor
+		commandLogView.Autoscroll = true
+		gui.renderCommandLog()
+	}
+
 	hiddenViewOffset := 9999
 
 	if v, err := setViewFromDimensions("status", "status", true); err != nil {
@@ -138,6 +158,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		}
 		filesView.Highlight = true
 		filesView.Title = gui.Tr.SLocalize("FilesTitle")
+		filesView.Tabs = gui.viewTabNames("files")
 		filesView.ContainsList = true
 	}
 
@@ -246,6 +267,29 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		searchView.Editable = true
 	}
 
+	if filterPrefixView, err := setViewFromDimensions("filterPrefix", "filterPrefix", false); err != nil {
+		if err.Error() != "unknown view" {
+			return err
+		}
+
+		filterPrefixView.BgColor = gocui.ColorDefault
+		filterPrefixView.FgColor = gocui.ColorGreen
+		filterPrefixView.Frame = false
+		gui.setViewContent(filterPrefixView, FUZZY_FILTER_PREFIX)
+	}
+
+	if filterView, err := setViewFromDimensions("filter", "filter", false); err != nil {
+		if err.Error() != "unknown view" {
+			return err
+		}
+
+		filterView.BgColor = gocui.ColorDefault
+		filterView.FgColor = gocui.ColorGreen
+		filterView.Frame = false
+		filterView.Editable = true
+		filterView.Editor = gocui.EditorFunc(gui.fuzzyFilterEditor)
+	}
+
 	if appStatusView, err := setViewFromDimensions("appStatus", "appStatus", false); err != nil {
 		if err.Error() != "unknown view" {
 			return err
@@ -273,7 +317,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 
 	if gui.g.CurrentView() == nil {
 		initialContext := gui.Contexts.Files.Context
-		if gui.State.Modes.Filtering.Active() {
+		if gui.State.Modes.Filtering.Active() || gui.State.Modes.CommitSearch.Active() {
 			initialContext = gui.Contexts.BranchCommits.Context
 		}
 
@@ -289,7 +333,9 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 
 	listContextStates := []listContextState{
 		{view: filesView, listContext: gui.filesListContext()},
+		{view: filesView, listContext: gui.submodulesListContext()},
 		{view: branchesView, listContext: gui.branchesListContext()},
+		{view: branchesView, listContext: gui.worktreesListContext()},
 		{view: branchesView, listContext: gui.remotesListContext()},
 		{view: branchesView, listContext: gui.remoteBranchesListContext()},
 		{view: branchesView, listContext: gui.tagsListContext()},
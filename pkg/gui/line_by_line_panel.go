@@ -225,7 +225,12 @@ func (gui *Gui) handleMouseScrollDown(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) getSelectedCommitFileName() string {
-	return gui.State.CommitFiles[gui.State.Panels.CommitFiles.SelectedLineIdx].Name
+	commitFile := gui.getSelectedCommitFile()
+	if commitFile == nil {
+		return ""
+	}
+
+	return commitFile.Name
 }
 
 func (gui *Gui) refreshMainViewForLineByLine() error {
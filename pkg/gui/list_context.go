@@ -26,6 +26,9 @@ type ListContext struct {
 	// we can't know on the calling end whether a Context is actually a nil value without reflection, so we're storing this flag here to tell us. There has got to be a better way around this.
 	hasParent  bool
 	WindowName string
+	// RangeSelectEnabled lets you select a range of items, for acting on them
+	// all at once
+	RangeSelectEnabled bool
 }
 
 type ListItem interface {
@@ -89,8 +92,18 @@ func (lc *ListContext) OnRender() error {
 	}
 
 	if lc.GetDisplayStrings != nil {
-		lc.Gui.refreshSelectedLine(lc.GetPanelState(), lc.GetItemsLength())
-		lc.Gui.renderDisplayStrings(view, lc.GetDisplayStrings())
+		panelState := lc.GetPanelState()
+		lc.Gui.refreshSelectedLine(panelState, lc.GetItemsLength())
+		displayStrings := lc.GetDisplayStrings()
+		if lc.Gui.isFuzzyFiltering(lc.ContextKey) {
+			lc.Gui.highlightFuzzyMatches(displayStrings)
+		}
+		if panelState.IsSelectingRange() {
+			start, end := panelState.GetSelectedRange()
+			lc.Gui.renderDisplayStringsWithRange(view, displayStrings, start, end)
+		} else {
+			lc.Gui.renderDisplayStrings(view, displayStrings)
+		}
 	}
 
 	return nil
@@ -118,6 +131,10 @@ func (lc *ListContext) HandleFocusLost() error {
 
 func (lc *ListContext) HandleFocus() error {
 	if lc.Gui.popupPanelFocused() {
+		// a popup like the menu can still show a preview of its selected item
+		if lc.Gui.isPopupPanel(lc.ViewName) && lc.OnFocus != nil {
+			return lc.OnFocus()
+		}
 		return nil
 	}
 
@@ -164,6 +181,12 @@ func (lc *ListContext) handleLineChange(change int) error {
 	lc.Gui.changeSelectedLine(lc.GetPanelState(), lc.GetItemsLength(), change)
 	view.FocusPoint(0, lc.GetPanelState().GetSelectedLineIdx())
 
+	if lc.GetPanelState().IsSelectingRange() {
+		if err := lc.HandleRender(); err != nil {
+			return err
+		}
+	}
+
 	if lc.ResetMainViewOriginOnFocus {
 		if err := lc.Gui.resetOrigin(lc.Gui.getMainView()); err != nil {
 			return err
@@ -229,6 +252,12 @@ func (lc *ListContext) handleClick(g *gocui.Gui, v *gocui.View) error {
 
 	lc.GetPanelState().SetSelectedLineIdx(newSelectedLineIdx)
 
+	if lc.GetPanelState().IsSelectingRange() {
+		if err := lc.HandleRender(); err != nil {
+			return err
+		}
+	}
+
 	prevViewName := lc.Gui.currentViewName()
 	if prevSelectedLineIdx == newSelectedLineIdx && prevViewName == lc.ViewName && lc.OnClickSelectedItem != nil {
 		return lc.OnClickSelectedItem()
@@ -236,6 +265,25 @@ func (lc *ListContext) handleClick(g *gocui.Gui, v *gocui.View) error {
 	return lc.HandleFocus()
 }
 
+func (lc *ListContext) handleToggleRangeSelect(g *gocui.Gui, v *gocui.View) error {
+	if lc.Gui.popupPanelFocused() {
+		return nil
+	}
+
+	lc.GetPanelState().ToggleRangeSelect()
+	return lc.HandleRender()
+}
+
+// cancelRangeSelect stops selecting a range, returning false if we weren't
+func (lc *ListContext) cancelRangeSelect() (bool, error) {
+	if !lc.GetPanelState().IsSelectingRange() {
+		return false, nil
+	}
+
+	lc.GetPanelState().CancelRangeSelect()
+	return true, lc.HandleRender()
+}
+
 func (lc *ListContext) onSearchSelect(selectedLineIdx int) error {
 	lc.GetPanelState().SetSelectedLineIdx(selectedLineIdx)
 	return lc.HandleFocus()
@@ -262,18 +310,40 @@ func (gui *Gui) filesListContext() *ListContext {
 	return &ListContext{
 		ViewName:                   "files",
 		ContextKey:                 FILES_CONTEXT_KEY,
-		GetItemsLength:             func() int { return len(gui.State.Files) },
+		GetItemsLength:             func() int { return gui.State.FileManager.GetItemsLength() },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.Files },
 		OnFocus:                    gui.focusAndSelectFile,
 		OnClickSelectedItem:        gui.handleFilePress,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: false,
 		Kind:                       SIDE_CONTEXT,
+		RangeSelectEnabled:         true,
+		GetDisplayStrings: func() [][]string {
+			return presentation.GetFileListDisplayStrings(gui.State.FileManager, gui.State.Modes.Diffing.Ref, gui.State.SubmoduleConfigs)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedFileNode()
+			return item, item != nil
+		},
+	}
+}
+
+func (gui *Gui) submodulesListContext() *ListContext {
+	return &ListContext{
+		ViewName:                   "files",
+		ContextKey:                 SUBMODULES_CONTEXT_KEY,
+		GetItemsLength:             func() int { return len(gui.State.Submodules) },
+		GetPanelState:              func() IListPanelState { return gui.State.Panels.Submodules },
+		OnFocus:                    gui.handleSubmoduleSelect,
+		OnClickSelectedItem:        gui.handleEnterSubmodule,
+		Gui:                        gui,
+		ResetMainViewOriginOnFocus: true,
+		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetFileListDisplayStrings(gui.State.Files, gui.State.Modes.Diffing.Ref, gui.State.SubmoduleConfigs)
+			return presentation.GetSubmoduleListDisplayStrings(gui.State.Submodules)
 		},
 		SelectedItem: func() (ListItem, bool) {
-			item := gui.getSelectedFile()
+			item := gui.getSelectedSubmodule()
 			return item, item != nil
 		},
 	}
@@ -289,8 +359,9 @@ func (gui *Gui) branchesListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
+		RangeSelectEnabled:         true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
+			return presentation.GetBranchListDisplayStrings(gui.State.Branches, gui.State.PullRequests, gui.State.ScreenMode != SCREEN_NORMAL, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedBranch()
@@ -299,6 +370,27 @@ func (gui *Gui) branchesListContext() *ListContext {
 	}
 }
 
+func (gui *Gui) worktreesListContext() *ListContext {
+	return &ListContext{
+		ViewName:                   "branches",
+		ContextKey:                 WORKTREES_CONTEXT_KEY,
+		GetItemsLength:             func() int { return len(gui.State.Worktrees) },
+		GetPanelState:              func() IListPanelState { return gui.State.Panels.Worktrees },
+		OnFocus:                    gui.handleWorktreeSelect,
+		OnClickSelectedItem:        gui.handleSwitchToWorktree,
+		Gui:                        gui,
+		ResetMainViewOriginOnFocus: true,
+		Kind:                       SIDE_CONTEXT,
+		GetDisplayStrings: func() [][]string {
+			return presentation.GetWorktreeListDisplayStrings(gui.State.Worktrees)
+		},
+		SelectedItem: func() (ListItem, bool) {
+			item := gui.getSelectedWorktree()
+			return item, item != nil
+		},
+	}
+}
+
 func (gui *Gui) remotesListContext() *ListContext {
 	return &ListContext{
 		ViewName:                   "branches",
@@ -350,6 +442,7 @@ func (gui *Gui) tagsListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
+		RangeSelectEnabled:         true,
 		GetDisplayStrings: func() [][]string {
 			return presentation.GetTagListDisplayStrings(gui.State.Tags, gui.State.Modes.Diffing.Ref)
 		},
@@ -371,8 +464,9 @@ func (gui *Gui) branchCommitsListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
+		RangeSelectEnabled:         true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref)
+			return presentation.GetCommitListDisplayStrings(gui.State.Commits, gui.commitGraphLines(), gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref, gui.State.Modes.Bisecting.Info)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedLocalCommit()
@@ -412,7 +506,7 @@ func (gui *Gui) subCommitsListContext() *ListContext {
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitListDisplayStrings(gui.State.SubCommits, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref)
+			return presentation.GetCommitListDisplayStrings(gui.State.SubCommits, nil, gui.State.ScreenMode != SCREEN_NORMAL, gui.cherryPickedCommitShaMap(), gui.State.Modes.Diffing.Ref, gui.State.Modes.Bisecting.Info)
 		},
 		SelectedItem: func() (ListItem, bool) {
 			item := gui.getSelectedSubCommit()
@@ -431,6 +525,7 @@ func (gui *Gui) stashListContext() *ListContext {
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
+		RangeSelectEnabled:         true,
 		GetDisplayStrings: func() [][]string {
 			return presentation.GetStashEntryListDisplayStrings(gui.State.StashEntries, gui.State.Modes.Diffing.Ref)
 		},
@@ -446,17 +541,18 @@ func (gui *Gui) commitFilesListContext() *ListContext {
 		ViewName:                   "commitFiles",
 		WindowName:                 "commits",
 		ContextKey:                 COMMIT_FILES_CONTEXT_KEY,
-		GetItemsLength:             func() int { return len(gui.State.CommitFiles) },
+		GetItemsLength:             func() int { return gui.State.CommitFileManager.GetItemsLength() },
 		GetPanelState:              func() IListPanelState { return gui.State.Panels.CommitFiles },
 		OnFocus:                    gui.handleCommitFileSelect,
 		Gui:                        gui,
 		ResetMainViewOriginOnFocus: true,
 		Kind:                       SIDE_CONTEXT,
+		RangeSelectEnabled:         true,
 		GetDisplayStrings: func() [][]string {
-			return presentation.GetCommitFileListDisplayStrings(gui.State.CommitFiles, gui.State.Modes.Diffing.Ref)
+			return presentation.GetCommitFileListDisplayStrings(gui.State.CommitFileManager, gui.State.Modes.Diffing.Ref)
 		},
 		SelectedItem: func() (ListItem, bool) {
-			item := gui.getSelectedCommitFile()
+			item := gui.getSelectedCommitFileNode()
 			return item, item != nil
 		},
 	}
@@ -466,7 +562,9 @@ func (gui *Gui) getListContexts() []*ListContext {
 	return []*ListContext{
 		gui.menuListContext(),
 		gui.filesListContext(),
+		gui.submodulesListContext(),
 		gui.branchesListContext(),
+		gui.worktreesListContext(),
 		gui.remotesListContext(),
 		gui.remoteBranchesListContext(),
 		gui.tagsListContext(),
@@ -495,6 +593,16 @@ func (gui *Gui) getListContextKeyBindings() []*Binding {
 			{ViewName: listContext.ViewName, Contexts: []string{listContext.ContextKey}, Key: gocui.MouseLeft, Modifier: gocui.ModNone, Handler: listContext.handleClick},
 		}...)
 
+		if listContext.RangeSelectEnabled {
+			bindings = append(bindings, &Binding{
+				ViewName:    listContext.ViewName,
+				Contexts:    []string{listContext.ContextKey},
+				Key:         gui.getKey("universal.toggleRangeSelect"),
+				Handler:     listContext.handleToggleRangeSelect,
+				Description: gui.Tr.SLocalize("toggleRangeSelect"),
+			})
+		}
+
 		// the commits panel needs to lazyload things so it has a couple of its own handlers
 		openSearchHandler := gui.handleOpenSearch
 		gotoBottomHandler := listContext.handleGotoBottom
@@ -511,6 +619,19 @@ func (gui *Gui) getListContextKeyBindings() []*Binding {
 				Handler:     openSearchHandler,
 				Description: gui.Tr.SLocalize("startSearch"),
 			},
+		}...)
+
+		if gui.isFuzzyFilterable(listContext.ContextKey) {
+			bindings = append(bindings, &Binding{
+				ViewName:    listContext.ViewName,
+				Contexts:    []string{listContext.ContextKey},
+				Key:         gui.getKey("universal.startFuzzyFilter"),
+				Handler:     gui.handleOpenFuzzyFilter,
+				Description: gui.Tr.SLocalize("startFuzzyFilter"),
+			})
+		}
+
+		bindings = append(bindings, []*Binding{
 			{
 				ViewName:    listContext.ViewName,
 				Contexts:    []string{listContext.ContextKey},
@@ -30,6 +30,7 @@ const (
 	RENDER_STRING_WITHOUT_SCROLL
 	RUN_FUNCTION
 	RUN_COMMAND
+	RUN_FORMATTED_COMMAND
 	RUN_PTY
 )
 
@@ -73,6 +74,23 @@ func (gui *Gui) createRunCommandTask(cmd *exec.Cmd) *runCommandTask {
 	return &runCommandTask{cmd: cmd}
 }
 
+type runFormattedCommandTask struct {
+	cmd *exec.Cmd
+	// format is given each line of the command's output and returns what should
+	// be rendered in its place, or false if nothing should be rendered
+	format func(string) (string, bool)
+	// flush is optional, and returns any lines the format function held back
+	flush func() (string, bool)
+}
+
+func (t *runFormattedCommandTask) GetKind() int {
+	return RUN_FORMATTED_COMMAND
+}
+
+func (gui *Gui) createRunFormattedCommandTask(cmd *exec.Cmd, format func(string) (string, bool)) *runFormattedCommandTask {
+	return &runFormattedCommandTask{cmd: cmd, format: format}
+}
+
 type runPtyTask struct {
 	cmd *exec.Cmd
 }
@@ -115,6 +133,10 @@ func (gui *Gui) runTaskForView(viewName string, task updateTask) error {
 		specificTask := task.(*runCommandTask)
 		return gui.newCmdTask(viewName, specificTask.cmd)
 
+	case RUN_FORMATTED_COMMAND:
+		specificTask := task.(*runFormattedCommandTask)
+		return gui.newFormattedCmdTask(viewName, specificTask.cmd, specificTask.format, specificTask.flush)
+
 	case RUN_PTY:
 		specificTask := task.(*runPtyTask)
 		return gui.newPtyTask(viewName, specificTask.cmd)
@@ -13,6 +13,9 @@ type menuItem struct {
 	displayString  string
 	displayStrings []string
 	onPress        func() error
+	// onSelect is optional, and is called when the item is selected, e.g. for
+	// showing a preview in the main view
+	onSelect func() error
 }
 
 // every item in a list context needs an ID
@@ -27,7 +30,16 @@ func (i *menuItem) ID() string {
 // list panel functions
 
 func (gui *Gui) handleMenuSelect() error {
-	return nil
+	selectedLine := gui.State.Panels.Menu.SelectedLineIdx
+	if selectedLine < 0 || selectedLine >= len(gui.State.MenuItems) {
+		return nil
+	}
+
+	item := gui.State.MenuItems[selectedLine]
+	if item.onSelect == nil {
+		return nil
+	}
+	return item.onSelect()
 }
 
 // specific functions
@@ -0,0 +1,410 @@
+package gui
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/gui/mergeconflicts"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// The merge editor resolves one conflict at a time, showing ours in the main
+// view, theirs in the secondary view and the result in the mergeResult view.
+// The main view keeps focus throughout: moving between ours and theirs just
+// changes which of the two views we highlight a line in.
+
+func (gui *Gui) mergeEditorOpen() bool {
+	return gui.State.Panels.MergeEditor.File != nil
+}
+
+func (gui *Gui) handleOpenMergeEditor() error {
+	file := gui.getSelectedFile()
+	if file == nil {
+		return nil
+	}
+
+	if !file.HasInlineMergeConflicts {
+		return gui.createErrorPanel(gui.Tr.SLocalize("FileNoMergeCons"))
+	}
+
+	if err := gui.loadMergeEditorFile(file.Name, 0); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.switchContext(gui.Contexts.MergeEditor.Context)
+}
+
+// loadMergeEditorFile takes a conflictIndex of -1 to mean the last conflict
+func (gui *Gui) loadMergeEditorFile(path string, conflictIndex int) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	file := mergeconflicts.NewFile(string(content))
+	if len(file.Conflicts) == 0 {
+		return errors.New(gui.Tr.SLocalize("FileNoMergeCons"))
+	}
+
+	state := gui.State.Panels.MergeEditor
+	state.Path = path
+	state.File = file
+	state.ConflictIndex = conflictIndex
+	if conflictIndex == -1 || conflictIndex >= len(file.Conflicts) {
+		state.ConflictIndex = len(file.Conflicts) - 1
+	}
+	gui.resetMergeEditorResolution()
+
+	return nil
+}
+
+func (gui *Gui) resetMergeEditorResolution() {
+	state := gui.State.Panels.MergeEditor
+	state.Resolution = mergeconflicts.NewResolution(
+		state.File.Lines(state.ConflictIndex, commands.CONFLICT_OURS),
+		state.File.Lines(state.ConflictIndex, commands.CONFLICT_THEIRS),
+	)
+	state.SelectedLineIdx = 0
+}
+
+func (gui *Gui) refreshMergeEditor() error {
+	state := gui.State.Panels.MergeEditor
+	if !gui.mergeEditorOpen() {
+		return nil
+	}
+
+	sideViewOpts := func(side int) *viewUpdateOpts {
+		view := gui.getMainView()
+		if side == commands.CONFLICT_THEIRS {
+			view = gui.getSecondaryView()
+		}
+		focused := side == state.Side
+		content := gui.mergeEditorSideString(side)
+		selectedLineIdx := state.SelectedLineIdx
+
+		title := gui.Tr.SLocalize("MergeEditorOurs")
+		if side == commands.CONFLICT_THEIRS {
+			title = gui.Tr.SLocalize("MergeEditorTheirs")
+		}
+		if label := state.File.Label(state.ConflictIndex, side); label != "" {
+			title += " (" + label + ")"
+		}
+
+		return &viewUpdateOpts{
+			title:     title,
+			noWrap:    true,
+			highlight: focused,
+			task: gui.createRunFunctionTask(func(stop chan struct{}) error {
+				gui.g.Update(func(*gocui.Gui) error {
+					gui.setViewContent(view, content)
+					if focused {
+						view.FocusPoint(0, selectedLineIdx)
+					}
+					return nil
+				})
+				return nil
+			}),
+		}
+	}
+
+	if err := gui.refreshMainViews(refreshMainOpts{
+		main:      sideViewOpts(commands.CONFLICT_OURS),
+		secondary: sideViewOpts(commands.CONFLICT_THEIRS),
+	}); err != nil {
+		return err
+	}
+
+	return gui.renderMergeResult()
+}
+
+// mergeEditorSideString shows the lines of ours or theirs, with the lines
+// picked for the result in green
+func (gui *Gui) mergeEditorSideString(side int) string {
+	resolution := gui.State.Panels.MergeEditor.Resolution
+	lines := resolution.Lines(side)
+	if len(lines) == 0 {
+		return utils.ColoredString(gui.Tr.SLocalize("MergeEditorNoLines"), color.FgBlue)
+	}
+
+	output := make([]string, len(lines))
+	for i, line := range lines {
+		if resolution.IsPicked(side, i) {
+			output[i] = utils.ColoredString("+ "+line, color.FgGreen)
+		} else {
+			output[i] = utils.ColoredString("  "+line, theme.DefaultTextColor)
+		}
+	}
+	return strings.Join(output, "\n")
+}
+
+func (gui *Gui) renderMergeResult() error {
+	state := gui.State.Panels.MergeEditor
+
+	view, err := gui.g.View("mergeResult")
+	if err != nil {
+		return nil
+	}
+
+	titleKey := "MergeEditorResultTitle"
+	if state.Resolution.Edited() {
+		titleKey = "MergeEditorEditedResultTitle"
+	}
+	view.Title = gui.Tr.TemplateLocalize(titleKey, Teml{
+		"conflict":  fmt.Sprintf("%d/%d", state.ConflictIndex+1, len(state.File.Conflicts)),
+		"path":      state.Path,
+		"fileIndex": fmt.Sprintf("%d/%d", gui.conflictedFileIndex(state.Path)+1, len(gui.conflictedFilePaths())),
+	})
+	_, _ = gui.g.SetViewOnTop("mergeResult")
+
+	// the result view is where the user edits the result, so it stays uncoloured
+	gui.renderString("mergeResult", strings.Join(state.Resolution.Result(), "\n"))
+
+	return nil
+}
+
+// conflictedFilePaths are the files we can jump between in the merge editor
+func (gui *Gui) conflictedFilePaths() []string {
+	paths := []string{}
+	for _, file := range gui.State.FileManager.GetAllFiles() {
+		if file.HasInlineMergeConflicts {
+			paths = append(paths, file.Name)
+		}
+	}
+	return paths
+}
+
+func (gui *Gui) conflictedFileIndex(path string) int {
+	for i, conflictedPath := range gui.conflictedFilePaths() {
+		if conflictedPath == path {
+			return i
+		}
+	}
+	return -1
+}
+
+func (gui *Gui) handleMergeEditorPrevLine(g *gocui.Gui, v *gocui.View) error {
+	return gui.mergeEditorCycleLine(-1)
+}
+
+func (gui *Gui) handleMergeEditorNextLine(g *gocui.Gui, v *gocui.View) error {
+	return gui.mergeEditorCycleLine(1)
+}
+
+func (gui *Gui) mergeEditorCycleLine(change int) error {
+	state := gui.State.Panels.MergeEditor
+
+	lineCount := len(state.Resolution.Lines(state.Side))
+	if lineCount == 0 {
+		return nil
+	}
+	newSelectedLineIdx := state.SelectedLineIdx + change
+	if newSelectedLineIdx < 0 || newSelectedLineIdx >= lineCount {
+		return nil
+	}
+	state.SelectedLineIdx = newSelectedLineIdx
+
+	return gui.refreshMergeEditor()
+}
+
+func (gui *Gui) handleMergeEditorSelectOurs(g *gocui.Gui, v *gocui.View) error {
+	return gui.mergeEditorSelectSide(commands.CONFLICT_OURS)
+}
+
+func (gui *Gui) handleMergeEditorSelectTheirs(g *gocui.Gui, v *gocui.View) error {
+	return gui.mergeEditorSelectSide(commands.CONFLICT_THEIRS)
+}
+
+func (gui *Gui) handleMergeEditorToggleSide(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.Panels.MergeEditor.Side == commands.CONFLICT_OURS {
+		return gui.mergeEditorSelectSide(commands.CONFLICT_THEIRS)
+	}
+	return gui.mergeEditorSelectSide(commands.CONFLICT_OURS)
+}
+
+func (gui *Gui) mergeEditorSelectSide(side int) error {
+	state := gui.State.Panels.MergeEditor
+	state.Side = side
+
+	lineCount := len(state.Resolution.Lines(side))
+	if state.SelectedLineIdx >= lineCount && lineCount > 0 {
+		state.SelectedLineIdx = lineCount - 1
+	}
+
+	return gui.refreshMergeEditor()
+}
+
+func (gui *Gui) handleMergeEditorPickLine(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.MergeEditor
+	state.Resolution.ToggleLine(state.Side, state.SelectedLineIdx)
+
+	return gui.refreshMergeEditor()
+}
+
+func (gui *Gui) handleMergeEditorPickSide(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.MergeEditor
+	state.Resolution.ToggleSide(state.Side)
+
+	return gui.refreshMergeEditor()
+}
+
+func (gui *Gui) handleMergeEditorEditResult(g *gocui.Gui, v *gocui.View) error {
+	return gui.switchContext(gui.Contexts.MergeResult.Context)
+}
+
+// handleMergeResultFocused puts the cursor at the end of the result, ready for typing
+func (gui *Gui) handleMergeResultFocused() error {
+	view, err := gui.g.View("mergeResult")
+	if err != nil {
+		return nil
+	}
+
+	lines := gui.State.Panels.MergeEditor.Resolution.Result()
+	gui.g.Update(func(*gocui.Gui) error {
+		gui.clearEditorView(view)
+		gui.setViewContent(view, strings.Join(lines, "\n"))
+		if len(lines) > 0 {
+			view.FocusPoint(0, len(lines)-1)
+			_, cy := view.Cursor()
+			return view.SetCursor(len(lines[len(lines)-1]), cy)
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// mergeResultEditor is gocui's default editor, except that enter starts a new
+// line rather than tab
+func (gui *Gui) mergeResultEditor(v *gocui.View, key gocui.Key, ch rune, mod gocui.Modifier) {
+	if key == gocui.KeyEnter {
+		v.EditNewLine()
+		return
+	}
+	gocui.DefaultEditor.Edit(v, key, ch, mod)
+}
+
+func (gui *Gui) handleMergeResultDone(g *gocui.Gui, v *gocui.View) error {
+	gui.State.Panels.MergeEditor.Resolution.SetResult(utils.SplitLines(v.Buffer()))
+
+	return gui.returnFromContext()
+}
+
+func (gui *Gui) handleMergeEditorPrevConflict(g *gocui.Gui, v *gocui.View) error {
+	return gui.mergeEditorCycleConflict(-1)
+}
+
+func (gui *Gui) handleMergeEditorNextConflict(g *gocui.Gui, v *gocui.View) error {
+	return gui.mergeEditorCycleConflict(1)
+}
+
+// mergeEditorCycleConflict moves to the previous or next conflict, moving onto
+// the previous or next conflicted file once we're past the ends of this one
+func (gui *Gui) mergeEditorCycleConflict(change int) error {
+	state := gui.State.Panels.MergeEditor
+
+	newConflictIndex := state.ConflictIndex + change
+	if newConflictIndex >= 0 && newConflictIndex < len(state.File.Conflicts) {
+		state.ConflictIndex = newConflictIndex
+		gui.resetMergeEditorResolution()
+		return gui.refreshMergeEditor()
+	}
+
+	paths := gui.conflictedFilePaths()
+	fileIndex := gui.conflictedFileIndex(state.Path)
+	var path string
+	var conflictIndex int
+	if change > 0 {
+		if fileIndex+1 >= len(paths) {
+			return nil
+		}
+		path = paths[fileIndex+1]
+		conflictIndex = 0
+	} else {
+		if fileIndex <= 0 {
+			return nil
+		}
+		path = paths[fileIndex-1]
+		conflictIndex = -1
+	}
+
+	if err := gui.loadMergeEditorFile(path, conflictIndex); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshMergeEditor()
+}
+
+// handleMergeEditorApply writes the result in place of the conflict. Once a
+// file has no conflicts left we stage it and move onto the next conflicted file
+func (gui *Gui) handleMergeEditorApply(g *gocui.Gui, v *gocui.View) error {
+	state := gui.State.Panels.MergeEditor
+
+	state.File.Resolve(state.ConflictIndex, state.Resolution.Result())
+	if err := ioutil.WriteFile(state.Path, []byte(state.File.Content()), 0644); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if len(state.File.Conflicts) > 0 {
+		if state.ConflictIndex >= len(state.File.Conflicts) {
+			state.ConflictIndex = len(state.File.Conflicts) - 1
+		}
+		gui.resetMergeEditorResolution()
+		return gui.refreshMergeEditor()
+	}
+
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("resolveMergeConflicts")).StageFile(state.Path); err != nil {
+		return gui.surfaceError(err)
+	}
+	if err := gui.refreshSidePanels(refreshOptions{scope: []int{FILES}}); err != nil {
+		return err
+	}
+
+	paths := gui.conflictedFilePaths()
+	if len(paths) > 0 {
+		if err := gui.loadMergeEditorFile(paths[0], 0); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.refreshMergeEditor()
+	}
+
+	if err := gui.closeMergeEditor(); err != nil {
+		return err
+	}
+
+	// if that was the last conflict, we should ask whether the user wants to continue
+	if gui.GitCommand.WorkingTreeState() != "normal" && !gui.anyFilesWithMergeConflicts() {
+		return gui.promptToContinue()
+	}
+	return nil
+}
+
+func (gui *Gui) handleMergeEditorEscape() error {
+	return gui.closeMergeEditor()
+}
+
+func (gui *Gui) closeMergeEditor() error {
+	state := gui.State.Panels.MergeEditor
+	state.File = nil
+	state.Resolution = nil
+
+	_, _ = gui.g.SetViewOnBottom("mergeResult")
+
+	return gui.switchContext(gui.Contexts.Files.Context)
+}
+
+func (gui *Gui) getMergeEditorOptions() map[string]string {
+	return map[string]string{
+		fmt.Sprintf("%s %s", gui.getKeyDisplay("universal.prevBlock"), gui.getKeyDisplay("universal.nextBlock")): gui.Tr.SLocalize("MergeEditorSelectSide"),
+		gui.getKeyDisplay("universal.select"):      gui.Tr.SLocalize("MergeEditorPickLine"),
+		gui.getKeyDisplay("main.toggleSelectHunk"): gui.Tr.SLocalize("MergeEditorPickSide"),
+		gui.getKeyDisplay("universal.edit"):        gui.Tr.SLocalize("MergeEditorEditResult"),
+		gui.getKeyDisplay("universal.confirm"):     gui.Tr.SLocalize("MergeEditorApply"),
+		fmt.Sprintf("%s %s", gui.getKeyDisplay("universal.prevTab"), gui.getKeyDisplay("universal.nextTab")): gui.Tr.SLocalize("navigateConflicts"),
+	}
+}
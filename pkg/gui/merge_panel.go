@@ -9,7 +9,6 @@ import (
 	"io/ioutil"
 	"math"
 	"os"
-	"strings"
 
 	"github.com/fatih/color"
 	"github.com/go-errors/errors"
@@ -20,37 +19,16 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func (gui *Gui) findConflicts(content string) []commands.Conflict {
-	conflicts := make([]commands.Conflict, 0)
-
-	if content == "" {
-		return conflicts
-	}
-
-	var newConflict commands.Conflict
-	for i, line := range utils.SplitLines(content) {
-		trimmedLine := strings.TrimPrefix(line, "++")
-		if trimmedLine == "<<<<<<< HEAD" || trimmedLine == "<<<<<<< MERGE_HEAD" || trimmedLine == "<<<<<<< Updated upstream" || trimmedLine == "<<<<<<< ours" {
-			newConflict = commands.Conflict{Start: i}
-		} else if trimmedLine == "=======" {
-			newConflict.Middle = i
-		} else if strings.HasPrefix(trimmedLine, ">>>>>>> ") {
-			newConflict.End = i
-			conflicts = append(conflicts, newConflict)
-		}
-	}
-	return conflicts
-}
-
 func (gui *Gui) shiftConflict(conflicts []commands.Conflict) (commands.Conflict, []commands.Conflict) {
 	return conflicts[0], conflicts[1:]
 }
 
-func (gui *Gui) shouldHighlightLine(index int, conflict commands.Conflict, top bool) bool {
-	return (index >= conflict.Start && index <= conflict.Middle && top) || (index >= conflict.Middle && index <= conflict.End && !top)
+func (gui *Gui) shouldHighlightLine(index int, conflict commands.Conflict, section int) bool {
+	start, end := conflict.SectionBounds(section)
+	return index >= start && index <= end
 }
 
-func (gui *Gui) coloredConflictFile(content string, conflicts []commands.Conflict, conflictIndex int, conflictTop, hasFocus bool) (string, error) {
+func (gui *Gui) coloredConflictFile(content string, conflicts []commands.Conflict, conflictIndex int, conflictSection int, hasFocus bool) (string, error) {
 	if len(conflicts) == 0 {
 		return content, nil
 	}
@@ -58,11 +36,13 @@ func (gui *Gui) coloredConflictFile(content string, conflicts []commands.Conflic
 	var outputBuffer bytes.Buffer
 	for i, line := range utils.SplitLines(content) {
 		colourAttr := theme.DefaultTextColor
-		if i == conflict.Start || i == conflict.Middle || i == conflict.End {
+		if conflict.IsMarker(i) {
 			colourAttr = color.FgRed
+		} else if conflict.SectionOfLine(i) == commands.CONFLICT_BASE {
+			colourAttr = color.FgCyan
 		}
 		colour := color.New(colourAttr)
-		if hasFocus && conflictIndex < len(conflicts) && conflicts[conflictIndex] == conflict && gui.shouldHighlightLine(i, conflict, conflictTop) {
+		if hasFocus && conflictIndex < len(conflicts) && conflicts[conflictIndex] == conflict && gui.shouldHighlightLine(i, conflict, conflictSection) {
 			colour.Add(color.Bold)
 			colour.Add(theme.SelectedRangeBgColor)
 		}
@@ -78,16 +58,36 @@ func (gui *Gui) takeOverScrolling() {
 	gui.State.Panels.Merging.UserScrolling = false
 }
 
-func (gui *Gui) handleSelectTop(g *gocui.Gui, v *gocui.View) error {
+// selectSection moves the selection up or down through the ours, base and
+// theirs sections of the current conflict
+func (gui *Gui) selectSection(change int) error {
 	gui.takeOverScrolling()
-	gui.State.Panels.Merging.ConflictTop = true
+	panelState := gui.State.Panels.Merging
+	if len(panelState.Conflicts) == 0 {
+		return nil
+	}
+
+	sections := panelState.Conflicts[panelState.ConflictIndex].Sections()
+	index := 0
+	for i, section := range sections {
+		if section == panelState.ConflictSection {
+			index = i
+		}
+	}
+	index += change
+	if index < 0 || index >= len(sections) {
+		return nil
+	}
+	panelState.ConflictSection = sections[index]
 	return gui.refreshMergePanel()
 }
 
-func (gui *Gui) handleSelectBottom(g *gocui.Gui, v *gocui.View) error {
-	gui.takeOverScrolling()
-	gui.State.Panels.Merging.ConflictTop = false
-	return gui.refreshMergePanel()
+func (gui *Gui) handleSelectPrevSection(g *gocui.Gui, v *gocui.View) error {
+	return gui.selectSection(-1)
+}
+
+func (gui *Gui) handleSelectNextSection(g *gocui.Gui, v *gocui.View) error {
+	return gui.selectSection(1)
 }
 
 func (gui *Gui) handleSelectNextConflict(g *gocui.Gui, v *gocui.View) error {
@@ -108,16 +108,7 @@ func (gui *Gui) handleSelectPrevConflict(g *gocui.Gui, v *gocui.View) error {
 	return gui.refreshMergePanel()
 }
 
-func (gui *Gui) isIndexToDelete(i int, conflict commands.Conflict, pick string) bool {
-	return i == conflict.Middle ||
-		i == conflict.Start ||
-		i == conflict.End ||
-		pick != "both" &&
-			(pick == "bottom" && i > conflict.Start && i < conflict.Middle) ||
-		(pick == "top" && i > conflict.Middle && i < conflict.End)
-}
-
-func (gui *Gui) resolveConflict(conflict commands.Conflict, pick string) error {
+func (gui *Gui) resolveConflict(conflict commands.Conflict, pick int) error {
 	gitFile := gui.getSelectedFile()
 	if gitFile == nil {
 		return nil
@@ -135,7 +126,7 @@ func (gui *Gui) resolveConflict(conflict commands.Conflict, pick string) error {
 		if err != nil {
 			break
 		}
-		if !gui.isIndexToDelete(i, conflict, pick) {
+		if !conflict.IsLineToRemove(i, pick) {
 			output += line
 		}
 	}
@@ -172,24 +163,31 @@ func (gui *Gui) handlePopFileSnapshot(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handlePickHunk(g *gocui.Gui, v *gocui.View) error {
+	return gui.pickConflictSection(gui.State.Panels.Merging.ConflictSection)
+}
+
+func (gui *Gui) handlePickAllHunks(g *gocui.Gui, v *gocui.View) error {
+	return gui.pickConflictSection(commands.CONFLICT_ALL)
+}
+
+func (gui *Gui) pickConflictSection(pick int) error {
 	gui.takeOverScrolling()
 
-	conflict := gui.State.Panels.Merging.Conflicts[gui.State.Panels.Merging.ConflictIndex]
-	if err := gui.pushFileSnapshot(g); err != nil {
+	panelState := gui.State.Panels.Merging
+	if len(panelState.Conflicts) == 0 {
+		return nil
+	}
+	conflict := panelState.Conflicts[panelState.ConflictIndex]
+	if err := gui.pushFileSnapshot(gui.g); err != nil {
 		return err
 	}
 
-	pick := "bottom"
-	if gui.State.Panels.Merging.ConflictTop {
-		pick = "top"
-	}
-	err := gui.resolveConflict(conflict, pick)
-	if err != nil {
-		panic(err)
+	if err := gui.resolveConflict(conflict, pick); err != nil {
+		return gui.surfaceError(err)
 	}
 
 	// if that was the last conflict, finish the merge for this file
-	if len(gui.State.Panels.Merging.Conflicts) == 1 {
+	if len(panelState.Conflicts) == 1 {
 		if err := gui.handleCompleteMerge(); err != nil {
 			return err
 		}
@@ -197,20 +195,6 @@ func (gui *Gui) handlePickHunk(g *gocui.Gui, v *gocui.View) error {
 	return gui.refreshMergePanel()
 }
 
-func (gui *Gui) handlePickBothHunks(g *gocui.Gui, v *gocui.View) error {
-	gui.takeOverScrolling()
-
-	conflict := gui.State.Panels.Merging.Conflicts[gui.State.Panels.Merging.ConflictIndex]
-	if err := gui.pushFileSnapshot(g); err != nil {
-		return err
-	}
-	err := gui.resolveConflict(conflict, "both")
-	if err != nil {
-		panic(err)
-	}
-	return gui.refreshMergePanel()
-}
-
 func (gui *Gui) refreshMergePanel() error {
 	panelState := gui.State.Panels.Merging
 	cat, err := gui.catSelectedFile(gui.g)
@@ -223,7 +207,7 @@ func (gui *Gui) refreshMergePanel() error {
 		})
 	}
 
-	panelState.Conflicts = gui.findConflicts(cat)
+	panelState.Conflicts = commands.FindConflicts(cat)
 
 	// handle potential fixes that the user made in their editor since we last refreshed
 	if len(panelState.Conflicts) == 0 {
@@ -231,9 +215,12 @@ func (gui *Gui) refreshMergePanel() error {
 	} else if panelState.ConflictIndex > len(panelState.Conflicts)-1 {
 		panelState.ConflictIndex = len(panelState.Conflicts) - 1
 	}
+	if panelState.ConflictSection == commands.CONFLICT_BASE && !panelState.Conflicts[panelState.ConflictIndex].HasAncestor() {
+		panelState.ConflictSection = commands.CONFLICT_OURS
+	}
 
 	hasFocus := gui.currentViewName() == "main"
-	content, err := gui.coloredConflictFile(cat, panelState.Conflicts, panelState.ConflictIndex, panelState.ConflictTop, hasFocus)
+	content, err := gui.coloredConflictFile(cat, panelState.Conflicts, panelState.ConflictIndex, panelState.ConflictSection, hasFocus)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,85 @@
+package mergeconflicts
+
+import (
+	"strings"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+)
+
+// File is the content of a file with merge conflicts. We hold onto each line's
+// line ending so that resolving a conflict leaves the rest of the file as it was
+type File struct {
+	lines     []string
+	Conflicts []commands.Conflict
+}
+
+func NewFile(content string) *File {
+	f := &File{}
+	f.setContent(content)
+	return f
+}
+
+func (f *File) setContent(content string) {
+	f.lines = strings.SplitAfter(content, "\n")
+	if f.lines[len(f.lines)-1] == "" {
+		f.lines = f.lines[:len(f.lines)-1]
+	}
+	f.Conflicts = commands.FindConflicts(content)
+}
+
+// Content is what we write back to disk
+func (f *File) Content() string {
+	return strings.Join(f.lines, "")
+}
+
+// Lines returns the lines of one section of a conflict, without line endings
+func (f *File) Lines(index int, section int) []string {
+	start, end := f.Conflicts[index].SectionBounds(section)
+	result := make([]string, 0, end-start-1)
+	for _, line := range f.lines[start+1 : end] {
+		result = append(result, trimLineEnding(line))
+	}
+	return result
+}
+
+// Label returns whatever follows the marker opening a section of a conflict,
+// e.g. HEAD, a branch name, or a sha and commit subject
+func (f *File) Label(index int, section int) string {
+	conflict := f.Conflicts[index]
+	markerLine := conflict.Start
+	switch section {
+	case commands.CONFLICT_BASE:
+		markerLine = conflict.Ancestor
+	case commands.CONFLICT_THEIRS:
+		markerLine = conflict.End
+	}
+
+	line := strings.TrimPrefix(trimLineEnding(f.lines[markerLine]), "++")
+	if len(line) <= len("<<<<<<< ") {
+		return ""
+	}
+	return line[len("<<<<<<< "):]
+}
+
+// Resolve replaces a conflict, markers and all, with the given lines
+func (f *File) Resolve(index int, result []string) {
+	conflict := f.Conflicts[index]
+
+	lineEnding := "\n"
+	if strings.HasSuffix(f.lines[conflict.Start], "\r\n") {
+		lineEnding = "\r\n"
+	}
+
+	newLines := make([]string, 0, len(f.lines))
+	newLines = append(newLines, f.lines[:conflict.Start]...)
+	for _, line := range result {
+		newLines = append(newLines, line+lineEnding)
+	}
+	newLines = append(newLines, f.lines[conflict.End+1:]...)
+
+	f.setContent(strings.Join(newLines, ""))
+}
+
+func trimLineEnding(line string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
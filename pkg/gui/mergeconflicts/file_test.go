@@ -0,0 +1,43 @@
+package mergeconflicts
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFile is a function.
+func TestFile(t *testing.T) {
+	content := "before\n<<<<<<< HEAD\nours\n||||||| base\nbase\n=======\ntheirs 1\ntheirs 2\n>>>>>>> 8d9e0f1 (add the thing)\nmiddle\n<<<<<<< HEAD\na\n=======\nb\n>>>>>>> feature\nafter\n"
+
+	f := NewFile(content)
+	assert.Len(t, f.Conflicts, 2)
+	assert.EqualValues(t, content, f.Content())
+
+	assert.EqualValues(t, []string{"ours"}, f.Lines(0, commands.CONFLICT_OURS))
+	assert.EqualValues(t, []string{"base"}, f.Lines(0, commands.CONFLICT_BASE))
+	assert.EqualValues(t, []string{"theirs 1", "theirs 2"}, f.Lines(0, commands.CONFLICT_THEIRS))
+	assert.EqualValues(t, "HEAD", f.Label(0, commands.CONFLICT_OURS))
+	assert.EqualValues(t, "base", f.Label(0, commands.CONFLICT_BASE))
+	assert.EqualValues(t, "8d9e0f1 (add the thing)", f.Label(0, commands.CONFLICT_THEIRS))
+
+	f.Resolve(0, []string{"ours", "theirs 2"})
+	assert.EqualValues(t, "before\nours\ntheirs 2\nmiddle\n<<<<<<< HEAD\na\n=======\nb\n>>>>>>> feature\nafter\n", f.Content())
+	assert.Len(t, f.Conflicts, 1)
+	assert.EqualValues(t, "feature", f.Label(0, commands.CONFLICT_THEIRS))
+
+	f.Resolve(0, []string{})
+	assert.EqualValues(t, "before\nours\ntheirs 2\nmiddle\nafter\n", f.Content())
+	assert.Len(t, f.Conflicts, 0)
+}
+
+// TestFileKeepsLineEndings is a function.
+func TestFileKeepsLineEndings(t *testing.T) {
+	f := NewFile("a\r\n<<<<<<< HEAD\r\nours\r\n=======\r\ntheirs\r\n>>>>>>> feature\r\nb")
+
+	assert.EqualValues(t, []string{"ours"}, f.Lines(0, commands.CONFLICT_OURS))
+
+	f.Resolve(0, []string{"ours", "theirs"})
+	assert.EqualValues(t, "a\r\nours\r\ntheirs\r\nb", f.Content())
+}
@@ -0,0 +1,86 @@
+package mergeconflicts
+
+import "github.com/jesseduffield/lazygit/pkg/commands"
+
+// Resolution is how the user wants a conflict resolved. They pick individual
+// lines from ours and theirs, with the picked lines of ours coming first in the
+// result. They can also write the result themselves, in which case that's what
+// we go with until they pick another line
+type Resolution struct {
+	lines        map[int][]string
+	picked       map[int][]bool
+	editedResult []string
+	edited       bool
+}
+
+func NewResolution(ours []string, theirs []string) *Resolution {
+	return &Resolution{
+		lines: map[int][]string{
+			commands.CONFLICT_OURS:   ours,
+			commands.CONFLICT_THEIRS: theirs,
+		},
+		picked: map[int][]bool{
+			commands.CONFLICT_OURS:   make([]bool, len(ours)),
+			commands.CONFLICT_THEIRS: make([]bool, len(theirs)),
+		},
+	}
+}
+
+// Lines returns the lines of either commands.CONFLICT_OURS or CONFLICT_THEIRS
+func (r *Resolution) Lines(side int) []string {
+	return r.lines[side]
+}
+
+func (r *Resolution) IsPicked(side int, index int) bool {
+	picked := r.picked[side]
+	return index >= 0 && index < len(picked) && picked[index]
+}
+
+func (r *Resolution) ToggleLine(side int, index int) {
+	picked := r.picked[side]
+	if index < 0 || index >= len(picked) {
+		return
+	}
+	picked[index] = !picked[index]
+	r.edited = false
+}
+
+// ToggleSide picks every line of a side, unless they're all picked already in
+// which case it unpicks them
+func (r *Resolution) ToggleSide(side int) {
+	picked := r.picked[side]
+	allPicked := true
+	for _, isPicked := range picked {
+		allPicked = allPicked && isPicked
+	}
+	for i := range picked {
+		picked[i] = !allPicked
+	}
+	r.edited = false
+}
+
+// SetResult is for when the user has written the result themselves
+func (r *Resolution) SetResult(lines []string) {
+	r.editedResult = lines
+	r.edited = true
+}
+
+func (r *Resolution) Edited() bool {
+	return r.edited
+}
+
+func (r *Resolution) Result() []string {
+	if r.edited {
+		return r.editedResult
+	}
+
+	result := []string{}
+	for _, side := range []int{commands.CONFLICT_OURS, commands.CONFLICT_THEIRS} {
+		for i, line := range r.lines[side] {
+			if r.picked[side][i] {
+				result = append(result, line)
+			}
+		}
+	}
+	return result
+}
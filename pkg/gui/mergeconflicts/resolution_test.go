@@ -0,0 +1,35 @@
+package mergeconflicts
+
+import (
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestResolution is a function.
+func TestResolution(t *testing.T) {
+	r := NewResolution([]string{"o1", "o2"}, []string{"t1", "t2", "t3"})
+	assert.EqualValues(t, []string{}, r.Result())
+
+	r.ToggleLine(commands.CONFLICT_THEIRS, 1)
+	r.ToggleLine(commands.CONFLICT_OURS, 1)
+	assert.True(t, r.IsPicked(commands.CONFLICT_OURS, 1))
+	assert.False(t, r.IsPicked(commands.CONFLICT_OURS, 0))
+	assert.False(t, r.IsPicked(commands.CONFLICT_OURS, 5))
+	assert.EqualValues(t, []string{"o2", "t2"}, r.Result())
+
+	r.ToggleSide(commands.CONFLICT_THEIRS)
+	assert.EqualValues(t, []string{"o2", "t1", "t2", "t3"}, r.Result())
+	r.ToggleSide(commands.CONFLICT_THEIRS)
+	assert.EqualValues(t, []string{"o2"}, r.Result())
+
+	r.SetResult([]string{"my own line"})
+	assert.True(t, r.Edited())
+	assert.EqualValues(t, []string{"my own line"}, r.Result())
+
+	// picking another line goes back to the picked lines
+	r.ToggleLine(commands.CONFLICT_OURS, 0)
+	assert.False(t, r.Edited())
+	assert.EqualValues(t, []string{"o1", "o2"}, r.Result())
+}
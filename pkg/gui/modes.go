@@ -2,6 +2,7 @@ package gui
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -36,6 +37,16 @@ func (gui *Gui) modeStatuses() []modeStatus {
 			},
 			reset: gui.exitFilterMode,
 		},
+		{
+			isActive: gui.State.Modes.CommitSearch.Active,
+			description: func() string {
+				return utils.ColoredString(
+					fmt.Sprintf("%s %s %s", gui.Tr.SLocalize("searchingCommits"), strings.Join(gui.State.Modes.CommitSearch.Options.Args(), " "), utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)),
+					color.FgRed,
+				)
+			},
+			reset: gui.exitCommitSearchMode,
+		},
 		{
 			isActive: gui.GitCommand.PatchManager.Active,
 			description: func() string {
@@ -57,5 +68,30 @@ func (gui *Gui) modeStatuses() []modeStatus {
 			},
 			reset: gui.exitCherryPickingMode,
 		},
+		{
+			isActive: gui.State.Modes.Bisecting.Active,
+			description: func() string {
+				return utils.ColoredString(
+					fmt.Sprintf("%s %s", gui.bisectStatusString(), utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)),
+					color.FgGreen,
+				)
+			},
+			reset: gui.handleResetBisect,
+		},
+		{
+			isActive: gui.State.Modes.RebaseOnto.Active,
+			description: func() string {
+				description := gui.Tr.TemplateLocalize("rebasingOnto", Teml{
+					"upstream":  gui.State.Modes.RebaseOnto.Upstream.ShortSha(),
+					"rebaseKey": gui.getKeyDisplay("branches.rebaseBranch"),
+					"markKey":   gui.getKeyDisplay("commits.markCommitAsUpstream"),
+				})
+				return utils.ColoredString(
+					fmt.Sprintf("%s %s", description, utils.ColoredString(gui.Tr.SLocalize("(reset)"), color.Underline)),
+					color.FgBlue,
+				)
+			},
+			reset: gui.exitRebaseOntoMode,
+		},
 	}
 }
@@ -0,0 +1,94 @@
+package gui
+
+import (
+	"io/ioutil"
+)
+
+// patch files are the patches people send you, e.g. by email. A mailbox made
+// with git format-patch can be applied with git am, which makes a commit for
+// each patch. If a patch doesn't apply, we're left in the middle of an am
+// session, which you continue, skip or abort like a rebase
+
+func (gui *Gui) handleCreateImportPatchesMenu() error {
+	patchFiles, err := gui.GitCommand.GetPatchFiles()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	menuItems := make([]*menuItem, 0, len(patchFiles)+1)
+	for _, path := range patchFiles {
+		path := path
+		menuItems = append(menuItems, &menuItem{
+			displayString: path,
+			onPress: func() error {
+				return gui.createPatchFileOptionsMenu(path)
+			},
+			onSelect: func() error {
+				return gui.previewPatchFile(path)
+			},
+		})
+	}
+
+	menuItems = append(menuItems, &menuItem{
+		displayString: gui.Tr.SLocalize("EnterPatchFilePath"),
+		onPress: func() error {
+			return gui.prompt(gui.Tr.SLocalize("PatchFilePathTitle"), "", func(path string) error {
+				return gui.createPatchFileOptionsMenu(path)
+			})
+		},
+	})
+
+	return gui.createMenu(gui.Tr.SLocalize("ImportPatchesTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) previewPatchFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: path,
+			task:  gui.createRenderStringTask(string(content)),
+		},
+	})
+}
+
+func (gui *Gui) createPatchFileOptionsMenu(path string) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("ApplyMailbox"),
+			onPress:       func() error { return gui.handleApplyMailbox(path, false) },
+		},
+		{
+			displayString: gui.Tr.SLocalize("ApplyMailboxThreeWay"),
+			onPress:       func() error { return gui.handleApplyMailbox(path, true) },
+		},
+		{
+			displayString: gui.Tr.SLocalize("ApplyPatchFileToIndex"),
+			onPress:       func() error { return gui.handleApplyPatchFile(path) },
+		},
+	}
+
+	return gui.createMenu(path, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) handleApplyMailbox(path string, threeWay bool) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("ApplyingPatchesStatus"), func() error {
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("ApplyMailbox")).ApplyMailbox(path, threeWay)
+		return gui.handleGenericMergeCommandResult(err)
+	})
+}
+
+func (gui *Gui) handleApplyPatchFile(path string) error {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("ApplyPatchFileToIndex")).ApplyPatchFile(path); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+}
@@ -8,6 +8,10 @@ import (
 
 func (gui *Gui) handleCreatePatchOptionsMenu(g *gocui.Gui, v *gocui.View) error {
 	if !gui.GitCommand.PatchManager.Active() {
+		// you can still get to your saved patches without building a new one
+		if savedPatches, err := gui.GitCommand.GetSavedPatches(); err == nil && len(savedPatches) > 0 {
+			return gui.handleCreateSavedPatchesMenu()
+		}
 		return gui.createErrorPanel(gui.Tr.SLocalize("NoPatchError"))
 	}
 
@@ -24,6 +28,14 @@ func (gui *Gui) handleCreatePatchOptionsMenu(g *gocui.Gui, v *gocui.View) error
 			displayString: "apply patch in reverse",
 			onPress:       func() error { return gui.handleApplyPatch(true) },
 		},
+		{
+			displayString: "save patch",
+			onPress:       gui.handleSavePatch,
+		},
+		{
+			displayString: "saved patches",
+			onPress:       gui.handleCreateSavedPatchesMenu,
+		},
 	}
 
 	if gui.GitCommand.PatchManager.CanRebase && gui.workingTreeState() == "normal" {
@@ -91,6 +103,9 @@ func (gui *Gui) handleDeletePatchFromCommit() error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return err
+	}
 
 	if err := gui.returnFocusFromLineByLinePanelIfNecessary(); err != nil {
 		return err
@@ -98,7 +113,8 @@ func (gui *Gui) handleDeletePatchFromCommit() error {
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
 		commitIndex := gui.getPatchCommitIndex()
-		err := gui.GitCommand.DeletePatchesFromCommit(gui.State.Commits, commitIndex, gui.GitCommand.PatchManager)
+		gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("RemovePatchFromCommitAction"))
+		err := gitCommand.DeletePatchesFromCommit(gui.State.Commits, commitIndex, gitCommand.PatchManager)
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -107,6 +123,9 @@ func (gui *Gui) handleMovePatchToSelectedCommit() error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return err
+	}
 
 	if err := gui.returnFocusFromLineByLinePanelIfNecessary(); err != nil {
 		return err
@@ -114,7 +133,8 @@ func (gui *Gui) handleMovePatchToSelectedCommit() error {
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
 		commitIndex := gui.getPatchCommitIndex()
-		err := gui.GitCommand.MovePatchToSelectedCommit(gui.State.Commits, commitIndex, gui.State.Panels.Commits.SelectedLineIdx, gui.GitCommand.PatchManager)
+		gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("MovePatchToSelectedCommitAction"))
+		err := gitCommand.MovePatchToSelectedCommit(gui.State.Commits, commitIndex, gui.State.Panels.Commits.SelectedLineIdx, gitCommand.PatchManager)
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -123,6 +143,9 @@ func (gui *Gui) handlePullPatchIntoWorkingTree() error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return err
+	}
 
 	if err := gui.returnFocusFromLineByLinePanelIfNecessary(); err != nil {
 		return err
@@ -131,7 +154,8 @@ func (gui *Gui) handlePullPatchIntoWorkingTree() error {
 	pull := func(stash bool) error {
 		return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
 			commitIndex := gui.getPatchCommitIndex()
-			err := gui.GitCommand.PullPatchIntoIndex(gui.State.Commits, commitIndex, gui.GitCommand.PatchManager, stash)
+			gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("PullPatchIntoIndexAction"))
+			err := gitCommand.PullPatchIntoIndex(gui.State.Commits, commitIndex, gitCommand.PatchManager, stash)
 			return gui.handleGenericMergeCommandResult(err)
 		})
 	}
@@ -153,6 +177,9 @@ func (gui *Gui) handlePullPatchIntoNewCommit() error {
 	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
 		return err
 	}
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return err
+	}
 
 	if err := gui.returnFocusFromLineByLinePanelIfNecessary(); err != nil {
 		return err
@@ -160,7 +187,8 @@ func (gui *Gui) handlePullPatchIntoNewCommit() error {
 
 	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
 		commitIndex := gui.getPatchCommitIndex()
-		err := gui.GitCommand.PullPatchIntoNewCommit(gui.State.Commits, commitIndex, gui.GitCommand.PatchManager)
+		gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("PullPatchIntoNewCommitAction"))
+		err := gitCommand.PullPatchIntoNewCommit(gui.State.Commits, commitIndex, gitCommand.PatchManager)
 		return gui.handleGenericMergeCommandResult(err)
 	})
 }
@@ -170,7 +198,7 @@ func (gui *Gui) handleApplyPatch(reverse bool) error {
 		return err
 	}
 
-	if err := gui.GitCommand.PatchManager.ApplyPatches(reverse); err != nil {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("ApplyPatchAction")).PatchManager.ApplyPatches(reverse); err != nil {
 		return gui.surfaceError(err)
 	}
 	return gui.refreshSidePanels(refreshOptions{mode: ASYNC})
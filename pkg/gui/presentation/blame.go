@@ -0,0 +1,59 @@
+package presentation
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+const blameAuthorWidth = 16
+
+var blameColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+	color.FgRed,
+}
+
+// GetBlameLineDisplayString returns a line of a blamed file, annotated with the
+// commit it came from. Only the first line of each block of lines from the same
+// commit gets the commit's details, but the whole block shares the commit's colour
+// so that you can see where it ends
+func GetBlameLineDisplayString(line *models.BlameLine, startsBlock bool) string {
+	commitColor := blameCommitColor(line.Commit)
+
+	annotation := strings.Repeat(" ", len(line.Commit.ShortSha())+blameAuthorWidth+6)
+	if startsBlock {
+		annotation = fmt.Sprintf(
+			"%s %s %s",
+			line.Commit.ShortSha(),
+			utils.WithPadding(utils.TruncateWithEllipsis(line.Commit.Author, blameAuthorWidth), blameAuthorWidth),
+			utils.WithPadding(utils.UnixToTimeAgo(line.Commit.AuthorTime), 4),
+		)
+	}
+
+	return fmt.Sprintf(
+		"%s %s %4d %s",
+		utils.ColoredString(annotation, commitColor),
+		utils.ColoredString("│", commitColor),
+		line.LineNumber,
+		line.Content,
+	)
+}
+
+func blameCommitColor(commit *models.BlameCommit) color.Attribute {
+	if commit.IsUncommitted() {
+		return theme.DefaultTextColor
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(commit.Sha))
+	return blameColors[hash.Sum32()%uint32(len(blameColors))]
+}
@@ -0,0 +1,78 @@
+package presentation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/oscommands"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// GetCmdLogDisplayString returns the command log, with each action followed by
+// the commands we ran for it. Background actions are left out unless asked for,
+// given that they're mostly us running git status every few seconds
+func GetCmdLogDisplayString(actions []*oscommands.CmdLogAction, showBackground bool, backgroundName string) string {
+	lines := []string{}
+
+	for _, action := range actions {
+		if action.Background && !showBackground {
+			continue
+		}
+
+		name := action.Name
+		nameAttr := color.Bold
+		if action.Background {
+			name = backgroundName
+			nameAttr = color.Faint
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"%s %s",
+			utils.ColoredString(action.Time.Format("15:04:05"), color.FgBlue),
+			utils.ColoredString(capitalise(name), nameAttr),
+		))
+
+		for _, entry := range action.Entries {
+			lines = append(lines, getCmdLogEntryLines(entry)...)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func getCmdLogEntryLines(entry *oscommands.CmdLogEntry) []string {
+	duration := utils.ColoredString(formatDuration(entry.Duration), color.FgBlue)
+
+	if entry.ExitCode == 0 {
+		return []string{fmt.Sprintf("  %s %s", entry.Command, duration)}
+	}
+
+	lines := []string{fmt.Sprintf(
+		"%s %s %s %s",
+		utils.ColoredString("✗", color.FgRed),
+		entry.Command,
+		duration,
+		utils.ColoredString(fmt.Sprintf("(exit %d)", entry.ExitCode), color.FgRed),
+	)}
+	for _, line := range strings.Split(entry.Stderr, "\n") {
+		lines = append(lines, utils.ColoredString("    "+line, color.FgRed))
+	}
+
+	return lines
+}
+
+func formatDuration(duration time.Duration) string {
+	if duration < time.Second {
+		return fmt.Sprintf("%dms", duration.Milliseconds())
+	}
+	return fmt.Sprintf("%.1fs", duration.Seconds())
+}
+
+func capitalise(str string) string {
+	if str == "" {
+		return str
+	}
+	return strings.ToUpper(str[:1]) + str[1:]
+}
@@ -1,48 +1,82 @@
 package presentation
 
 import (
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetCommitFileListDisplayStrings(commitFiles []*models.CommitFile, diffName string) [][]string {
-	if len(commitFiles) == 0 {
+func GetCommitFileListDisplayStrings(commitFileManager *filetree.CommitFileManager, diffName string) [][]string {
+	nodes := commitFileManager.GetVisibleNodes()
+	if len(nodes) == 0 {
 		return [][]string{{utils.ColoredString("(none)", color.FgRed)}}
 	}
 
-	lines := make([][]string, len(commitFiles))
+	lines := make([][]string, len(nodes))
 
-	for i := range commitFiles {
-		diffed := commitFiles[i].Name == diffName
-		lines[i] = getCommitFileDisplayStrings(commitFiles[i], diffed)
+	for i, node := range nodes {
+		diffed := node.Path == diffName
+		indentation := strings.Repeat("  ", node.Depth)
+		if node.IsLeaf() {
+			lines[i] = getCommitFileDisplayStrings(node.CommitFile(), node.Name, indentation, diffed)
+		} else {
+			lines[i] = getCommitFileDirDisplayStrings(node, indentation, commitFileManager.IsCollapsed(node.Path), diffed)
+		}
 	}
 
 	return lines
 }
 
-// getCommitFileDisplayStrings returns the display string of branch
-func getCommitFileDisplayStrings(f *models.CommitFile, diffed bool) []string {
-	yellow := color.New(color.FgYellow)
-	green := color.New(color.FgGreen)
-	defaultColor := color.New(theme.DefaultTextColor)
-	diffTerminalColor := color.New(theme.DiffTerminalColor)
-
-	var colour *color.Color
-	switch f.PatchStatus {
-	case patch.UNSELECTED:
-		colour = defaultColor
-	case patch.WHOLE:
-		colour = green
-	case patch.PART:
-		colour = yellow
+// getCommitFileDisplayStrings returns the display string of a commit file
+func getCommitFileDisplayStrings(f *models.CommitFile, name string, indentation string, diffed bool) []string {
+	colour := getColorForPatchStatus(f.PatchStatus)
+	if diffed {
+		colour = color.New(theme.DiffTerminalColor)
 	}
+	return []string{indentation + utils.ColoredString(f.ChangeStatus, getColorForChangeStatus(f.ChangeStatus)) + " " + colour.Sprint(name)}
+}
+
+// getCommitFileDirDisplayStrings shows a directory in the colour of its files'
+// combined patch status i.e. green if they're all in the patch
+func getCommitFileDirDisplayStrings(node *filetree.Node, indentation string, collapsed bool, diffed bool) []string {
+	arrow := "▼"
+	if collapsed {
+		arrow = "▶"
+	}
+
+	colour := getColorForPatchStatus(getDirPatchStatus(node))
 	if diffed {
-		colour = diffTerminalColor
+		colour = color.New(theme.DiffTerminalColor)
+	}
+
+	return []string{indentation + colour.Sprintf("%s %s", arrow, node.Name)}
+}
+
+func getDirPatchStatus(node *filetree.Node) int {
+	commitFiles := node.CommitFiles()
+	status := commitFiles[0].PatchStatus
+	for _, commitFile := range commitFiles[1:] {
+		if commitFile.PatchStatus != status {
+			return patch.PART
+		}
+	}
+	return status
+}
+
+func getColorForPatchStatus(patchStatus int) *color.Color {
+	switch patchStatus {
+	case patch.WHOLE:
+		return color.New(color.FgGreen)
+	case patch.PART:
+		return color.New(color.FgYellow)
+	default:
+		return color.New(theme.DefaultTextColor)
 	}
-	return []string{utils.ColoredString(f.ChangeStatus, getColorForChangeStatus(f.ChangeStatus)), colour.Sprint(f.Name)}
 }
 
 func getColorForChangeStatus(changeStatus string) color.Attribute {
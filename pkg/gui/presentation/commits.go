@@ -9,10 +9,12 @@ import (
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetCommitListDisplayStrings(commits []*models.Commit, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string) [][]string {
+// GetCommitListDisplayStrings takes the graph lines for the commits if we're
+// showing the graph, or nil if we're not
+func GetCommitListDisplayStrings(commits []*models.Commit, graphLines []string, fullDescription bool, cherryPickedCommitShaMap map[string]bool, diffName string, bisectInfo *models.BisectInfo) [][]string {
 	lines := make([][]string, len(commits))
 
-	var displayFunc func(*models.Commit, map[string]bool, bool) []string
+	var displayFunc func(*models.Commit, string, map[string]bool, bool, *models.BisectInfo) []string
 	if fullDescription {
 		displayFunc = getFullDescriptionDisplayStringsForCommit
 	} else {
@@ -21,13 +23,17 @@ func GetCommitListDisplayStrings(commits []*models.Commit, fullDescription bool,
 
 	for i := range commits {
 		diffed := commits[i].Sha == diffName
-		lines[i] = displayFunc(commits[i], cherryPickedCommitShaMap, diffed)
+		graphLine := ""
+		if graphLines != nil {
+			graphLine = graphLines[i]
+		}
+		lines[i] = displayFunc(commits[i], graphLine, cherryPickedCommitShaMap, diffed, bisectInfo)
 	}
 
 	return lines
 }
 
-func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool) []string {
+func getFullDescriptionDisplayStringsForCommit(c *models.Commit, graphLine string, cherryPickedCommitShaMap map[string]bool, diffed bool, bisectInfo *models.BisectInfo) []string {
 	red := color.New(color.FgRed)
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -39,6 +45,7 @@ func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCom
 	// horizontally. For the sake of accessibility I'm considering this a feature,
 	// not a bug
 	copied := color.New(color.FgCyan, color.BgBlue)
+	bisectCandidate := color.New(color.FgMagenta, color.Bold)
 
 	var shaColor *color.Color
 	switch c.Status {
@@ -60,6 +67,8 @@ func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCom
 		shaColor = diffedColor
 	} else if cherryPickedCommitShaMap[c.Sha] {
 		shaColor = copied
+	} else if bisectInfo != nil && bisectInfo.IsCandidate(c.Sha) {
+		shaColor = bisectCandidate
 	}
 
 	tagString := ""
@@ -73,10 +82,10 @@ func getFullDescriptionDisplayStringsForCommit(c *models.Commit, cherryPickedCom
 
 	truncatedAuthor := utils.TruncateWithEllipsis(c.Author, 17)
 
-	return []string{shaColor.Sprint(c.ShortSha()), secondColumnString, yellow.Sprint(truncatedAuthor), tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(c.ShortSha()), secondColumnString, yellow.Sprint(truncatedAuthor), graphString(graphLine) + getBisectStatusString(c, bisectInfo) + tagString + defaultColor.Sprint(c.Name)}
 }
 
-func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[string]bool, diffed bool) []string {
+func getDisplayStringsForCommit(c *models.Commit, graphLine string, cherryPickedCommitShaMap map[string]bool, diffed bool, bisectInfo *models.BisectInfo) []string {
 	red := color.New(color.FgRed)
 	yellow := color.New(color.FgYellow)
 	green := color.New(color.FgGreen)
@@ -88,6 +97,7 @@ func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[s
 	// horizontally. For the sake of accessibility I'm considering this a feature,
 	// not a bug
 	copied := color.New(color.FgCyan, color.BgBlue)
+	bisectCandidate := color.New(color.FgMagenta, color.Bold)
 
 	var shaColor *color.Color
 	switch c.Status {
@@ -109,6 +119,8 @@ func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[s
 		shaColor = diffedColor
 	} else if cherryPickedCommitShaMap[c.Sha] {
 		shaColor = copied
+	} else if bisectInfo != nil && bisectInfo.IsCandidate(c.Sha) {
+		shaColor = bisectCandidate
 	}
 
 	actionString := ""
@@ -120,7 +132,37 @@ func getDisplayStringsForCommit(c *models.Commit, cherryPickedCommitShaMap map[s
 		tagString = utils.ColoredStringDirect(strings.Join(c.Tags, " "), tagColor) + " "
 	}
 
-	return []string{shaColor.Sprint(c.ShortSha()), actionString + tagString + defaultColor.Sprint(c.Name)}
+	return []string{shaColor.Sprint(c.ShortSha()), graphString(graphLine) + actionString + getBisectStatusString(c, bisectInfo) + tagString + defaultColor.Sprint(c.Name)}
+}
+
+// graphString puts the graph line in front of the commit's message. The lines
+// all come padded to the same width so that the messages line up
+func graphString(graphLine string) string {
+	if graphLine == "" {
+		return ""
+	}
+	return graphLine + " "
+}
+
+// getBisectStatusString labels the commits we've marked in the current bisect,
+// along with the commit that is currently checked out for testing
+func getBisectStatusString(c *models.Commit, bisectInfo *models.BisectInfo) string {
+	if bisectInfo == nil || !bisectInfo.Started() {
+		return ""
+	}
+
+	switch bisectInfo.Status(c.Sha) {
+	case models.BisectStatusNew:
+		return utils.ColoredString(bisectInfo.NewTerm, color.FgRed) + " "
+	case models.BisectStatusOld:
+		return utils.ColoredString(bisectInfo.OldTerm, color.FgGreen) + " "
+	case models.BisectStatusSkipped:
+		return utils.ColoredString("skipped", color.FgYellow) + " "
+	case models.BisectStatusCurrent:
+		return utils.ColoredString("current", color.FgMagenta) + " "
+	default:
+		return ""
+	}
 }
 
 func actionColorMap(str string) color.Attribute {
@@ -1,32 +1,76 @@
 package presentation
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/filetree"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 )
 
-func GetFileListDisplayStrings(files []*models.File, diffName string, submoduleConfigs []*models.SubmoduleConfig) [][]string {
-	lines := make([][]string, len(files))
+func GetFileListDisplayStrings(fileManager *filetree.FileManager, diffName string, submoduleConfigs []*models.SubmoduleConfig) [][]string {
+	nodes := fileManager.GetVisibleNodes()
+	lines := make([][]string, len(nodes))
 
-	for i := range files {
-		diffed := files[i].Name == diffName
-		lines[i] = getFileDisplayStrings(files[i], diffed, submoduleConfigs)
+	for i, node := range nodes {
+		indentation := strings.Repeat("  ", node.Depth)
+		if node.IsLeaf() {
+			file := node.File()
+			lines[i] = getFileDisplayStrings(file, getFileName(node, fileManager.ShowingTree()), indentation, file.Name == diffName, submoduleConfigs)
+		} else {
+			lines[i] = getFileDirDisplayStrings(node, indentation, fileManager.IsCollapsed(node.Path), node.Path == diffName)
+		}
 	}
 
 	return lines
 }
 
-// getFileDisplayStrings returns the display string of branch
-func getFileDisplayStrings(f *models.File, diffed bool, submoduleConfigs []*models.SubmoduleConfig) []string {
+// getFileName returns the name we show for a file: its full path in the flat
+// list, or just its base name in the tree where the path is given by the
+// directories above it. Newlines in names are escaped so a file takes one line
+func getFileName(node *filetree.Node, showingTree bool) string {
+	file := node.File()
+	name := node.Name
+	if file.IsRename() {
+		name = file.PreviousName + " -> " + file.Name
+	} else if !showingTree {
+		name = file.Name
+	}
+	return strings.Replace(name, "\n", "\\n", -1)
+}
+
+// getFileDirDisplayStrings shows a directory in the colour its files would be
+// if they were one file, so you can see at a glance whether it's all staged
+func getFileDirDisplayStrings(node *filetree.Node, indentation string, collapsed bool, diffed bool) []string {
+	arrow := "▼"
+	if collapsed {
+		arrow = "▶"
+	}
+
+	var colour color.Attribute
+	if diffed {
+		colour = theme.DiffTerminalColor
+	} else if node.AnyFile(func(f *models.File) bool { return f.HasUnstagedChanges || (!f.Tracked && !f.HasStagedChanges) }) {
+		colour = color.FgRed
+	} else {
+		colour = color.FgGreen
+	}
+
+	return []string{indentation + utils.ColoredString(fmt.Sprintf("%s %s", arrow, node.Name), colour)}
+}
+
+// getFileDisplayStrings returns the display string of a file
+func getFileDisplayStrings(f *models.File, name string, indentation string, diffed bool, submoduleConfigs []*models.SubmoduleConfig) []string {
 	// potentially inefficient to be instantiating these color
 	// objects with each render
 	red := color.New(color.FgRed)
 	green := color.New(color.FgGreen)
 	diffColor := color.New(theme.DiffTerminalColor)
 	if !f.Tracked && !f.HasStagedChanges {
-		return []string{red.Sprint(f.DisplayString)}
+		return []string{indentation + red.Sprintf("%s %s", f.ShortStatus, name)}
 	}
 
 	var restColor *color.Color
@@ -39,25 +83,47 @@ func getFileDisplayStrings(f *models.File, diffed bool, submoduleConfigs []*mode
 	}
 
 	// this is just making things look nice when the background attribute is 'reverse'
-	firstChar := f.DisplayString[0:1]
+	firstChar := f.ShortStatus[0:1]
 	firstCharCl := green
 	if firstChar == " " {
 		firstCharCl = restColor
 	}
 
-	secondChar := f.DisplayString[1:2]
+	secondChar := f.ShortStatus[1:2]
 	secondCharCl := red
 	if secondChar == " " {
 		secondCharCl = restColor
 	}
 
-	output := firstCharCl.Sprint(firstChar)
+	output := indentation + firstCharCl.Sprint(firstChar)
 	output += secondCharCl.Sprint(secondChar)
-	output += restColor.Sprintf(" %s", f.Name)
+	output += restColor.Sprintf(" %s", name)
 
-	if f.IsSubmodule(submoduleConfigs) {
-		output += utils.ColoredString(" (submodule)", theme.DefaultTextColor)
+	if f.IsSubmodule(submoduleConfigs) || f.Submodule != nil {
+		output += utils.ColoredString(" "+getSubmoduleStatusDisplayString(f.Submodule), theme.DefaultTextColor)
 	}
 
 	return []string{output}
 }
+
+// getSubmoduleStatusDisplayString says what's changed in a submodule, the way
+// git status does
+func getSubmoduleStatusDisplayString(status *models.SubmoduleStatus) string {
+	changes := []string{}
+	if status != nil {
+		if status.CommitChanged {
+			changes = append(changes, "new commits")
+		}
+		if status.HasTrackedChanges {
+			changes = append(changes, "modified content")
+		}
+		if status.HasUntrackedChanges {
+			changes = append(changes, "untracked content")
+		}
+	}
+
+	if len(changes) == 0 {
+		return "(submodule)"
+	}
+	return fmt.Sprintf("(submodule: %s)", strings.Join(changes, ", "))
+}
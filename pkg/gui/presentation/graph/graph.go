@@ -0,0 +1,318 @@
+package graph
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// to give a high-level explanation of what's going on here: we draw the graph
+// one commit at a time, from the newest commit down. We keep track of a set of
+// lanes, each of which is waiting for a particular commit to come along (the
+// parent of a commit we've already drawn). When that commit turns up we draw it
+// in its lane, and the lane goes on to wait for the commit's first parent. Any
+// other parents of a merge commit get a lane of their own, and if more than one
+// lane was waiting on the commit (i.e. the commit has more than one child) those
+// lanes converge into the commit's lane and end there.
+// Because each line only depends on the commits above it, when we load more
+// commits we can carry on from where we left off rather than starting again.
+
+var laneColors = []color.Attribute{
+	color.FgCyan,
+	color.FgGreen,
+	color.FgYellow,
+	color.FgBlue,
+	color.FgMagenta,
+	color.FgRed,
+}
+
+const (
+	commitSymbol = "◯"
+	mergeSymbol  = "⏣"
+)
+
+type lane struct {
+	// sha is the commit the lane is waiting for. An empty sha means the lane is free
+	sha   string
+	color color.Attribute
+}
+
+// cell is one lane's worth of a single line of the graph. Connections say which
+// sides of the cell a line passes through, and the gap is the space between the
+// cell and the next one, which a horizontal line may run through
+type cell struct {
+	up, down, left, right bool
+	isCommit              bool
+	isMerge               bool
+	color                 color.Attribute
+	gapLine               bool
+	gapColor              color.Attribute
+}
+
+func (c *cell) isEmpty() bool {
+	return !c.up && !c.down && !c.left && !c.right && !c.isCommit
+}
+
+func (c *cell) render() string {
+	var str string
+	if c.isCommit {
+		str = commitSymbol
+		if c.isMerge {
+			str = mergeSymbol
+		}
+	} else {
+		str = boxChar(c.up, c.down, c.left, c.right)
+	}
+	if str != " " {
+		str = utils.ColoredString(str, c.color)
+	}
+
+	gap := " "
+	if c.gapLine {
+		gap = utils.ColoredString("─", c.gapColor)
+	}
+
+	return str + gap
+}
+
+func boxChar(up, down, left, right bool) string {
+	switch {
+	case up && down && left && right:
+		return "┼"
+	case up && down && left:
+		return "┤"
+	case up && down && right:
+		return "├"
+	case up && left && right:
+		return "┴"
+	case down && left && right:
+		return "┬"
+	case up && left:
+		return "╯"
+	case up && right:
+		return "╰"
+	case down && left:
+		return "╮"
+	case down && right:
+		return "╭"
+	case up || down:
+		return "│"
+	case left || right:
+		return "─"
+	default:
+		return " "
+	}
+}
+
+// Graph draws the commit graph and remembers where it got up to so that it only
+// needs to draw the commits it hasn't seen before
+type Graph struct {
+	mutex     sync.Mutex
+	lanes     []lane
+	nextColor int
+	shas      []string
+	lines     []string
+	widths    []int
+}
+
+func NewGraph() *Graph {
+	return &Graph{}
+}
+
+// Lines returns a line of the graph for each of the given commits, padded so
+// that they're all the same width
+func (g *Graph) Lines(commits []*models.Commit) []string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if !g.isExtendedBy(commits) {
+		g.reset()
+	}
+
+	for _, commit := range commits[len(g.shas):] {
+		g.add(commit)
+	}
+
+	maxWidth := 0
+	for _, width := range g.widths {
+		if width > maxWidth {
+			maxWidth = width
+		}
+	}
+
+	lines := make([]string, len(commits))
+	for i := range commits {
+		lines[i] = g.lines[i] + strings.Repeat(" ", maxWidth-g.widths[i])
+	}
+
+	return lines
+}
+
+// isExtendedBy tells us whether the commits we've already drawn are the first
+// of the given commits, in which case we can carry on from where we left off
+func (g *Graph) isExtendedBy(commits []*models.Commit) bool {
+	if len(commits) < len(g.shas) {
+		return false
+	}
+
+	for i, sha := range g.shas {
+		if commits[i].Sha != sha {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (g *Graph) reset() {
+	g.lanes = nil
+	g.nextColor = 0
+	g.shas = nil
+	g.lines = nil
+	g.widths = nil
+}
+
+func (g *Graph) add(commit *models.Commit) {
+	g.shas = append(g.shas, commit.Sha)
+
+	// the commits we're yet to rebase aren't part of the history yet
+	if commit.Status == "rebasing" {
+		g.lines = append(g.lines, "")
+		g.widths = append(g.widths, 0)
+		return
+	}
+
+	cells := make([]*cell, len(g.lanes))
+	for i, lane := range g.lanes {
+		cells[i] = &cell{}
+		if lane.sha != "" {
+			cells[i] = &cell{up: true, down: true, color: lane.color}
+		}
+	}
+
+	// the first lane waiting for the commit is where we draw it, and any others
+	// converge into that one
+	commitLane := -1
+	convergingLanes := []int{}
+	for i, lane := range g.lanes {
+		if lane.sha != commit.Sha {
+			continue
+		}
+		if commitLane == -1 {
+			commitLane = i
+		} else {
+			convergingLanes = append(convergingLanes, i)
+		}
+	}
+
+	hasChildren := commitLane != -1
+	if !hasChildren {
+		commitLane, cells = g.freeLane(cells)
+		g.lanes[commitLane] = lane{sha: commit.Sha, color: g.newColor()}
+	}
+
+	cells[commitLane] = &cell{
+		up:       hasChildren,
+		down:     len(commit.Parents) > 0,
+		isCommit: true,
+		isMerge:  commit.IsMerge(),
+		color:    g.lanes[commitLane].color,
+	}
+
+	for _, i := range convergingLanes {
+		cells[i] = &cell{up: true, color: g.lanes[i].color}
+		connect(cells, commitLane, i, g.lanes[i].color)
+		g.lanes[i] = lane{}
+	}
+
+	if len(commit.Parents) == 0 {
+		g.lanes[commitLane] = lane{}
+	} else {
+		g.lanes[commitLane].sha = commit.Parents[0]
+		cells = g.addMergeParents(cells, commitLane, commit.Parents[1:])
+	}
+
+	for len(g.lanes) > 0 && g.lanes[len(g.lanes)-1].sha == "" {
+		g.lanes = g.lanes[:len(g.lanes)-1]
+	}
+
+	line := ""
+	for _, cell := range cells {
+		line += cell.render()
+	}
+	g.lines = append(g.lines, line)
+	g.widths = append(g.widths, len(cells)*2)
+}
+
+// addMergeParents gives each of a merge commit's other parents a lane, reusing
+// any lane that's already waiting for the parent
+func (g *Graph) addMergeParents(cells []*cell, commitLane int, parents []string) []*cell {
+	for _, parent := range parents {
+		parentLane := g.laneWaitingFor(parent)
+		if parentLane == commitLane {
+			continue
+		}
+		if parentLane == -1 {
+			parentLane, cells = g.freeLane(cells)
+			g.lanes[parentLane] = lane{sha: parent, color: g.newColor()}
+			cells[parentLane] = &cell{down: true, color: g.lanes[parentLane].color}
+		}
+		connect(cells, commitLane, parentLane, g.lanes[parentLane].color)
+	}
+
+	return cells
+}
+
+// freeLane returns a lane that isn't waiting on any commit, and that nothing has
+// been drawn in on the current line, adding a new lane if there isn't one
+func (g *Graph) freeLane(cells []*cell) (int, []*cell) {
+	for i, lane := range g.lanes {
+		if lane.sha == "" && cells[i].isEmpty() {
+			return i, cells
+		}
+	}
+
+	g.lanes = append(g.lanes, lane{})
+	return len(g.lanes) - 1, append(cells, &cell{})
+}
+
+func (g *Graph) laneWaitingFor(sha string) int {
+	for i, lane := range g.lanes {
+		if lane.sha == sha {
+			return i
+		}
+	}
+	return -1
+}
+
+func (g *Graph) newColor() color.Attribute {
+	attr := laneColors[g.nextColor%len(laneColors)]
+	g.nextColor++
+	return attr
+}
+
+// connect draws a horizontal line between two cells on the same line
+func connect(cells []*cell, from int, to int, attr color.Attribute) {
+	start, end := from, to
+	if start > end {
+		start, end = end, start
+	}
+
+	cells[start].right = true
+	cells[end].left = true
+	for i := start; i < end; i++ {
+		if i > start {
+			// where we cross a lane we leave it its own color
+			if !cells[i].up && !cells[i].down {
+				cells[i].color = attr
+			}
+			cells[i].left = true
+			cells[i].right = true
+		}
+		cells[i].gapLine = true
+		cells[i].gapColor = attr
+	}
+}
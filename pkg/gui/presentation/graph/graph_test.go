@@ -0,0 +1,107 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// commits takes lines like 'sha parent1 parent2' and returns the corresponding commits
+func commits(lines ...string) []*models.Commit {
+	result := make([]*models.Commit, len(lines))
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		result[i] = &models.Commit{Sha: fields[0], Parents: fields[1:]}
+	}
+	return result
+}
+
+func decolorise(lines []string) []string {
+	result := make([]string, len(lines))
+	for i, line := range lines {
+		result[i] = utils.Decolorise(line)
+	}
+	return result
+}
+
+func TestGraphLines(t *testing.T) {
+	type scenario struct {
+		testName string
+		commits  []*models.Commit
+		expected []string
+	}
+
+	scenarios := []scenario{
+		{
+			"Linear history",
+			commits("c b", "b a", "a"),
+			[]string{
+				"◯ ",
+				"◯ ",
+				"◯ ",
+			},
+		},
+		{
+			"Merged branch",
+			commits("d c b", "c a", "b a", "a"),
+			[]string{
+				"⏣─╮ ",
+				"◯ │ ",
+				"│ ◯ ",
+				"◯─╯ ",
+			},
+		},
+		{
+			"Two branches with separate heads",
+			commits("c a", "b a", "a"),
+			[]string{
+				"◯   ",
+				"│ ◯ ",
+				"◯─╯ ",
+			},
+		},
+		{
+			"Merge crossing another lane",
+			commits("f e d", "x c", "e c", "d c", "c"),
+			[]string{
+				"⏣─╮   ",
+				"│ │ ◯ ",
+				"◯ │ │ ",
+				"│ ◯ │ ",
+				"◯─┴─╯ ",
+			},
+		},
+		{
+			"Rebasing commits have no graph",
+			[]*models.Commit{{Sha: "todo", Status: "rebasing"}, {Sha: "b", Parents: []string{"a"}}, {Sha: "a"}},
+			[]string{
+				"  ",
+				"◯ ",
+				"◯ ",
+			},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			assert.EqualValues(t, s.expected, decolorise(NewGraph().Lines(s.commits)))
+		})
+	}
+}
+
+func TestGraphLinesIncremental(t *testing.T) {
+	all := commits("d c b", "c a", "b a", "a")
+
+	graph := NewGraph()
+	first := decolorise(graph.Lines(all[:2]))
+	assert.EqualValues(t, []string{"⏣─╮ ", "◯ │ "}, first)
+
+	// carrying on from where we left off should give us the same result as drawing everything at once
+	assert.EqualValues(t, decolorise(NewGraph().Lines(all)), decolorise(graph.Lines(all)))
+
+	// and if the history changes underneath us we start again
+	assert.EqualValues(t, []string{"◯ ", "◯ "}, decolorise(graph.Lines(commits("z a", "a"))))
+}
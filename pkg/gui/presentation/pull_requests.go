@@ -0,0 +1,53 @@
+package presentation
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetPullRequestListDisplayStrings(pullRequests []*models.PullRequest) [][]string {
+	lines := make([][]string, len(pullRequests))
+
+	for i := range pullRequests {
+		lines[i] = getPullRequestDisplayStrings(pullRequests[i])
+	}
+
+	return lines
+}
+
+func getPullRequestDisplayStrings(pr *models.PullRequest) []string {
+	return []string{
+		GetPullRequestStatusString(pr),
+		utils.ColoredString(pr.HeadBranch, GetBranchColor(pr.HeadBranch)),
+		utils.ColoredString(pr.Author, color.FgYellow),
+		utils.ColoredString(pr.Title, theme.DefaultTextColor),
+	}
+}
+
+// GetPullRequestStatusString shows the pull request's number, colored by its
+// CI status, followed by a mark if it has been approved or had changes requested
+func GetPullRequestStatusString(pr *models.PullRequest) string {
+	numberColor := color.FgCyan
+	switch pr.CIStatus {
+	case "success":
+		numberColor = color.FgGreen
+	case "failure":
+		numberColor = color.FgRed
+	case "pending":
+		numberColor = color.FgYellow
+	}
+
+	str := utils.ColoredString(fmt.Sprintf("#%d", pr.Number), numberColor)
+	switch pr.ReviewStatus {
+	case "approved":
+		str += utils.ColoredString(" ✓", color.FgGreen)
+	case "changes_requested":
+		str += utils.ColoredString(" ✗", color.FgRed)
+	}
+
+	return str
+}
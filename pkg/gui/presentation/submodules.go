@@ -0,0 +1,47 @@
+package presentation
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetSubmoduleListDisplayStrings(submodules []*models.SubmoduleConfig) [][]string {
+	lines := make([][]string, len(submodules))
+
+	for i := range submodules {
+		lines[i] = getSubmoduleDisplayStrings(submodules[i])
+	}
+
+	return lines
+}
+
+// getSubmoduleDisplayStrings returns the display string of a submodule. If the
+// submodule has a different commit checked out to the one the parent repo has
+// recorded we show both, as recorded → checked out
+func getSubmoduleDisplayStrings(s *models.SubmoduleConfig) []string {
+	var shas string
+	switch {
+	case !s.IsInitialized():
+		shas = utils.ColoredString("(not initialized)", color.FgRed)
+	case s.HasNewCheckout():
+		shas = utils.ColoredString(shortSha(s.RecordedSha)+" → "+shortSha(s.CheckedOutSha), color.FgYellow)
+	default:
+		shas = utils.ColoredString(shortSha(s.CheckedOutSha), color.FgGreen)
+	}
+
+	dirty := ""
+	if s.IsDirty {
+		dirty = utils.ColoredString("(modified)", color.FgRed)
+	}
+
+	return []string{utils.ColoredString(s.Name, theme.DefaultTextColor), shas, dirty}
+}
+
+func shortSha(sha string) string {
+	if len(sha) < 8 {
+		return sha
+	}
+	return sha[:8]
+}
@@ -1,6 +1,7 @@
 package presentation
 
 import (
+	"github.com/fatih/color"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
@@ -23,5 +24,12 @@ func getTagDisplayStrings(t *models.Tag, diffed bool) []string {
 	if diffed {
 		attr = theme.DiffTerminalColor
 	}
-	return []string{utils.ColoredString(t.Name, attr)}
+
+	// lightweight tags have no message, so this column also tells us which tags are annotated
+	description := ""
+	if t.IsAnnotated {
+		description = utils.ColoredString(t.Message, color.FgYellow)
+	}
+
+	return []string{utils.ColoredString(t.Name, attr), description}
 }
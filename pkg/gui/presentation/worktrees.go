@@ -0,0 +1,47 @@
+package presentation
+
+import (
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/theme"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+func GetWorktreeListDisplayStrings(worktrees []*models.Worktree) [][]string {
+	lines := make([][]string, len(worktrees))
+
+	for i := range worktrees {
+		lines[i] = getWorktreeDisplayStrings(worktrees[i])
+	}
+
+	return lines
+}
+
+// getWorktreeDisplayStrings returns the display string of a worktree
+func getWorktreeDisplayStrings(w *models.Worktree) []string {
+	currentMarker := " "
+	if w.IsCurrent {
+		currentMarker = utils.ColoredString("*", color.FgGreen)
+	}
+
+	var name string
+	switch {
+	case w.IsBare:
+		name = utils.ColoredString("(bare)", color.FgYellow)
+	case w.Branch == "":
+		name = utils.ColoredString("(detached "+w.ShortHead()+")", color.FgYellow)
+	default:
+		name = utils.ColoredString(w.Branch, theme.DefaultTextColor)
+	}
+
+	status := ""
+	if w.IsMain {
+		status = "(main)"
+	} else if w.Prunable {
+		status = utils.ColoredString("(prunable)", color.FgRed)
+	} else if w.IsLocked {
+		status = utils.ColoredString("(locked)", color.FgYellow)
+	}
+
+	return []string{currentMarker, name, utils.ColoredString(w.Path, color.FgBlue), status}
+}
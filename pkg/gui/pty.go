@@ -35,8 +35,9 @@ func (gui *Gui) newPtyTask(viewName string, cmd *exec.Cmd) error {
 	pager := gui.GitCommand.GetPager(width)
 
 	if pager == "" {
-		// if we're not using a custom pager we don't need to use a pty
-		return gui.newCmdTask(viewName, cmd)
+		// if we're not using a custom pager we don't need to use a pty, and we
+		// do our own word-level highlighting of the diff
+		return gui.newWordDiffCmdTask(viewName, cmd)
 	}
 
 	cmd.Env = append(cmd.Env, "GIT_PAGER="+pager)
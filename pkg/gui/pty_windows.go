@@ -9,5 +9,5 @@ func (gui *Gui) onResize() error {
 }
 
 func (gui *Gui) newPtyTask(viewName string, cmd *exec.Cmd) error {
-	return gui.newCmdTask(viewName, cmd)
+	return gui.newWordDiffCmdTask(viewName, cmd)
 }
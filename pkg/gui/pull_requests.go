@@ -0,0 +1,208 @@
+package gui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-errors/errors"
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands"
+	"github.com/jesseduffield/lazygit/pkg/commands/forges"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/gui/presentation"
+)
+
+// startPullRequestPolling keeps the pull requests shown in the branches panel
+// up to date. We only talk to the forge's API if the user has given us a token
+func (gui *Gui) startPullRequestPolling() {
+	if gui.Config.GetUserConfig().GetString("forge.token") == "" {
+		return
+	}
+
+	gui.waitForIntro.Wait()
+	if err := gui.refreshPullRequests(); err != nil {
+		gui.Log.Error(err)
+	}
+
+	gui.goEvery(time.Second*60, gui.stopChan, gui.refreshPullRequests)
+}
+
+// refreshPullRequests loads the repo's open pull requests, along with the CI
+// and review statuses of those we have local branches for. We don't get the
+// statuses of the rest because it's two API calls per pull request. This runs
+// in the background, so we only touch the gui's state from the main loop
+func (gui *Gui) refreshPullRequests() error {
+	provider, err := gui.GitCommand.GetForge()
+	if err != nil || provider == nil {
+		return err
+	}
+
+	pullRequests, err := provider.ListPullRequests()
+	if err != nil {
+		return err
+	}
+
+	localBranches := map[string]bool{}
+	for _, branch := range gui.unfilteredBranches() {
+		localBranches[branch.Name] = true
+	}
+
+	pullRequestsByBranch := map[string]*models.PullRequest{}
+	for _, pr := range pullRequests {
+		if !localBranches[pr.LocalBranchName()] {
+			continue
+		}
+		if err := provider.FetchStatus(pr); err != nil {
+			gui.Log.Error(err)
+		}
+		pullRequestsByBranch[pr.LocalBranchName()] = pr
+	}
+
+	gui.g.Update(func(*gocui.Gui) error {
+		gui.State.PullRequests = pullRequestsByBranch
+		return gui.postRefreshUpdate(gui.Contexts.Branches.Context)
+	})
+
+	return nil
+}
+
+// getForge is for when the user has asked for something that needs the forge's
+// API, so we tell them if it's not set up
+func (gui *Gui) getForge() (forges.Provider, error) {
+	provider, err := gui.GitCommand.GetForge()
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return nil, errors.New(gui.Tr.SLocalize("ForgeNotConfigured"))
+	}
+	return provider, nil
+}
+
+func (gui *Gui) handleViewPullRequests(g *gocui.Gui, v *gocui.View) error {
+	provider, err := gui.getForge()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("FetchingPullRequestsStatus"), func() error {
+		pullRequests, err := provider.ListPullRequests()
+		if err != nil {
+			return gui.surfaceError(err)
+		}
+
+		if len(pullRequests) == 0 {
+			return gui.createErrorPanel(gui.Tr.SLocalize("NoPullRequests"))
+		}
+
+		displayStrings := presentation.GetPullRequestListDisplayStrings(pullRequests)
+		menuItems := make([]*menuItem, len(pullRequests))
+		for i, pr := range pullRequests {
+			pr := pr
+			menuItems[i] = &menuItem{
+				displayStrings: displayStrings[i],
+				onPress: func() error {
+					return gui.createPullRequestMenu(pr)
+				},
+			}
+		}
+
+		gui.g.Update(func(*gocui.Gui) error {
+			return gui.createMenu(gui.Tr.SLocalize("PullRequestsTitle"), menuItems, createMenuOptions{showCancel: true})
+		})
+		return nil
+	})
+}
+
+func (gui *Gui) createPullRequestMenu(pr *models.PullRequest) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("CheckoutPullRequest"),
+			onPress: func() error {
+				return gui.checkoutPullRequest(pr)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("OpenPullRequestInBrowser"),
+			onPress: func() error {
+				return gui.OSCommand.OpenLink(pr.URL)
+			},
+		},
+	}
+
+	return gui.createMenu(fmt.Sprintf("#%d %s", pr.Number, pr.Title), menuItems, createMenuOptions{showCancel: true})
+}
+
+// checkoutPullRequest checks out the pull request's local branch, fetching it
+// first if we don't have it. We fetch the pull request's ref rather than the
+// branch itself so that this works for pull requests from forks too
+func (gui *Gui) checkoutPullRequest(pr *models.PullRequest) error {
+	branchName := pr.LocalBranchName()
+	for _, branch := range gui.unfilteredBranches() {
+		if branch.Name == branchName {
+			return gui.handleCheckoutRef(branchName, handleCheckoutRefOptions{})
+		}
+	}
+
+	go func() {
+		gui.State.FetchMutex.Lock()
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("checkout")).Fetch(
+			commands.FetchOptions{
+				PromptUserForCredential: gui.promptUserForCredential,
+				RemoteName:              "origin",
+				BranchName:              fmt.Sprintf("%s:%s", pr.FetchRef, branchName),
+			},
+		)
+		gui.State.FetchMutex.Unlock()
+
+		gui.handleCredentialsPopup(err)
+		if err != nil {
+			return
+		}
+
+		if err := gui.handleCheckoutRef(branchName, handleCheckoutRefOptions{}); err != nil {
+			_ = gui.surfaceError(err)
+		}
+	}()
+
+	return nil
+}
+
+// createPullRequestWithForge asks for a title and description, then creates the
+// pull request against the repo's default branch
+func (gui *Gui) createPullRequestWithForge(provider forges.Provider, branch *models.Branch) error {
+	return gui.prompt(gui.Tr.SLocalize("PullRequestTitle"), branch.Name, func(title string) error {
+		return gui.prompt(gui.Tr.SLocalize("PullRequestBody"), "", func(body string) error {
+			return gui.WithWaitingStatus(gui.Tr.SLocalize("CreatingPullRequestStatus"), func() error {
+				base, err := provider.DefaultBranch()
+				if err != nil {
+					return gui.surfaceError(err)
+				}
+
+				pr, err := provider.CreatePullRequest(forges.CreatePullRequestOpts{
+					Title: title,
+					Body:  body,
+					Head:  branch.Name,
+					Base:  base,
+				})
+				if err != nil {
+					return gui.surfaceError(err)
+				}
+
+				go func() {
+					if err := gui.refreshPullRequests(); err != nil {
+						gui.Log.Error(err)
+					}
+				}()
+
+				return gui.ask(askOpts{
+					title:  gui.Tr.SLocalize("PullRequestCreatedTitle"),
+					prompt: gui.Tr.TemplateLocalize("PullRequestCreatedPrompt", Teml{"number": pr.Number, "base": base}),
+					handleConfirm: func() error {
+						return gui.OSCommand.OpenLink(pr.URL)
+					},
+				})
+			})
+		})
+	})
+}
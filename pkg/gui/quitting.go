@@ -37,6 +37,16 @@ func (gui *Gui) handleQuit() error {
 func (gui *Gui) handleTopLevelReturn(g *gocui.Gui, v *gocui.View) error {
 	currentContext := gui.currentContext()
 
+	if listContext, ok := currentContext.(*ListContext); ok {
+		if cancelled, err := listContext.cancelRangeSelect(); cancelled || err != nil {
+			return err
+		}
+
+		if gui.isFuzzyFiltering(listContext.GetKey()) {
+			return gui.stopFuzzyFiltering(true)
+		}
+	}
+
 	parentContext, hasParent := currentContext.GetParentContext()
 	if hasParent && currentContext != nil && parentContext != nil {
 		// TODO: think about whether this should be marked as a return rather than adding to the stack
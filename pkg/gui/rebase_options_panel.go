@@ -8,7 +8,7 @@ import (
 func (gui *Gui) handleCreateRebaseOptionsMenu() error {
 	options := []string{"continue", "abort"}
 
-	if gui.GitCommand.WorkingTreeState() == "rebasing" {
+	if status := gui.GitCommand.WorkingTreeState(); status == "rebasing" || status == "applying" {
 		options = append(options, "skip")
 	}
 
@@ -25,9 +25,12 @@ func (gui *Gui) handleCreateRebaseOptionsMenu() error {
 	}
 
 	var title string
-	if gui.GitCommand.WorkingTreeState() == "merging" {
+	switch gui.GitCommand.WorkingTreeState() {
+	case "merging":
 		title = gui.Tr.SLocalize("MergeOptionsTitle")
-	} else {
+	case "applying":
+		title = gui.Tr.SLocalize("ApplyPatchesOptionsTitle")
+	default:
 		title = gui.Tr.SLocalize("RebaseOptionsTitle")
 	}
 
@@ -37,13 +40,16 @@ func (gui *Gui) handleCreateRebaseOptionsMenu() error {
 func (gui *Gui) genericMergeCommand(command string) error {
 	status := gui.GitCommand.WorkingTreeState()
 
-	if status != "merging" && status != "rebasing" {
+	// we should end up with a command like 'git merge --continue'
+	commandType, ok := map[string]string{
+		"merging":  "merge",
+		"rebasing": "rebase",
+		"applying": "am",
+	}[status]
+	if !ok {
 		return gui.createErrorPanel(gui.Tr.SLocalize("NotMergingOrRebasing"))
 	}
 
-	commandType := strings.Replace(status, "ing", "e", 1)
-	// we should end up with a command like 'git merge --continue'
-
 	// it's impossible for a rebase to require a commit so we'll use a subprocess only if it's a merge
 	if status == "merging" && command != "abort" && gui.Config.GetUserConfig().GetBool("git.merging.manualCommit") {
 		sub := gui.OSCommand.PrepareSubProcess("git", commandType, fmt.Sprintf("--%s", command))
@@ -53,7 +59,7 @@ func (gui *Gui) genericMergeCommand(command string) error {
 		}
 		return nil
 	}
-	result := gui.GitCommand.GenericMergeOrRebaseAction(commandType, command)
+	result := gui.GitCommand.WithAction(fmt.Sprintf("%s %s", commandType, command)).GenericMergeOrRebaseAction(commandType, command)
 	if err := gui.handleGenericMergeCommandResult(result); err != nil {
 		return err
 	}
@@ -0,0 +1,88 @@
+package gui
+
+import (
+	"strings"
+)
+
+// besides picking commits, a rebase can run commands and stop along the way.
+// Mid-rebase, we add 'exec' and 'break' lines to the todo, whereas otherwise
+// we start a rebase that runs a command after each commit, e.g. to check that
+// the tests pass at every commit on the branch
+
+func (gui *Gui) handleAddTodoLine() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	commit := gui.getSelectedCommitsLine()
+	if commit == nil {
+		return nil
+	}
+
+	if gui.GitCommand.WorkingTreeState() != "rebasing" {
+		return gui.prompt(gui.Tr.SLocalize("ExecEachCommitTitle"), "", func(command string) error {
+			return gui.handleRebaseWithExec(strings.TrimSpace(command))
+		})
+	}
+
+	if commit.Status != "rebasing" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CanOnlyAddTodoLineToRebase"))
+	}
+
+	index := gui.State.Panels.Commits.SelectedLineIdx
+	menuItems := []*menuItem{
+		{
+			displayStrings: []string{"exec", gui.Tr.SLocalize("ExecAfterTodoItem")},
+			onPress: func() error {
+				return gui.prompt(gui.Tr.SLocalize("ExecCommandTitle"), "", func(command string) error {
+					command = strings.TrimSpace(command)
+					if command == "" {
+						return nil
+					}
+					return gui.insertTodoLine(index, "exec "+command)
+				})
+			},
+		},
+		{
+			displayStrings: []string{"break", gui.Tr.SLocalize("BreakAfterTodoItem")},
+			onPress: func() error {
+				return gui.insertTodoLine(index, "break")
+			},
+		},
+		{
+			displayStrings: []string{"exec", gui.Tr.SLocalize("ExecAfterEachTodoCommit")},
+			onPress: func() error {
+				return gui.prompt(gui.Tr.SLocalize("ExecCommandTitle"), "", func(command string) error {
+					command = strings.TrimSpace(command)
+					if command == "" {
+						return nil
+					}
+					if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("AddTodoLine")).AddExecToEachTodoCommit(command); err != nil {
+						return gui.surfaceError(err)
+					}
+					return gui.refreshRebaseCommits()
+				})
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("AddTodoLineTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) insertTodoLine(index int, line string) error {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("AddTodoLine")).InsertTodoLine(index, line); err != nil {
+		return gui.surfaceError(err)
+	}
+	return gui.refreshRebaseCommits()
+}
+
+func (gui *Gui) handleRebaseWithExec(command string) error {
+	if command == "" {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("ExecEachCommitTitle")).InteractiveRebaseWithExec(gui.State.Commits, gui.State.Panels.Commits.SelectedLineIdx, command)
+		return gui.handleGenericMergeCommandResult(err)
+	})
+}
@@ -0,0 +1,77 @@
+package gui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// rebasing onto a new base is done in two steps: first you mark a commit in the
+// commits panel as the upstream, then you pick the new base from a branch, tag
+// or commit. The commits after the upstream are the ones that move, which we
+// show you before going ahead
+
+func (gui *Gui) handleMarkCommitAsUpstream() error {
+	if ok, err := gui.validateNotInFilterMode(); err != nil || !ok {
+		return err
+	}
+
+	commit := gui.getSelectedLocalCommit()
+	if commit == nil {
+		return nil
+	}
+	if commit.Status == "rebasing" {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantRebaseOntoRebasingCommit"))
+	}
+
+	mode := &gui.State.Modes.RebaseOnto
+	if !mode.Active() {
+		mode.Upstream = commit
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{COMMITS}})
+	}
+
+	if mode.Upstream.Sha == commit.Sha {
+		return gui.exitRebaseOntoMode()
+	}
+
+	return gui.handleRebaseOnto(commit.Sha, commit.ShortSha())
+}
+
+func (gui *Gui) exitRebaseOntoMode() error {
+	gui.State.Modes.RebaseOnto = RebaseOnto{}
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{COMMITS}})
+}
+
+// handleRebaseOnto asks to move the commits after the marked upstream onto the
+// new base, listing the commits that'll move
+func (gui *Gui) handleRebaseOnto(newBase string, newBaseName string) error {
+	upstream := gui.State.Modes.RebaseOnto.Upstream
+
+	commits, err := gui.GitCommand.GetCommitsSince(upstream.Sha)
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+	if len(commits) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoCommitsToRebaseOnto"))
+	}
+
+	prompt := gui.Tr.TemplateLocalize(
+		"ConfirmRebaseOnto",
+		Teml{
+			"count":    strconv.Itoa(len(commits)),
+			"upstream": upstream.ShortSha(),
+			"newBase":  newBaseName,
+		},
+	) + "\n\n" + strings.Join(commits, "\n")
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.SLocalize("RebaseOntoTitle"),
+		prompt: prompt,
+		handleConfirm: func() error {
+			gui.State.Modes.RebaseOnto = RebaseOnto{}
+			return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+				err := gui.GitCommand.WithAction(gui.Tr.SLocalize("RebaseOntoTitle")).RebaseOnto(newBase, upstream.Sha)
+				return gui.handleGenericMergeCommandResult(err)
+			})
+		},
+	})
+}
@@ -43,6 +43,7 @@ func (gui *Gui) dispatchSwitchToRepo(path string) error {
 	}
 	gui.GitCommand = newGitCommand
 	gui.State.Modes.Filtering.Path = ""
+	gui.State.Modes.CommitSearch = CommitSearch{}
 	return gui.Errors.ErrSwitchRepo
 }
 
@@ -73,12 +73,15 @@ func (gui *Gui) refreshReflogCommits() error {
 	}
 
 	if gui.State.Modes.Filtering.Active() {
+		// we only load the new entries, so we need to add them to the full list
+		state.FilteredReflogCommits = gui.unfilteredReflogCommits()
 		if err := refresh(&state.FilteredReflogCommits, state.Modes.Filtering.Path); err != nil {
 			return err
 		}
 	} else {
 		state.FilteredReflogCommits = state.ReflogCommits
 	}
+	gui.reapplyFuzzyFilter(REFLOG_COMMITS_CONTEXT_KEY)
 
 	return gui.postRefreshUpdate(gui.Contexts.ReflogCommits.Context)
 }
@@ -39,6 +39,10 @@ func (gui *Gui) handleRemoteBranchSelect() error {
 }
 
 func (gui *Gui) handleRemoteBranchesEscape(g *gocui.Gui, v *gocui.View) error {
+	if gui.isFuzzyFiltering(REMOTE_BRANCHES_CONTEXT_KEY) {
+		return gui.stopFuzzyFiltering(true)
+	}
+
 	return gui.switchContext(gui.Contexts.Remotes.Context)
 }
 
@@ -59,7 +63,7 @@ func (gui *Gui) handleDeleteRemoteBranch(g *gocui.Gui, v *gocui.View) error {
 		prompt: message,
 		handleConfirm: func() error {
 			return gui.WithWaitingStatus(gui.Tr.SLocalize("DeletingStatus"), func() error {
-				if err := gui.GitCommand.DeleteRemoteBranch(remoteBranch.RemoteName, remoteBranch.Name); err != nil {
+				if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("DeleteRemoteBranch")).DeleteRemoteBranch(remoteBranch.RemoteName, remoteBranch.Name); err != nil {
 					return err
 				}
 
@@ -90,7 +94,7 @@ func (gui *Gui) handleSetBranchUpstream(g *gocui.Gui, v *gocui.View) error {
 		title:  gui.Tr.SLocalize("SetUpstreamTitle"),
 		prompt: message,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.SetBranchUpstream(selectedBranch.RemoteName, selectedBranch.Name, checkedOutBranch.Name); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("setUpstream")).SetBranchUpstream(selectedBranch.RemoteName, selectedBranch.Name, checkedOutBranch.Name); err != nil {
 				return err
 			}
 
@@ -56,6 +56,7 @@ func (gui *Gui) refreshRemotes() error {
 				gui.State.RemoteBranches = remote.Branches
 			}
 		}
+		gui.reapplyFuzzyFilter(REMOTE_BRANCHES_CONTEXT_KEY)
 	}
 
 	return gui.postRefreshUpdate(gui.mustContextForContextKey(gui.getBranchesView().Context))
@@ -82,7 +83,7 @@ func (gui *Gui) handleRemoteEnter() error {
 func (gui *Gui) handleAddRemote(g *gocui.Gui, v *gocui.View) error {
 	return gui.prompt(gui.Tr.SLocalize("newRemoteName"), "", func(remoteName string) error {
 		return gui.prompt(gui.Tr.SLocalize("newRemoteUrl"), "", func(remoteUrl string) error {
-			if err := gui.GitCommand.AddRemote(remoteName, remoteUrl); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("addNewRemote")).AddRemote(remoteName, remoteUrl); err != nil {
 				return err
 			}
 			return gui.refreshSidePanels(refreshOptions{scope: []int{REMOTES}})
@@ -100,7 +101,7 @@ func (gui *Gui) handleRemoveRemote(g *gocui.Gui, v *gocui.View) error {
 		title:  gui.Tr.SLocalize("removeRemote"),
 		prompt: gui.Tr.SLocalize("removeRemotePrompt") + " '" + remote.Name + "'?",
 		handleConfirm: func() error {
-			if err := gui.GitCommand.RemoveRemote(remote.Name); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("removeRemote")).RemoveRemote(remote.Name); err != nil {
 				return err
 			}
 
@@ -124,7 +125,7 @@ func (gui *Gui) handleEditRemote(g *gocui.Gui, v *gocui.View) error {
 
 	return gui.prompt(editNameMessage, remote.Name, func(updatedRemoteName string) error {
 		if updatedRemoteName != remote.Name {
-			if err := gui.GitCommand.RenameRemote(remote.Name, updatedRemoteName); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("editRemote")).RenameRemote(remote.Name, updatedRemoteName); err != nil {
 				return gui.surfaceError(err)
 			}
 		}
@@ -143,7 +144,7 @@ func (gui *Gui) handleEditRemote(g *gocui.Gui, v *gocui.View) error {
 		}
 
 		return gui.prompt(editUrlMessage, url, func(updatedRemoteUrl string) error {
-			if err := gui.GitCommand.UpdateRemoteUrl(updatedRemoteName, updatedRemoteUrl); err != nil {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("editRemote")).UpdateRemoteUrl(updatedRemoteName, updatedRemoteUrl); err != nil {
 				return gui.surfaceError(err)
 			}
 			return gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, REMOTES}})
@@ -162,7 +163,7 @@ func (gui *Gui) handleFetchRemote(g *gocui.Gui, v *gocui.View) error {
 		defer gui.State.FetchMutex.Unlock()
 
 		// TODO: test this
-		err := gui.GitCommand.FetchRemote(remote.Name, gui.promptUserForCredential)
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("fetchRemote")).FetchRemote(remote.Name, gui.promptUserForCredential)
 		gui.handleCredentialsPopup(err)
 
 		return gui.refreshSidePanels(refreshOptions{scope: []int{BRANCHES, REMOTES}})
@@ -8,7 +8,7 @@ import (
 )
 
 func (gui *Gui) resetToRef(ref string, strength string, options oscommands.RunCommandOptions) error {
-	if err := gui.GitCommand.ResetToCommit(ref, strength, options); err != nil {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("resetTo")+" "+ref).ResetToCommit(ref, strength, options); err != nil {
 		return gui.surfaceError(err)
 	}
 
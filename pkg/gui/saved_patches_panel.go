@@ -0,0 +1,218 @@
+package gui
+
+import (
+	"errors"
+
+	"github.com/fatih/color"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
+	"github.com/jesseduffield/lazygit/pkg/utils"
+)
+
+// saved patches are custom patches we've saved under a name so that they
+// survive resetting the patch or quitting lazygit. They can be applied to the
+// working tree, the index, or any commit of the current branch
+
+func (gui *Gui) handleSavePatch() error {
+	if gui.GitCommand.PatchManager.IsEmpty() {
+		return gui.createErrorPanel(gui.Tr.SLocalize("EmptyPatchError"))
+	}
+
+	return gui.prompt(gui.Tr.SLocalize("SavePatchTitle"), "", func(name string) error {
+		if err := gui.GitCommand.ValidateSavedPatchName(name); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		save := func() error {
+			content := gui.GitCommand.PatchManager.RenderAggregatedPatchColored(true)
+			if err := gui.GitCommand.SavePatch(name, content); err != nil {
+				return gui.surfaceError(err)
+			}
+			return nil
+		}
+
+		if gui.GitCommand.SavedPatchExists(name) {
+			return gui.ask(askOpts{
+				title:         gui.Tr.SLocalize("SavePatchTitle"),
+				prompt:        gui.Tr.TemplateLocalize("OverwriteSavedPatchPrompt", Teml{"name": name}),
+				handleConfirm: save,
+			})
+		}
+
+		return save()
+	})
+}
+
+func (gui *Gui) handleCreateSavedPatchesMenu() error {
+	savedPatches, err := gui.GitCommand.GetSavedPatches()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if len(savedPatches) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoSavedPatches"))
+	}
+
+	menuItems := make([]*menuItem, len(savedPatches))
+	for i, savedPatch := range savedPatches {
+		savedPatch := savedPatch
+		menuItems[i] = &menuItem{
+			displayStrings: []string{
+				savedPatch.Name,
+				utils.ColoredString(utils.UnixToTimeAgo(savedPatch.ModTime.Unix()), color.FgBlue),
+			},
+			onPress: func() error {
+				return gui.createSavedPatchOptionsMenu(savedPatch)
+			},
+			onSelect: func() error {
+				return gui.previewSavedPatch(savedPatch)
+			},
+		}
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("SavedPatchesTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) previewSavedPatch(savedPatch *models.SavedPatch) error {
+	patchManager, err := gui.loadSavedPatch(savedPatch.Name, "")
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: gui.Tr.TemplateLocalize("SavedPatchPreviewTitle", Teml{"name": savedPatch.Name}),
+			task:  gui.createRenderStringTask(patchManager.RenderAggregatedPatchColored(false)),
+		},
+	})
+}
+
+// loadSavedPatch returns a patch manager for the saved patch, separate from the
+// one for the custom patch you're building, so that the latter is left alone
+func (gui *Gui) loadSavedPatch(name string, action string) (*patch.PatchManager, error) {
+	content, err := gui.GitCommand.ReadSavedPatch(name)
+	if err != nil {
+		return nil, err
+	}
+
+	gitCommand := gui.GitCommand
+	if action != "" {
+		gitCommand = gitCommand.WithAction(action)
+	}
+
+	patchManager := patch.NewPatchManager(gui.Log, gitCommand.ApplyPatch, gitCommand.ShowFileDiff)
+	patchManager.LoadPatch(content)
+	if patchManager.IsEmpty() {
+		return nil, errors.New(gui.Tr.SLocalize("EmptyPatchError"))
+	}
+
+	return patchManager, nil
+}
+
+func (gui *Gui) createSavedPatchOptionsMenu(savedPatch *models.SavedPatch) error {
+	name := savedPatch.Name
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("ApplySavedPatchToWorkingTree"),
+			onPress:       func() error { return gui.handleApplySavedPatch(name, false) },
+		},
+		{
+			displayString: gui.Tr.SLocalize("ApplySavedPatchToWorkingTreeInReverse"),
+			onPress:       func() error { return gui.handleApplySavedPatch(name, true) },
+		},
+		{
+			displayString: gui.Tr.SLocalize("ApplySavedPatchToIndex"),
+			onPress:       func() error { return gui.handleApplySavedPatch(name, false, "cached") },
+		},
+		{
+			displayString: gui.Tr.SLocalize("ApplySavedPatchToIndexInReverse"),
+			onPress:       func() error { return gui.handleApplySavedPatch(name, true, "cached") },
+		},
+	}
+
+	if gui.currentContextKeyIgnoringPopups() == BRANCH_COMMITS_CONTEXT_KEY && gui.workingTreeState() == "normal" {
+		if commit := gui.getSelectedLocalCommit(); commit != nil {
+			menuItems = append(menuItems, []*menuItem{
+				{
+					displayString: gui.Tr.TemplateLocalize("ApplySavedPatchToCommit", Teml{"sha": commit.ShortSha()}),
+					onPress:       func() error { return gui.handleApplySavedPatchToCommit(name, false) },
+				},
+				{
+					displayString: gui.Tr.TemplateLocalize("RemoveSavedPatchFromCommit", Teml{"sha": commit.ShortSha()}),
+					onPress:       func() error { return gui.handleApplySavedPatchToCommit(name, true) },
+				},
+			}...)
+		}
+	}
+
+	menuItems = append(menuItems, []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("ExportSavedPatch"),
+			onPress:       func() error { return gui.handleExportSavedPatch(name) },
+		},
+		{
+			displayString: gui.Tr.SLocalize("DeleteSavedPatch"),
+			onPress:       func() error { return gui.handleDeleteSavedPatch(name) },
+		},
+	}...)
+
+	return gui.createMenu(name, menuItems, createMenuOptions{showCancel: true})
+}
+
+// handleApplySavedPatch applies the patch to the working tree, or with the
+// 'cached' flag, to the index
+func (gui *Gui) handleApplySavedPatch(name string, reverse bool, flags ...string) error {
+	patchManager, err := gui.loadSavedPatch(name, gui.Tr.SLocalize("ApplySavedPatch"))
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	if err := patchManager.ApplyPatchesWithFlags(reverse, flags...); err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+}
+
+func (gui *Gui) handleApplySavedPatchToCommit(name string, reverse bool) error {
+	if ok, err := gui.validateNormalWorkingTreeState(); !ok {
+		return err
+	}
+	if ok, err := gui.validateNotFuzzyFilteringCommits(); err != nil || !ok {
+		return err
+	}
+
+	patchManager, err := gui.loadSavedPatch(name, gui.Tr.SLocalize("ApplySavedPatch"))
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("RebasingStatus"), func() error {
+		commitIndex := gui.State.Panels.Commits.SelectedLineIdx
+		err := gui.GitCommand.WithAction(gui.Tr.SLocalize("ApplySavedPatch")).ApplyPatchesToCommit(gui.State.Commits, commitIndex, patchManager, reverse)
+		return gui.handleGenericMergeCommandResult(err)
+	})
+}
+
+func (gui *Gui) handleExportSavedPatch(name string) error {
+	return gui.prompt(gui.Tr.SLocalize("ExportSavedPatchTitle"), name+".patch", func(path string) error {
+		if err := gui.GitCommand.ExportSavedPatch(name, path); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{FILES}})
+	})
+}
+
+func (gui *Gui) handleDeleteSavedPatch(name string) error {
+	return gui.ask(askOpts{
+		title:  gui.Tr.SLocalize("DeleteSavedPatch"),
+		prompt: gui.Tr.TemplateLocalize("DeleteSavedPatchPrompt", Teml{"name": name}),
+		handleConfirm: func() error {
+			if err := gui.GitCommand.DeleteSavedPatch(name); err != nil {
+				return gui.surfaceError(err)
+			}
+			return nil
+		},
+	})
+}
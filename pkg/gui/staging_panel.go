@@ -144,8 +144,22 @@ func (gui *Gui) applySelection(reverse bool) error {
 	if !reverse || state.SecondaryFocused {
 		applyFlags = append(applyFlags, "cached")
 	}
-	err := gui.GitCommand.ApplyPatch(patch, applyFlags...)
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("StageSelection"))
+
+	// git can't apply a patch to the index for a file that isn't in it, so for
+	// an untracked file we first add an empty entry for the lines to go into
+	addingIntent := !reverse && !file.Tracked && !file.HasStagedChanges
+	if addingIntent {
+		if err := gitCommand.AddIntentToAdd(file.Name); err != nil {
+			return gui.surfaceError(err)
+		}
+	}
+
+	err := gitCommand.ApplyPatch(patch, applyFlags...)
 	if err != nil {
+		if addingIntent {
+			_ = gitCommand.UnStageFile(file.Names(), false)
+		}
 		return gui.surfaceError(err)
 	}
 
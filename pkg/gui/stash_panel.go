@@ -38,6 +38,7 @@ func (gui *Gui) handleStashEntrySelect() error {
 
 func (gui *Gui) refreshStashEntries() error {
 	gui.State.StashEntries = gui.GitCommand.GetStashEntries(gui.State.Modes.Filtering.Path)
+	gui.reapplyFuzzyFilter(STASH_CONTEXT_KEY)
 
 	return gui.Contexts.Stash.Context.HandleRender()
 }
@@ -85,6 +86,16 @@ func (gui *Gui) handleStashPop(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleStashDrop(g *gocui.Gui, v *gocui.View) error {
+	if gui.State.Panels.Stash.IsSelectingRange() {
+		return gui.ask(askOpts{
+			title:  gui.Tr.SLocalize("StashDrop"),
+			prompt: gui.Tr.SLocalize("SureDropStashEntries"),
+			handleConfirm: func() error {
+				return gui.dropStashRange()
+			},
+		})
+	}
+
 	return gui.ask(askOpts{
 		title:  gui.Tr.SLocalize("StashDrop"),
 		prompt: gui.Tr.SLocalize("SureDropStashEntry"),
@@ -105,12 +116,31 @@ func (gui *Gui) stashDo(method string) error {
 		)
 		return gui.createErrorPanel(errorMessage)
 	}
-	if err := gui.GitCommand.StashDo(stashEntry.Index, method); err != nil {
+	action := gui.Tr.TemplateLocalize("StashAction", Teml{"method": gui.Tr.SLocalize(method)})
+	if err := gui.GitCommand.WithAction(action).StashDo(stashEntry.Index, method); err != nil {
 		return gui.surfaceError(err)
 	}
 	return gui.refreshSidePanels(refreshOptions{scope: []int{STASH, FILES}})
 }
 
+// dropStashRange drops the stash entries in the selected range, newest last so
+// that dropping one doesn't change the index of the others
+func (gui *Gui) dropStashRange() error {
+	start, end := gui.State.Panels.Stash.GetSelectedRange()
+	gitCommand := gui.GitCommand.WithAction(gui.Tr.TemplateLocalize("StashAction", Teml{"method": gui.Tr.SLocalize("drop")}))
+	for i := end; i >= start; i-- {
+		if i >= len(gui.State.StashEntries) {
+			continue
+		}
+		if err := gitCommand.StashDo(gui.State.StashEntries[i].Index, "drop"); err != nil {
+			_ = gui.refreshSidePanels(refreshOptions{scope: []int{STASH}})
+			return gui.surfaceError(err)
+		}
+	}
+	gui.State.Panels.Stash.CancelRangeSelect()
+	return gui.refreshSidePanels(refreshOptions{scope: []int{STASH, FILES}})
+}
+
 func (gui *Gui) handleStashSave(stashFunc func(message string) error) error {
 	if len(gui.trackedFiles()) == 0 && len(gui.stagedFiles()) == 0 {
 		return gui.createErrorPanel(gui.Tr.SLocalize("NoTrackedStagedFilesStash"))
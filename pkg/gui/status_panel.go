@@ -38,7 +38,7 @@ func (gui *Gui) refreshStatus() {
 	}
 
 	name := utils.ColoredString(currentBranch.Name, presentation.GetBranchColor(currentBranch.Name))
-	repoName := utils.GetCurrentRepoName()
+	repoName := gui.repoBreadcrumb()
 	status += fmt.Sprintf("%s → %s ", repoName, name)
 
 	gui.g.Update(func(*gocui.Gui) error {
@@ -78,9 +78,9 @@ func (gui *Gui) handleStatusClick(g *gocui.Gui, v *gocui.View) error {
 
 	cx, _ := v.Cursor()
 	upstreamStatus := fmt.Sprintf("↑%s↓%s", currentBranch.Pushables, currentBranch.Pullables)
-	repoName := utils.GetCurrentRepoName()
+	repoName := gui.repoBreadcrumb()
 	switch gui.GitCommand.WorkingTreeState() {
-	case "rebasing", "merging":
+	case "rebasing", "merging", "applying":
 		workingTreeStatus := fmt.Sprintf("(%s)", gui.GitCommand.WorkingTreeState())
 		if cursorInSubstring(cx, upstreamStatus+" ", workingTreeStatus) {
 			return gui.handleCreateRebaseOptionsMenu()
@@ -146,13 +146,5 @@ func lazygitTitle() string {
 }
 
 func (gui *Gui) workingTreeState() string {
-	rebaseMode, _ := gui.GitCommand.RebaseMode()
-	if rebaseMode != "" {
-		return "rebasing"
-	}
-	merging, _ := gui.GitCommand.IsInMergeState()
-	if merging {
-		return "merging"
-	}
-	return "normal"
+	return gui.GitCommand.WorkingTreeState()
 }
@@ -86,6 +86,7 @@ func (gui *Gui) switchToSubCommitsContext(refName string) error {
 			FilterPath:           gui.State.Modes.Filtering.Path,
 			IncludeRebaseCommits: false,
 			RefName:              refName,
+			Search:               gui.State.Modes.CommitSearch.Options,
 		},
 	)
 	if err != nil {
@@ -0,0 +1,236 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedSubmodule() *models.SubmoduleConfig {
+	selectedLine := gui.State.Panels.Submodules.SelectedLineIdx
+	if selectedLine == -1 || len(gui.State.Submodules) == 0 {
+		return nil
+	}
+
+	return gui.State.Submodules[selectedLine]
+}
+
+func (gui *Gui) handleSubmoduleSelect() error {
+	var task updateTask
+	submodule := gui.getSelectedSubmodule()
+	if submodule == nil {
+		task = gui.createRenderStringTask(gui.Tr.SLocalize("NoSubmodules"))
+	} else {
+		task = gui.createRenderStringTask(gui.submoduleSummary(submodule))
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: "Submodule",
+			task:  task,
+		},
+	})
+}
+
+func (gui *Gui) submoduleSummary(submodule *models.SubmoduleConfig) string {
+	checkedOutSha := submodule.CheckedOutSha
+	if !submodule.IsInitialized() {
+		checkedOutSha = gui.Tr.SLocalize("SubmoduleNotInitialized")
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s: %s", gui.Tr.SLocalize("Name"), submodule.Name),
+		fmt.Sprintf("%s: %s", gui.Tr.SLocalize("Path"), submodule.Path),
+		fmt.Sprintf("%s: %s", gui.Tr.SLocalize("Url"), submodule.Url),
+		fmt.Sprintf("%s: %s", gui.Tr.SLocalize("RecordedCommit"), submodule.RecordedSha),
+		fmt.Sprintf("%s: %s", gui.Tr.SLocalize("CheckedOutCommit"), checkedOutSha),
+	}
+
+	if submodule.IsDirty {
+		lines = append(lines, "", gui.Tr.SLocalize("SubmoduleHasChanges"))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (gui *Gui) refreshSubmodules() error {
+	submodules, err := gui.GitCommand.GetSubmodules()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Submodules = submodules
+
+	return gui.postRefreshUpdate(gui.Contexts.Submodules.Context)
+}
+
+// specific functions
+
+func (gui *Gui) handleEnterSubmodule() error {
+	submodule := gui.getSelectedSubmodule()
+	if submodule == nil {
+		return nil
+	}
+
+	if !submodule.IsInitialized() {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantEnterUninitializedSubmodule"))
+	}
+
+	return gui.enterSubmodule(submodule)
+}
+
+// enterSubmodule opens the submodule as a repo in its own right, remembering
+// where we came from so that we can return to the parent repo afterwards
+func (gui *Gui) enterSubmodule(submodule *models.SubmoduleConfig) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	gui.State.RepoPathStack = append(gui.State.RepoPathStack, wd)
+
+	return gui.dispatchSwitchToRepo(submodule.Path)
+}
+
+// repoBreadcrumb returns the name of the current repo, prefixed with the repos
+// we've entered it from if it's a submodule e.g. 'lazygit/vendor/gocui'
+func (gui *Gui) repoBreadcrumb() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	repoPathStack := gui.State.RepoPathStack
+	if len(repoPathStack) == 0 {
+		return filepath.Base(wd)
+	}
+
+	// the outermost repo's path is relative to its parent directory, and every
+	// repo after that is relative to the repo before it
+	crumbs := []string{filepath.Base(repoPathStack[0])}
+	paths := append([]string{}, repoPathStack[1:]...)
+	paths = append(paths, wd)
+	for i, path := range paths {
+		relativePath, err := filepath.Rel(repoPathStack[i], path)
+		if err != nil {
+			relativePath = filepath.Base(path)
+		}
+		crumbs = append(crumbs, filepath.ToSlash(relativePath))
+	}
+
+	return strings.Join(crumbs, "/")
+}
+
+func (gui *Gui) handleSubmoduleInit(g *gocui.Gui, v *gocui.View) error {
+	submodule := gui.getSelectedSubmodule()
+	if submodule == nil {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("InitializingSubmoduleStatus"), func() error {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("initSubmodule")).SubmoduleInit(submodule); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{SUBMODULES}})
+	})
+}
+
+func (gui *Gui) handleCreateSubmoduleUpdateMenu(g *gocui.Gui, v *gocui.View) error {
+	submodule := gui.getSelectedSubmodule()
+	if submodule == nil {
+		return nil
+	}
+
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("UpdateSubmodule"),
+			onPress: func() error {
+				return gui.updateSubmodule(submodule, false)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("UpdateSubmoduleRecursively"),
+			onPress: func() error {
+				return gui.updateSubmodule(submodule, true)
+			},
+		},
+	}
+
+	return gui.createMenu(submodule.Name, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) updateSubmodule(submodule *models.SubmoduleConfig, recursive bool) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("UpdatingSubmoduleStatus"), func() error {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("UpdateSubmodule")).SubmoduleUpdate(submodule, recursive); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{SUBMODULES, FILES}})
+	})
+}
+
+func (gui *Gui) handleSubmoduleSync(g *gocui.Gui, v *gocui.View) error {
+	submodule := gui.getSelectedSubmodule()
+	if submodule == nil {
+		return nil
+	}
+
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("SyncingSubmoduleStatus"), func() error {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("syncSubmodule")).SubmoduleSync(submodule); err != nil {
+			return gui.surfaceError(err)
+		}
+
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{SUBMODULES}})
+	})
+}
+
+func (gui *Gui) handleAddSubmodule(g *gocui.Gui, v *gocui.View) error {
+	return gui.prompt(gui.Tr.SLocalize("NewSubmoduleUrl"), "", func(url string) error {
+		return gui.prompt(gui.Tr.SLocalize("NewSubmodulePath"), defaultSubmodulePath(url), func(path string) error {
+			return gui.WithWaitingStatus(gui.Tr.SLocalize("AddingSubmoduleStatus"), func() error {
+				// git would use the path as the name anyway, but we pass it explicitly
+				// so that we know what it is if we ever need to remove the submodule
+				if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("addSubmodule")).SubmoduleAdd(path, path, url); err != nil {
+					return gui.surfaceError(err)
+				}
+
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{SUBMODULES, FILES}})
+			})
+		})
+	})
+}
+
+// defaultSubmodulePath suggests the repo's name as the path for a new submodule
+// e.g. 'gocui' for git@github.com:jesseduffield/gocui.git
+func defaultSubmodulePath(url string) string {
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+	if i := strings.LastIndexAny(url, "/:"); i != -1 {
+		return url[i+1:]
+	}
+	return url
+}
+
+func (gui *Gui) handleRemoveSubmodule(g *gocui.Gui, v *gocui.View) error {
+	submodule := gui.getSelectedSubmodule()
+	if submodule == nil {
+		return nil
+	}
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.SLocalize("RemoveSubmodule"),
+		prompt: gui.Tr.TemplateLocalize("RemoveSubmodulePrompt", Teml{"name": submodule.Name}),
+		handleConfirm: func() error {
+			if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("removeSubmodule")).SubmoduleDelete(submodule); err != nil {
+				return gui.surfaceError(err)
+			}
+
+			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{SUBMODULES, FILES}})
+		},
+	})
+}
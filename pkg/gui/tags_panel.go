@@ -1,6 +1,8 @@
 package gui
 
 import (
+	"strings"
+
 	"github.com/jesseduffield/gocui"
 	"github.com/jesseduffield/lazygit/pkg/commands/models"
 )
@@ -21,6 +23,12 @@ func (gui *Gui) handleTagSelect() error {
 	tag := gui.getSelectedTag()
 	if tag == nil {
 		task = gui.createRenderStringTask("No tags")
+	} else if tag.IsAnnotated {
+		// this gives us the tagger and the full message, followed by the commit
+		cmd := gui.OSCommand.ExecutableFromString(
+			gui.GitCommand.ShowTagCmdStr(tag.Name),
+		)
+		task = gui.createRunCommandTask(cmd)
 	} else {
 		cmd := gui.OSCommand.ExecutableFromString(
 			gui.GitCommand.GetBranchGraphCmdStr(tag.Name),
@@ -43,6 +51,7 @@ func (gui *Gui) refreshTags() error {
 	}
 
 	gui.State.Tags = tags
+	gui.reapplyFuzzyFilter(TAGS_CONTEXT_KEY)
 
 	return gui.postRefreshUpdate(gui.Contexts.Tags.Context)
 }
@@ -58,16 +67,54 @@ func (gui *Gui) handleCheckoutTag(g *gocui.Gui, v *gocui.View) error {
 	return gui.switchContext(gui.Contexts.Branches.Context)
 }
 
+// getSelectedTags returns the tags in the selected range, or just the selected
+// tag if we're not selecting a range
+func (gui *Gui) getSelectedTags() []*models.Tag {
+	if gui.State.Panels.Tags.SelectedLineIdx == -1 || len(gui.State.Tags) == 0 {
+		return nil
+	}
+
+	start, end := gui.State.Panels.Tags.GetSelectedRange()
+	return gui.State.Tags[start : end+1]
+}
+
+func tagNames(tags []*models.Tag) string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return strings.Join(names, ", ")
+}
+
 func (gui *Gui) handleDeleteTag(g *gocui.Gui, v *gocui.View) error {
-	tag := gui.getSelectedTag()
-	if tag == nil {
+	tags := gui.getSelectedTags()
+	if len(tags) == 0 {
 		return nil
 	}
 
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("DeleteLocalTag"),
+			onPress: func() error {
+				return gui.deleteLocalTags(tags)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("DeleteLocalAndRemoteTag"),
+			onPress: func() error {
+				return gui.createDeleteRemoteTagMenu(tags)
+			},
+		},
+	}
+
+	return gui.createMenu(gui.Tr.SLocalize("DeleteTagTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) deleteLocalTags(tags []*models.Tag) error {
 	prompt := gui.Tr.TemplateLocalize(
 		"DeleteTagPrompt",
 		Teml{
-			"tagName": tag.Name,
+			"tagName": tagNames(tags),
 		},
 	)
 
@@ -75,14 +122,70 @@ func (gui *Gui) handleDeleteTag(g *gocui.Gui, v *gocui.View) error {
 		title:  gui.Tr.SLocalize("DeleteTagTitle"),
 		prompt: prompt,
 		handleConfirm: func() error {
-			if err := gui.GitCommand.DeleteTag(tag.Name); err != nil {
-				return gui.surfaceError(err)
+			gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("DeleteLocalTag"))
+			for _, tag := range tags {
+				if err := gitCommand.DeleteTag(tag.Name); err != nil {
+					return gui.surfaceError(err)
+				}
 			}
+			gui.State.Panels.Tags.CancelRangeSelect()
 			return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{COMMITS, TAGS}})
 		},
 	})
 }
 
+// createDeleteRemoteTagMenu lets the user pick which remote to delete the tags
+// from. The local tags go too, given they're the ones they've selected
+func (gui *Gui) createDeleteRemoteTagMenu(tags []*models.Tag) error {
+	if len(gui.State.Remotes) == 0 {
+		return gui.createErrorPanel(gui.Tr.SLocalize("NoRemotes"))
+	}
+
+	menuItems := make([]*menuItem, len(gui.State.Remotes))
+	for i, remote := range gui.State.Remotes {
+		remote := remote
+		menuItems[i] = &menuItem{
+			displayString: remote.Name,
+			onPress: func() error {
+				return gui.deleteRemoteTags(remote.Name, tags)
+			},
+		}
+	}
+
+	title := gui.Tr.TemplateLocalize("DeleteRemoteTagTitle", Teml{"tagName": tagNames(tags)})
+	return gui.createMenu(title, menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) deleteRemoteTags(remoteName string, tags []*models.Tag) error {
+	prompt := gui.Tr.TemplateLocalize(
+		"DeleteRemoteTagPrompt",
+		Teml{
+			"tagName":    tagNames(tags),
+			"remoteName": remoteName,
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.SLocalize("DeleteTagTitle"),
+		prompt: prompt,
+		handleConfirm: func() error {
+			return gui.WithWaitingStatus(gui.Tr.SLocalize("DeletingTagStatus"), func() error {
+				gitCommand := gui.GitCommand.WithAction(gui.Tr.SLocalize("DeleteLocalAndRemoteTag"))
+				for _, tag := range tags {
+					if err := gitCommand.DeleteRemoteTag(remoteName, tag.Name); err != nil {
+						return gui.surfaceError(err)
+					}
+					if err := gitCommand.DeleteTag(tag.Name); err != nil {
+						return gui.surfaceError(err)
+					}
+				}
+				gui.State.Panels.Tags.CancelRangeSelect()
+				return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{COMMITS, TAGS}})
+			})
+		},
+	})
+}
+
 func (gui *Gui) handlePushTag(g *gocui.Gui, v *gocui.View) error {
 	tag := gui.getSelectedTag()
 	if tag == nil {
@@ -97,7 +200,7 @@ func (gui *Gui) handlePushTag(g *gocui.Gui, v *gocui.View) error {
 	)
 
 	return gui.prompt(title, "origin", func(response string) error {
-		if err := gui.GitCommand.PushTag(response, tag.Name); err != nil {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("pushTag")).PushTag(response, tag.Name); err != nil {
 			return gui.surfaceError(err)
 		}
 		return nil
@@ -105,28 +208,103 @@ func (gui *Gui) handlePushTag(g *gocui.Gui, v *gocui.View) error {
 }
 
 func (gui *Gui) handleCreateTag(g *gocui.Gui, v *gocui.View) error {
-	return gui.prompt(gui.Tr.SLocalize("CreateTagTitle"), "", func(tagName string) error {
-		// leaving commit SHA blank so that we're just creating the tag for the current commit
-		if err := gui.GitCommand.CreateLightweightTag(tagName, ""); err != nil {
-			return gui.surfaceError(err)
-		}
-		return gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS, TAGS}, then: func() {
-			// find the index of the tag and set that as the currently selected line
-			for i, tag := range gui.State.Tags {
-				if tag.Name == tagName {
-					gui.State.Panels.Tags.SelectedLineIdx = i
-					if err := gui.Contexts.Tags.Context.HandleRender(); err != nil {
-						gui.Log.Error(err)
+	// leaving commit SHA blank so that we're just creating the tag for the current commit
+	return gui.createTagMenu("")
+}
+
+// createTagMenu asks what kind of tag the user wants to create on the given
+// commit. Annotated tags need a message, which can be written inline or in the
+// user's editor
+func (gui *Gui) createTagMenu(commitSha string) error {
+	menuItems := []*menuItem{
+		{
+			displayString: gui.Tr.SLocalize("LightweightTag"),
+			onPress: func() error {
+				return gui.prompt(gui.Tr.SLocalize("TagNameTitle"), "", func(tagName string) error {
+					if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("createTag")).CreateLightweightTag(tagName, commitSha); err != nil {
+						return gui.surfaceError(err)
 					}
+					return gui.refreshAfterTagCreated(tagName)
+				})
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("AnnotatedTag"),
+			onPress: func() error {
+				return gui.createAnnotatedTag(commitSha, false)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("AnnotatedTagWithEditor"),
+			onPress: func() error {
+				return gui.createAnnotatedTagWithEditor(commitSha, false)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("SignedTag"),
+			onPress: func() error {
+				return gui.createAnnotatedTag(commitSha, true)
+			},
+		},
+		{
+			displayString: gui.Tr.SLocalize("SignedTagWithEditor"),
+			onPress: func() error {
+				return gui.createAnnotatedTagWithEditor(commitSha, true)
+			},
+		},
+	}
 
-					return
-				}
+	return gui.createMenu(gui.Tr.SLocalize("CreateTagMenuTitle"), menuItems, createMenuOptions{showCancel: true})
+}
+
+func (gui *Gui) createAnnotatedTag(commitSha string, sign bool) error {
+	return gui.prompt(gui.Tr.SLocalize("TagNameTitle"), "", func(tagName string) error {
+		return gui.prompt(gui.Tr.SLocalize("TagMessageTitle"), "", func(message string) error {
+			ok, err := gui.runSyncOrAsyncCommand(gui.GitCommand.WithAction(gui.Tr.SLocalize("createTag")).CreateAnnotatedTag(tagName, commitSha, message, sign))
+			if err != nil {
+				return err
 			}
-		},
+			if !ok {
+				return nil
+			}
+			return gui.refreshAfterTagCreated(tagName)
 		})
 	})
 }
 
+func (gui *Gui) createAnnotatedTagWithEditor(commitSha string, sign bool) error {
+	return gui.prompt(gui.Tr.SLocalize("TagNameTitle"), "", func(tagName string) error {
+		gui.SubProcess = gui.GitCommand.PrepareAnnotatedTagSubProcess(tagName, commitSha, sign)
+		return gui.Errors.ErrSubProcess
+	})
+}
+
+func (gui *Gui) refreshAfterTagCreated(tagName string) error {
+	return gui.refreshSidePanels(refreshOptions{scope: []int{COMMITS, TAGS}, then: func() {
+		// find the index of the tag and set that as the currently selected line
+		for i, tag := range gui.State.Tags {
+			if tag.Name == tagName {
+				gui.State.Panels.Tags.SelectedLineIdx = i
+				if err := gui.Contexts.Tags.Context.HandleRender(); err != nil {
+					gui.Log.Error(err)
+				}
+
+				return
+			}
+		}
+	},
+	})
+}
+
+func (gui *Gui) handleRebaseOntoTag() error {
+	tag := gui.getSelectedTag()
+	if tag == nil {
+		return nil
+	}
+
+	return gui.handleRebaseOntoBranch(tag.Name)
+}
+
 func (gui *Gui) handleCreateResetToTagMenu(g *gocui.Gui, v *gocui.View) error {
 	tag := gui.getSelectedTag()
 	if tag == nil {
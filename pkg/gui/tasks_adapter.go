@@ -1,10 +1,13 @@
 package gui
 
 import (
+	"bufio"
+	"io"
 	"os/exec"
 	"strings"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/patch"
 	"github.com/jesseduffield/lazygit/pkg/tasks"
 )
 
@@ -41,6 +44,88 @@ func (gui *Gui) newCmdTask(viewName string, cmd *exec.Cmd) error {
 	return nil
 }
 
+// newFormattedCmdTask is like newCmdTask except that each line of the command's
+// output goes through the format function before being written to the view.
+// We pipe the formatted lines through to the view buffer manager so that it can
+// still read lazily, meaning we only format as much as the user looks at.
+// If the format function holds lines back, flush is called once the output ends
+// to get whatever's left
+func (gui *Gui) newFormattedCmdTask(viewName string, cmd *exec.Cmd, format func(string) (string, bool), flush func() (string, bool)) error {
+	gui.Log.WithField(
+		"command",
+		strings.Join(cmd.Args, " "),
+	).Debug("RunCommand")
+
+	view, err := gui.g.View(viewName)
+	if err != nil {
+		return nil // swallowing for now
+	}
+
+	_, height := view.Size()
+	_, oy := view.Origin()
+
+	manager := gui.getManager(view)
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	cmd.Stderr = cmd.Stdout
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	r, w := io.Pipe()
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			formatted, ok := format(scanner.Text())
+			if !ok {
+				continue
+			}
+			if _, err := w.Write([]byte(formatted + "\n")); err != nil {
+				// the task has been stopped so nobody is reading anymore
+				return
+			}
+		}
+		if flush != nil {
+			if formatted, ok := flush(); ok {
+				_, _ = w.Write([]byte(formatted + "\n"))
+			}
+		}
+		_ = w.Close()
+	}()
+
+	// closing the reader frees up the goroutine above if it's blocked on a write
+	onDone := func() {
+		_ = r.Close()
+	}
+
+	if err := manager.NewTask(manager.NewCmdTask(r, cmd, height+oy+10, onDone)); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// newWordDiffCmdTask is like newCmdTask except that any diff in the command's
+// output gets word-level highlighting of the changes within paired lines
+func (gui *Gui) newWordDiffCmdTask(viewName string, cmd *exec.Cmd) error {
+	highlighter := patch.NewWordDiffHighlighter()
+	joinLines := func(lines []string) (string, bool) {
+		return strings.Join(lines, "\n"), len(lines) > 0
+	}
+
+	return gui.newFormattedCmdTask(
+		viewName,
+		cmd,
+		func(line string) (string, bool) { return joinLines(highlighter.Write(line)) },
+		func() (string, bool) { return joinLines(highlighter.Flush()) },
+	)
+}
+
 func (gui *Gui) newTask(viewName string, f func(chan struct{}) error) error {
 	view, err := gui.g.View(viewName)
 	if err != nil {
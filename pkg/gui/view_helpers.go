@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/theme"
 	"github.com/jesseduffield/lazygit/pkg/utils"
 	"github.com/spkg/bom"
 )
@@ -25,18 +26,22 @@ const (
 	TAGS
 	REMOTES
 	STATUS
+	WORKTREES
+	SUBMODULES
 )
 
 func getScopeNames(scopes []int) []string {
 	scopeNameMap := map[int]string{
-		COMMITS:  "commits",
-		BRANCHES: "branches",
-		FILES:    "files",
-		STASH:    "stash",
-		REFLOG:   "reflog",
-		TAGS:     "tags",
-		REMOTES:  "remotes",
-		STATUS:   "status",
+		COMMITS:    "commits",
+		BRANCHES:   "branches",
+		FILES:      "files",
+		STASH:      "stash",
+		REFLOG:     "reflog",
+		TAGS:       "tags",
+		REMOTES:    "remotes",
+		STATUS:     "status",
+		WORKTREES:  "worktrees",
+		SUBMODULES: "submodules",
 	}
 
 	scopeNames := make([]string, len(scopes))
@@ -99,7 +104,7 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 	f := func() {
 		var scopeMap map[int]bool
 		if len(options.scope) == 0 {
-			scopeMap = intArrToMap([]int{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, STATUS})
+			scopeMap = intArrToMap([]int{COMMITS, BRANCHES, FILES, STASH, REFLOG, TAGS, REMOTES, STATUS, WORKTREES, SUBMODULES})
 		} else {
 			scopeMap = intArrToMap(options.scope)
 		}
@@ -152,6 +157,30 @@ func (gui *Gui) refreshSidePanels(options refreshOptions) error {
 			}()
 		}
 
+		if scopeMap[WORKTREES] {
+			wg.Add(1)
+			func() {
+				if options.mode == ASYNC {
+					go gui.refreshWorktrees()
+				} else {
+					gui.refreshWorktrees()
+				}
+				wg.Done()
+			}()
+		}
+
+		if scopeMap[SUBMODULES] {
+			wg.Add(1)
+			func() {
+				if options.mode == ASYNC {
+					go gui.refreshSubmodules()
+				} else {
+					gui.refreshSubmodules()
+				}
+				wg.Done()
+			}()
+		}
+
 		if scopeMap[REMOTES] {
 			wg.Add(1)
 			func() {
@@ -287,6 +316,11 @@ func (gui *Gui) getSearchView() *gocui.View {
 	return v
 }
 
+func (gui *Gui) getFilterView() *gocui.View {
+	v, _ := gui.g.View("filter")
+	return v
+}
+
 func (gui *Gui) getStatusView() *gocui.View {
 	v, _ := gui.g.View("status")
 	return v
@@ -355,6 +389,11 @@ func (gui *Gui) changeSelectedLine(panelState IListPanelState, total int, change
 func (gui *Gui) refreshSelectedLine(panelState IListPanelState, total int) {
 	line := panelState.GetSelectedLineIdx()
 
+	// the items in the range may no longer be there
+	if _, end := panelState.GetSelectedRange(); panelState.IsSelectingRange() && total-1 < end {
+		panelState.CancelRangeSelect()
+	}
+
 	if line == -1 && total > 0 {
 		panelState.SetSelectedLineIdx(0)
 	} else if total-1 < line {
@@ -363,10 +402,20 @@ func (gui *Gui) refreshSelectedLine(panelState IListPanelState, total int) {
 }
 
 func (gui *Gui) renderDisplayStrings(v *gocui.View, displayStrings [][]string) {
+	gui.renderDisplayStringsWithRange(v, displayStrings, -1, -1)
+}
+
+// renderDisplayStringsWithRange is like renderDisplayStrings, but highlights
+// the lines from start to end, for when you're selecting a range
+func (gui *Gui) renderDisplayStringsWithRange(v *gocui.View, displayStrings [][]string, start int, end int) {
 	gui.g.Update(func(g *gocui.Gui) error {
-		list := utils.RenderDisplayStrings(displayStrings)
+		lines := strings.Split(utils.RenderDisplayStrings(displayStrings), "\n")
+		for i := start; i >= 0 && i <= end && i < len(lines); i++ {
+			// the line's own colours would reset the background, so we drop them
+			lines[i] = utils.ColoredString(utils.Decolorise(lines[i]), theme.SelectedRangeBgColor)
+		}
 		v.Clear()
-		fmt.Fprint(v, list)
+		fmt.Fprint(v, strings.Join(lines, "\n"))
 		return nil
 	})
 }
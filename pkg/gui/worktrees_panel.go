@@ -0,0 +1,192 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jesseduffield/gocui"
+	"github.com/jesseduffield/lazygit/pkg/commands/models"
+)
+
+// list panel functions
+
+func (gui *Gui) getSelectedWorktree() *models.Worktree {
+	selectedLine := gui.State.Panels.Worktrees.SelectedLineIdx
+	if selectedLine == -1 || len(gui.State.Worktrees) == 0 {
+		return nil
+	}
+
+	return gui.State.Worktrees[selectedLine]
+}
+
+func (gui *Gui) handleWorktreeSelect() error {
+	var task updateTask
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		task = gui.createRenderStringTask(gui.Tr.SLocalize("NoWorktreesThisRepo"))
+	} else if worktree.Head == "" {
+		task = gui.createRenderStringTask(worktree.Path)
+	} else {
+		cmd := gui.OSCommand.ExecutableFromString(
+			gui.GitCommand.GetBranchGraphCmdStr(worktree.Head),
+		)
+		task = gui.createRunPtyTask(cmd)
+	}
+
+	return gui.refreshMainViews(refreshMainOpts{
+		main: &viewUpdateOpts{
+			title: "Worktree",
+			task:  task,
+		},
+	})
+}
+
+func (gui *Gui) refreshWorktrees() error {
+	worktrees, err := gui.GitCommand.GetWorktrees()
+	if err != nil {
+		return gui.surfaceError(err)
+	}
+
+	gui.State.Worktrees = worktrees
+
+	return gui.postRefreshUpdate(gui.Contexts.Worktrees.Context)
+}
+
+// specific functions
+
+func (gui *Gui) handleSwitchToWorktree() error {
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil || worktree.IsCurrent {
+		return nil
+	}
+
+	if worktree.IsBare {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantSwitchToBareWorktree"))
+	}
+
+	return gui.dispatchSwitchToRepo(worktree.Path)
+}
+
+func (gui *Gui) handleCreateWorktree(g *gocui.Gui, v *gocui.View) error {
+	prefilledBranchName := ""
+	if branch := gui.getSelectedBranch(); branch != nil {
+		prefilledBranchName = branch.Name
+	}
+
+	return gui.prompt(gui.Tr.SLocalize("NewWorktreeBranch"), prefilledBranchName, func(branchName string) error {
+		return gui.createWorktreeFromBranch(branchName)
+	})
+}
+
+func (gui *Gui) handleCreateWorktreeFromBranch(g *gocui.Gui, v *gocui.View) error {
+	branch := gui.getSelectedBranch()
+	if branch == nil {
+		return nil
+	}
+
+	return gui.createWorktreeFromBranch(branch.Name)
+}
+
+func (gui *Gui) createWorktreeFromBranch(branchName string) error {
+	title := gui.Tr.TemplateLocalize(
+		"NewWorktreePath",
+		Teml{
+			"branchName": branchName,
+		},
+	)
+
+	return gui.prompt(title, gui.defaultWorktreePath(branchName), func(path string) error {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("createWorktree")).AddWorktree(path, branchName); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.refreshSidePanels(refreshOptions{scope: []int{WORKTREES, BRANCHES}, then: func() {
+			// select the new worktree so that it's easy to switch into it
+			for i, worktree := range gui.State.Worktrees {
+				if worktree.Branch == branchName {
+					gui.State.Panels.Worktrees.SelectedLineIdx = i
+					if err := gui.Contexts.Worktrees.Context.HandleRender(); err != nil {
+						gui.Log.Error(err)
+					}
+
+					return
+				}
+			}
+		},
+		})
+	})
+}
+
+// defaultWorktreePath suggests a sibling directory of the main worktree e.g.
+// ../lazygit-release-1.0 for the branch release/1.0
+func (gui *Gui) defaultWorktreePath(branchName string) string {
+	repoPath, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	if len(gui.State.Worktrees) > 0 {
+		repoPath = gui.State.Worktrees[0].Path
+	}
+
+	suffix := strings.Replace(branchName, "/", "-", -1)
+	return filepath.Join(filepath.Dir(repoPath), filepath.Base(repoPath)+"-"+suffix)
+}
+
+func (gui *Gui) handleRemoveWorktree(g *gocui.Gui, v *gocui.View) error {
+	worktree := gui.getSelectedWorktree()
+	if worktree == nil {
+		return nil
+	}
+
+	if worktree.IsMain {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantRemoveMainWorktree"))
+	}
+
+	if worktree.IsCurrent {
+		return gui.createErrorPanel(gui.Tr.SLocalize("CantRemoveCurrentWorktree"))
+	}
+
+	prompt := gui.Tr.TemplateLocalize(
+		"RemoveWorktreePrompt",
+		Teml{
+			"path": worktree.Path,
+		},
+	)
+
+	return gui.ask(askOpts{
+		title:  gui.Tr.SLocalize("RemoveWorktreeTitle"),
+		prompt: prompt,
+		handleConfirm: func() error {
+			return gui.removeWorktree(worktree, false)
+		},
+	})
+}
+
+func (gui *Gui) removeWorktree(worktree *models.Worktree, force bool) error {
+	if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("removeWorktree")).RemoveWorktree(worktree.Path, force); err != nil {
+		errMessage := err.Error()
+		if force || !strings.Contains(errMessage, "--force") {
+			return gui.surfaceError(err)
+		}
+
+		// git refuses to remove a worktree with local changes unless we force it
+		return gui.ask(askOpts{
+			title:  gui.Tr.SLocalize("ForceRemoveWorktreeTitle"),
+			prompt: gui.Tr.TemplateLocalize("ForceRemoveWorktreePrompt", Teml{"error": errMessage}),
+			handleConfirm: func() error {
+				return gui.removeWorktree(worktree, true)
+			},
+		})
+	}
+
+	return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{WORKTREES, BRANCHES}})
+}
+
+func (gui *Gui) handlePruneWorktrees(g *gocui.Gui, v *gocui.View) error {
+	return gui.WithWaitingStatus(gui.Tr.SLocalize("PruningWorktreesStatus"), func() error {
+		if err := gui.GitCommand.WithAction(gui.Tr.SLocalize("pruneWorktrees")).PruneWorktrees(); err != nil {
+			return gui.surfaceError(err)
+		}
+		return gui.refreshSidePanels(refreshOptions{mode: ASYNC, scope: []int{WORKTREES}})
+	})
+}
@@ -61,9 +61,6 @@ func addDutch(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "CredentialsUsername",
 			Other: "Gebruikersnaam",
-		}, &i18n.Message{
-			ID:    "CredentialsPassword",
-			Other: "Wachtwoord",
 		}, &i18n.Message{
 			ID:    "PassUnameWrong",
 			Other: "Wachtwoord en/of gebruikersnaam verkeert",
@@ -982,9 +979,6 @@ func addDutch(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "createTag",
 			Other: "creëer tag",
-		}, &i18n.Message{
-			ID:    "CreateTagTitle",
-			Other: "Tag naam:",
 		}, &i18n.Message{
 			ID:    "fetchRemote",
 			Other: "fetch remote",
@@ -69,9 +69,6 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "CredentialsUsername",
 			Other: "Username",
-		}, &i18n.Message{
-			ID:    "CredentialsPassword",
-			Other: "Password",
 		}, &i18n.Message{
 			ID:    "PassUnameWrong",
 			Other: "Password and/or username wrong",
@@ -350,7 +347,7 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 			Other: "pick hunk",
 		}, &i18n.Message{
 			ID:    "pickBothHunks",
-			Other: "pick both hunks",
+			Other: "pick all hunks",
 		}, &i18n.Message{
 			ID:    "undo",
 			Other: "undo",
@@ -560,7 +557,7 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 			Other: "pick hunk",
 		}, &i18n.Message{
 			ID:    "PickBothHunks",
-			Other: "pick both hunks",
+			Other: "pick all hunks",
 		}, &i18n.Message{
 			ID:    "ViewMergeRebaseOptions",
 			Other: "view merge/rebase options",
@@ -701,10 +698,10 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 			Other: "select next conflict",
 		}, &i18n.Message{
 			ID:    "SelectTop",
-			Other: "select top hunk",
+			Other: "select previous hunk (ours, base or theirs)",
 		}, &i18n.Message{
 			ID:    "SelectBottom",
-			Other: "select bottom hunk",
+			Other: "select next hunk (ours, base or theirs)",
 		}, &i18n.Message{
 			ID:    "ScrollDown",
 			Other: "scroll down",
@@ -987,9 +984,6 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "createTag",
 			Other: "create tag",
-		}, &i18n.Message{
-			ID:    "CreateTagTitle",
-			Other: "Tag name:",
 		}, &i18n.Message{
 			ID:    "fetchRemote",
 			Other: "fetch remote",
@@ -1197,6 +1191,687 @@ func addEnglish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "andResetSubmodules",
 			Other: "and reset submodules",
+		}, &i18n.Message{
+			ID:    "NoWorktreesThisRepo",
+			Other: "No worktrees for this repo",
+		}, &i18n.Message{
+			ID:    "switchToWorktree",
+			Other: "switch to worktree",
+		}, &i18n.Message{
+			ID:    "createWorktree",
+			Other: "create worktree",
+		}, &i18n.Message{
+			ID:    "createWorktreeFromBranch",
+			Other: "create worktree from branch",
+		}, &i18n.Message{
+			ID:    "removeWorktree",
+			Other: "remove worktree",
+		}, &i18n.Message{
+			ID:    "pruneWorktrees",
+			Other: "prune stale worktrees",
+		}, &i18n.Message{
+			ID:    "NewWorktreeBranch",
+			Other: "Branch to check out in new worktree:",
+		}, &i18n.Message{
+			ID:    "NewWorktreePath",
+			Other: "Path for new worktree of '{{.branchName}}':",
+		}, &i18n.Message{
+			ID:    "CantSwitchToBareWorktree",
+			Other: "You cannot switch to a bare worktree",
+		}, &i18n.Message{
+			ID:    "CantRemoveMainWorktree",
+			Other: "You cannot remove the main worktree",
+		}, &i18n.Message{
+			ID:    "CantRemoveCurrentWorktree",
+			Other: "You cannot remove the worktree you're currently in. Switch to another worktree first",
+		}, &i18n.Message{
+			ID:    "RemoveWorktreeTitle",
+			Other: "Remove worktree",
+		}, &i18n.Message{
+			ID:    "RemoveWorktreePrompt",
+			Other: "Are you sure you want to remove the worktree at '{{.path}}'?",
+		}, &i18n.Message{
+			ID:    "ForceRemoveWorktreeTitle",
+			Other: "Force remove worktree",
+		}, &i18n.Message{
+			ID:    "ForceRemoveWorktreePrompt",
+			Other: "{{.error}}\n\nAre you sure you want to force remove it? Any uncommitted changes in the worktree will be lost.",
+		}, &i18n.Message{
+			ID:    "PruningWorktreesStatus",
+			Other: "pruning worktrees",
+		}, &i18n.Message{
+			ID:    "viewBisectOptions",
+			Other: "view bisect options",
+		}, &i18n.Message{
+			ID:    "BisectOptionsTitle",
+			Other: "Bisect",
+		}, &i18n.Message{
+			ID:    "BisectMark",
+			Other: "mark {{.sha}} as {{.term}}",
+		}, &i18n.Message{
+			ID:    "BisectRun",
+			Other: "run a script to find the culprit (git bisect run)",
+		}, &i18n.Message{
+			ID:    "BisectReset",
+			Other: "reset bisect",
+		}, &i18n.Message{
+			ID:    "BisectResetPrompt",
+			Other: "Are you sure you want to reset the bisect? This will check out the commit you started bisecting from.",
+		}, &i18n.Message{
+			ID:    "BisectingStatus",
+			Other: "bisecting",
+		}, &i18n.Message{
+			ID:    "BisectRunningStatus",
+			Other: "running bisect script",
+		}, &i18n.Message{
+			ID:    "BisectRunPrompt",
+			Other: "Command to test each commit with (exit code 0 is good, 125 is skip, anything else is bad):",
+		}, &i18n.Message{
+			ID:    "BisectRunTitle",
+			Other: "Bisect run",
+		}, &i18n.Message{
+			ID:    "BisectRunFailed",
+			Other: "git bisect run did not complete. See the main panel for its output",
+		}, &i18n.Message{
+			ID:    "BisectCompleteTitle",
+			Other: "Bisect complete",
+		}, &i18n.Message{
+			ID:    "BisectCompletePrompt",
+			Other: "The first {{.term}} commit is:\n{{.commit}}\n\nDo you want to reset the bisect now?",
+		}, &i18n.Message{
+			ID:    "Bisecting",
+			Other: "bisecting",
+		}, &i18n.Message{
+			ID:    "BisectingCandidatesLeft",
+			Other: "bisecting: {{.count}} candidate commits left",
+		}, &i18n.Message{
+			ID:    "BisectFoundCulprit",
+			Other: "bisect found the first {{.term}} commit: {{.sha}}",
+		}, &i18n.Message{
+			ID:    "viewBlame",
+			Other: "view blame",
+		}, &i18n.Message{
+			ID:    "BlameTitle",
+			Other: "Blame: {{.path}}",
+		}, &i18n.Message{
+			ID:    "BlameTitleAtRef",
+			Other: "Blame: {{.path}} at {{.ref}}",
+		}, &i18n.Message{
+			ID:    "CannotBlameFile",
+			Other: "Only files that exist in git can be blamed",
+		}, &i18n.Message{
+			ID:    "BlameLineNotCommitted",
+			Other: "This line hasn't been committed yet",
+		}, &i18n.Message{
+			ID:    "NoEarlierBlame",
+			Other: "There is nothing earlier to blame: this line was added in the first commit",
+		}, &i18n.Message{
+			ID:    "ExitBlame",
+			Other: "return to previous blame or exit blame",
+		}, &i18n.Message{
+			ID:    "GoToBlamedCommit",
+			Other: "go to commit",
+		}, &i18n.Message{
+			ID:    "BlameAtParent",
+			Other: "blame at commit's parent",
+		}, &i18n.Message{
+			ID:    "NoSubmodules",
+			Other: "No submodules",
+		}, &i18n.Message{
+			ID:    "SubmoduleNotInitialized",
+			Other: "not initialized",
+		}, &i18n.Message{
+			ID:    "Name",
+			Other: "Name",
+		}, &i18n.Message{
+			ID:    "Path",
+			Other: "Path",
+		}, &i18n.Message{
+			ID:    "Url",
+			Other: "Url",
+		}, &i18n.Message{
+			ID:    "RecordedCommit",
+			Other: "Recorded commit",
+		}, &i18n.Message{
+			ID:    "CheckedOutCommit",
+			Other: "Checked out commit",
+		}, &i18n.Message{
+			ID:    "SubmoduleHasChanges",
+			Other: "This submodule has uncommitted changes",
+		}, &i18n.Message{
+			ID:    "CantEnterUninitializedSubmodule",
+			Other: "This submodule hasn't been initialized yet. Update it first",
+		}, &i18n.Message{
+			ID:    "enterSubmodule",
+			Other: "enter submodule",
+		}, &i18n.Message{
+			ID:    "initSubmodule",
+			Other: "initialize submodule",
+		}, &i18n.Message{
+			ID:    "viewSubmoduleUpdateOptions",
+			Other: "view update options",
+		}, &i18n.Message{
+			ID:    "UpdateSubmodule",
+			Other: "update submodule",
+		}, &i18n.Message{
+			ID:    "UpdateSubmoduleRecursively",
+			Other: "update submodule and its own submodules",
+		}, &i18n.Message{
+			ID:    "syncSubmodule",
+			Other: "sync submodule url",
+		}, &i18n.Message{
+			ID:    "addSubmodule",
+			Other: "add new submodule",
+		}, &i18n.Message{
+			ID:    "removeSubmodule",
+			Other: "remove submodule",
+		}, &i18n.Message{
+			ID:    "RemoveSubmodule",
+			Other: "Remove submodule",
+		}, &i18n.Message{
+			ID:    "RemoveSubmodulePrompt",
+			Other: "Are you sure you want to remove submodule '{{.name}}' and its corresponding directory? This is irreversible.",
+		}, &i18n.Message{
+			ID:    "NewSubmoduleUrl",
+			Other: "New submodule url:",
+		}, &i18n.Message{
+			ID:    "NewSubmodulePath",
+			Other: "New submodule path:",
+		}, &i18n.Message{
+			ID:    "InitializingSubmoduleStatus",
+			Other: "initializing submodule",
+		}, &i18n.Message{
+			ID:    "UpdatingSubmoduleStatus",
+			Other: "updating submodule",
+		}, &i18n.Message{
+			ID:    "SyncingSubmoduleStatus",
+			Other: "syncing submodule",
+		}, &i18n.Message{
+			ID:    "AddingSubmoduleStatus",
+			Other: "adding submodule",
+		}, &i18n.Message{
+			ID:    "CreateTagMenuTitle",
+			Other: "Create tag",
+		}, &i18n.Message{
+			ID:    "LightweightTag",
+			Other: "lightweight tag",
+		}, &i18n.Message{
+			ID:    "AnnotatedTag",
+			Other: "annotated tag",
+		}, &i18n.Message{
+			ID:    "AnnotatedTagWithEditor",
+			Other: "annotated tag (write message in editor)",
+		}, &i18n.Message{
+			ID:    "SignedTag",
+			Other: "signed tag",
+		}, &i18n.Message{
+			ID:    "SignedTagWithEditor",
+			Other: "signed tag (write message in editor)",
+		}, &i18n.Message{
+			ID:    "TagMessageTitle",
+			Other: "Tag message:",
+		}, &i18n.Message{
+			ID:    "DeleteLocalTag",
+			Other: "delete local tag",
+		}, &i18n.Message{
+			ID:    "DeleteLocalAndRemoteTag",
+			Other: "delete local and remote tag",
+		}, &i18n.Message{
+			ID:    "DeleteRemoteTagTitle",
+			Other: "Remote to delete tag '{{.tagName}}' from",
+		}, &i18n.Message{
+			ID:    "DeleteRemoteTagPrompt",
+			Other: "Are you sure you want to delete tag '{{.tagName}}' locally and on '{{.remoteName}}'?",
+		}, &i18n.Message{
+			ID:    "DeletingTagStatus",
+			Other: "deleting tag",
+		}, &i18n.Message{
+			ID:    "NoRemotes",
+			Other: "This repo has no remotes",
+		}, &i18n.Message{
+			ID:    "NoOriginRemote",
+			Other: "This repo has no origin remote",
+		}, &i18n.Message{
+			ID:    "ForgeNotConfigured",
+			Other: "To use pull requests from within lazygit, set forge.token in your config",
+		}, &i18n.Message{
+			ID:    "viewPullRequests",
+			Other: "view pull requests",
+		}, &i18n.Message{
+			ID:    "PullRequestsTitle",
+			Other: "Open pull requests",
+		}, &i18n.Message{
+			ID:    "NoPullRequests",
+			Other: "There are no open pull requests",
+		}, &i18n.Message{
+			ID:    "FetchingPullRequestsStatus",
+			Other: "fetching pull requests",
+		}, &i18n.Message{
+			ID:    "CheckoutPullRequest",
+			Other: "checkout",
+		}, &i18n.Message{
+			ID:    "OpenPullRequestInBrowser",
+			Other: "open in browser",
+		}, &i18n.Message{
+			ID:    "PullRequestTitle",
+			Other: "Pull request title:",
+		}, &i18n.Message{
+			ID:    "PullRequestBody",
+			Other: "Pull request description:",
+		}, &i18n.Message{
+			ID:    "CreatingPullRequestStatus",
+			Other: "creating pull request",
+		}, &i18n.Message{
+			ID:    "PullRequestCreatedTitle",
+			Other: "Pull request created",
+		}, &i18n.Message{
+			ID:    "PullRequestCreatedPrompt",
+			Other: "Created pull request #{{.number}} into {{.base}}. Open it in your browser?",
+		}, &i18n.Message{
+			ID:    "openCommandLogMenu",
+			Other: "open command log menu",
+		}, &i18n.Message{
+			ID:    "CommandLogTitle",
+			Other: "Command Log",
+		}, &i18n.Message{
+			ID:    "ShowCommandLog",
+			Other: "show command log",
+		}, &i18n.Message{
+			ID:    "HideCommandLog",
+			Other: "hide command log",
+		}, &i18n.Message{
+			ID:    "ShowBackgroundCommands",
+			Other: "show commands run in the background (e.g. to refresh panels)",
+		}, &i18n.Message{
+			ID:    "HideBackgroundCommands",
+			Other: "hide commands run in the background",
+		}, &i18n.Message{
+			ID:    "BackgroundCommands",
+			Other: "background",
+		}, &i18n.Message{
+			ID:    "CopyCommandLog",
+			Other: "copy command log to clipboard",
+		}, &i18n.Message{
+			ID:    "SaveCommandLog",
+			Other: "save command log to file",
+		}, &i18n.Message{
+			ID:    "SaveCommandLogPrompt",
+			Other: "Save command log to:",
+		}, &i18n.Message{
+			ID:    "RunSubprocessAction",
+			Other: "run subprocess",
+		}, &i18n.Message{
+			ID:    "BisectAction",
+			Other: "bisect",
+		}, &i18n.Message{
+			ID:    "EditRebaseTodoAction",
+			Other: "mark commit to {{.action}} in rebase",
+		}, &i18n.Message{
+			ID:    "toggleTreeView",
+			Other: "toggle file tree view",
+		}, &i18n.Message{
+			ID:    "DirectoryHasMergeConflicts",
+			Other: "This directory has files with merge conflicts. Resolve them one file at a time",
+		}, &i18n.Message{
+			ID:    "ExecUnknownOperation",
+			Other: "Unknown operation '{{.operation}}'. Operations: {{.operations}}",
+		}, &i18n.Message{
+			ID:    "ExecWrongArguments",
+			Other: "Usage: lazygit exec {{.usage}}",
+		}, &i18n.Message{
+			ID:    "ExecNotInNormalState",
+			Other: "You can't do this while in a merging or rebasing state",
+		}, &i18n.Message{
+			ID:    "ExecCommitNotFound",
+			Other: "Could not find commit {{.sha}} in the last 300 commits",
+		}, &i18n.Message{
+			ID:    "ExecDirtyWorkingTree",
+			Other: "You have changes to tracked files which a hard reset would lose. Stash or discard them first",
+		}, &i18n.Message{
+			ID:    "ExecNothingToUndo",
+			Other: "Nothing to undo",
+		}, &i18n.Message{
+			ID:    "ExecNothingToRedo",
+			Other: "Nothing to redo",
+		}, &i18n.Message{
+			ID:    "OpenMergeEditor",
+			Other: "open merge editor",
+		}, &i18n.Message{
+			ID:    "MergeEditorTitle",
+			Other: "Merge Editor",
+		}, &i18n.Message{
+			ID:    "MergeResultTitle",
+			Other: "Merge Result",
+		}, &i18n.Message{
+			ID:    "MergeEditorOurs",
+			Other: "Ours",
+		}, &i18n.Message{
+			ID:    "MergeEditorTheirs",
+			Other: "Theirs",
+		}, &i18n.Message{
+			ID:    "MergeEditorResultTitle",
+			Other: "Result: conflict {{.conflict}} in {{.path}} (file {{.fileIndex}})",
+		}, &i18n.Message{
+			ID:    "MergeEditorEditedResultTitle",
+			Other: "Result (edited): conflict {{.conflict}} in {{.path}} (file {{.fileIndex}})",
+		}, &i18n.Message{
+			ID:    "MergeEditorNoLines",
+			Other: "(no lines)",
+		}, &i18n.Message{
+			ID:    "MergeEditorSelectSide",
+			Other: "select ours/theirs",
+		}, &i18n.Message{
+			ID:    "MergeEditorSelectOurs",
+			Other: "select ours",
+		}, &i18n.Message{
+			ID:    "MergeEditorSelectTheirs",
+			Other: "select theirs",
+		}, &i18n.Message{
+			ID:    "MergeEditorToggleSide",
+			Other: "switch between ours and theirs",
+		}, &i18n.Message{
+			ID:    "MergeEditorPickLine",
+			Other: "pick line",
+		}, &i18n.Message{
+			ID:    "MergeEditorPickSide",
+			Other: "pick all lines",
+		}, &i18n.Message{
+			ID:    "MergeEditorEditResult",
+			Other: "edit result",
+		}, &i18n.Message{
+			ID:    "MergeEditorApply",
+			Other: "apply result",
+		}, &i18n.Message{
+			ID:    "MergeEditorDoneEditing",
+			Other: "done editing result",
+		}, &i18n.Message{
+			ID:    "BinaryFileTitle",
+			Other: "Binary file {{.name}}",
+		}, &i18n.Message{
+			ID:    "BinaryFileSize",
+			Other: "Size",
+		}, &i18n.Message{
+			ID:    "BinaryFileDimensions",
+			Other: "Dimensions",
+		}, &i18n.Message{
+			ID:    "BinaryFileNone",
+			Other: "none",
+		}, &i18n.Message{
+			ID:    "EmptyPatchError",
+			Other: "The patch is empty",
+		}, &i18n.Message{
+			ID:    "SavePatchTitle",
+			Other: "Save patch as",
+		}, &i18n.Message{
+			ID:    "OverwriteSavedPatchPrompt",
+			Other: "A patch named '{{.name}}' is already saved. Overwrite it?",
+		}, &i18n.Message{
+			ID:    "InvalidSavedPatchName",
+			Other: "'{{.name}}' can't be used as a patch name",
+		}, &i18n.Message{
+			ID:    "NoSavedPatches",
+			Other: "No saved patches. To save a patch, build one and choose 'save patch' from the patch options",
+		}, &i18n.Message{
+			ID:    "SavedPatchesTitle",
+			Other: "Saved patches",
+		}, &i18n.Message{
+			ID:    "SavedPatchPreviewTitle",
+			Other: "Saved patch {{.name}}",
+		}, &i18n.Message{
+			ID:    "ApplySavedPatch",
+			Other: "apply saved patch",
+		}, &i18n.Message{
+			ID:    "ApplySavedPatchToWorkingTree",
+			Other: "apply to working tree",
+		}, &i18n.Message{
+			ID:    "ApplySavedPatchToWorkingTreeInReverse",
+			Other: "apply to working tree in reverse",
+		}, &i18n.Message{
+			ID:    "ApplySavedPatchToIndex",
+			Other: "apply to index",
+		}, &i18n.Message{
+			ID:    "ApplySavedPatchToIndexInReverse",
+			Other: "apply to index in reverse",
+		}, &i18n.Message{
+			ID:    "ApplySavedPatchToCommit",
+			Other: "apply to selected commit ({{.sha}})",
+		}, &i18n.Message{
+			ID:    "RemoveSavedPatchFromCommit",
+			Other: "remove from selected commit ({{.sha}})",
+		}, &i18n.Message{
+			ID:    "ExportSavedPatch",
+			Other: "export as mailbox file for git am",
+		}, &i18n.Message{
+			ID:    "ExportSavedPatchTitle",
+			Other: "Export patch to",
+		}, &i18n.Message{
+			ID:    "DeleteSavedPatch",
+			Other: "delete saved patch",
+		}, &i18n.Message{
+			ID:    "DeleteSavedPatchPrompt",
+			Other: "Are you sure you want to delete the saved patch '{{.name}}'?",
+		}, &i18n.Message{
+			ID:    "ImportPatches",
+			Other: "apply patch file or mailbox",
+		}, &i18n.Message{
+			ID:    "ImportPatchesTitle",
+			Other: "Apply patch file",
+		}, &i18n.Message{
+			ID:    "EnterPatchFilePath",
+			Other: "enter path...",
+		}, &i18n.Message{
+			ID:    "PatchFilePathTitle",
+			Other: "Path to patch file:",
+		}, &i18n.Message{
+			ID:    "ApplyMailbox",
+			Other: "apply as commits (git am)",
+		}, &i18n.Message{
+			ID:    "ApplyMailboxThreeWay",
+			Other: "apply as commits with three-way merge (git am --3way)",
+		}, &i18n.Message{
+			ID:    "ApplyPatchFileToIndex",
+			Other: "apply to working tree and index (git apply --index)",
+		}, &i18n.Message{
+			ID:    "ApplyingPatchesStatus",
+			Other: "applying patches",
+		}, &i18n.Message{
+			ID:    "ApplyPatchesOptionsTitle",
+			Other: "Apply Patches Options",
+		}, &i18n.Message{
+			ID:    "FormatPatch",
+			Other: "format patch series",
+		}, &i18n.Message{
+			ID:    "FormatPatchTitle",
+			Other: "Format patch series ({{.commits}})",
+		}, &i18n.Message{
+			ID:    "FormatPatchToDirectory",
+			Other: "write patch files to directory",
+		}, &i18n.Message{
+			ID:    "FormatPatchToClipboard",
+			Other: "copy as a single patch to clipboard",
+		}, &i18n.Message{
+			ID:    "FormatPatchCoverLetter",
+			Other: "cover letter",
+		}, &i18n.Message{
+			ID:    "FormatPatchSubjectPrefix",
+			Other: "subject prefix",
+		}, &i18n.Message{
+			ID:    "FormatPatchDefaultSubjectPrefix",
+			Other: "(git default)",
+		}, &i18n.Message{
+			ID:    "FormatPatchVersion",
+			Other: "version",
+		}, &i18n.Message{
+			ID:    "InvalidFormatPatchVersion",
+			Other: "The version must be a whole number of at least 1",
+		}, &i18n.Message{
+			ID:    "FormatPatchDirectoryTitle",
+			Other: "Write patch files to",
+		}, &i18n.Message{
+			ID:    "FormatPatchWrittenTitle",
+			Other: "Patch files written",
+		}, &i18n.Message{
+			ID:    "CantFormatRebasingCommits",
+			Other: "Can't format commits that are still being rebased",
+		}, &i18n.Message{
+			ID:    "Yes",
+			Other: "yes",
+		}, &i18n.Message{
+			ID:    "No",
+			Other: "no",
+		}, &i18n.Message{
+			ID:    "toggleRangeSelect",
+			Other: "toggle range select",
+		}, &i18n.Message{
+			ID:    "RangeHasMergeConflicts",
+			Other: "Can't stage a range that has merge conflicts. Resolve them one file at a time",
+		}, &i18n.Message{
+			ID:    "SelectedFilesTitle",
+			Other: "{{.count}} files",
+		}, &i18n.Message{
+			ID:    "SureDropStashEntries",
+			Other: "Are you sure you want to drop the selected stash entries?",
+		}, &i18n.Message{
+			ID:    "MixedRebasingRange",
+			Other: "Can't act on a range of commits that are only partly in the rebase",
+		}, &i18n.Message{
+			ID:    "SureSquashTheseCommits",
+			Other: "Are you sure you want to squash these {{.count}} commits into the commit below?",
+		}, &i18n.Message{
+			ID:    "SureFixupTheseCommits",
+			Other: "Are you sure you want to 'fixup' these {{.count}} commits? They will be merged into the commit below",
+		}, &i18n.Message{
+			ID:    "DeleteCommitsPrompt",
+			Other: "Are you sure you want to delete these {{.count}} commits?",
+		}, &i18n.Message{
+			ID:    "TodoItemNotFound",
+			Other: "Couldn't find that line in the rebase todo",
+		}, &i18n.Message{
+			ID:    "CantChangeTodoAction",
+			Other: "Can't change a '{{.action}}' line of the rebase todo",
+		}, &i18n.Message{
+			ID:    "CantMoveTodoAction",
+			Other: "Can't move past a '{{.action}}' line of the rebase todo, as other lines depend on it",
+		}, &i18n.Message{
+			ID:    "AddTodoLine",
+			Other: "add exec/break to rebase",
+		}, &i18n.Message{
+			ID:    "AddTodoLineTitle",
+			Other: "Add to rebase",
+		}, &i18n.Message{
+			ID:    "ExecAfterTodoItem",
+			Other: "run a command after this",
+		}, &i18n.Message{
+			ID:    "BreakAfterTodoItem",
+			Other: "stop after this",
+		}, &i18n.Message{
+			ID:    "ExecAfterEachTodoCommit",
+			Other: "run a command after each remaining commit",
+		}, &i18n.Message{
+			ID:    "ExecCommandTitle",
+			Other: "Command to run:",
+		}, &i18n.Message{
+			ID:    "ExecEachCommitTitle",
+			Other: "Command to run after this commit and each one above it:",
+		}, &i18n.Message{
+			ID:    "CanOnlyAddTodoLineToRebase",
+			Other: "You can only add exec or break lines among the commits still to be rebased",
+		}, &i18n.Message{
+			ID:    "RebaseWithMergesTitle",
+			Other: "Rebase '{{.checkedOutBranch}}' onto '{{.selectedBranch}}'",
+		}, &i18n.Message{
+			ID:    "RebaseFlatteningMerges",
+			Other: "rebase, flattening merges",
+		}, &i18n.Message{
+			ID:    "RebaseKeepingMerges",
+			Other: "rebase, keeping merges (--rebase-merges)",
+		}, &i18n.Message{
+			ID:    "markCommitAsUpstream",
+			Other: "mark as upstream for rebase --onto",
+		}, &i18n.Message{
+			ID:    "rebaseOntoTag",
+			Other: "rebase checked-out branch onto this tag",
+		}, &i18n.Message{
+			ID:    "rebasingOnto",
+			Other: "moving the commits after {{.upstream}}: pick a new base with '{{.rebaseKey}}' on a branch or tag, or '{{.markKey}}' on a commit",
+		}, &i18n.Message{
+			ID:    "CantRebaseOntoRebasingCommit",
+			Other: "Can't use a commit that's still being rebased",
+		}, &i18n.Message{
+			ID:    "NoCommitsToRebaseOnto",
+			Other: "There are no commits after the marked upstream to move",
+		}, &i18n.Message{
+			ID:    "RebaseOntoTitle",
+			Other: "Rebase onto",
+		}, &i18n.Message{
+			ID:    "ConfirmRebaseOnto",
+			Other: "Are you sure you want to move these {{.count}} commits after {{.upstream}} onto '{{.newBase}}'?",
+		}, &i18n.Message{
+			ID:    "startFuzzyFilter",
+			Other: "filter list",
+		}, &i18n.Message{
+			ID:    "FuzzyFilterMatches",
+			Other: "{{.count}} of {{.total}} matching '{{.needle}}'",
+		}, &i18n.Message{
+			ID:    "FuzzyFilterOptions",
+			Other: "{{.filterKey}}: change filter, {{.escapeKey}}: stop filtering",
+		}, &i18n.Message{
+			ID:    "MustStopFuzzyFilteringPrompt",
+			Other: "This command depends on where the commit is in the full list, so it isn't available while you're filtering the commits. Stop filtering?",
+		}, &i18n.Message{
+			ID:    "openCommitSearchMenu",
+			Other: "search commits by message, author, content or date",
+		}, &i18n.Message{
+			ID:    "CommitSearchMenuTitle",
+			Other: "Search commits",
+		}, &i18n.Message{
+			ID:    "searchCommitsByMessage",
+			Other: "message matches (--grep)",
+		}, &i18n.Message{
+			ID:    "searchCommitsByAuthor",
+			Other: "author matches (--author)",
+		}, &i18n.Message{
+			ID:    "searchCommitsByString",
+			Other: "adds or removes string (-S)",
+		}, &i18n.Message{
+			ID:    "searchCommitsByRegex",
+			Other: "changes a line matching (-G)",
+		}, &i18n.Message{
+			ID:    "searchCommitsSince",
+			Other: "committed since (--since)",
+		}, &i18n.Message{
+			ID:    "searchCommitsUntil",
+			Other: "committed until (--until)",
+		}, &i18n.Message{
+			ID:    "exitCommitSearch",
+			Other: "stop searching commits",
+		}, &i18n.Message{
+			ID:    "searchingCommits",
+			Other: "searching commits:",
+		}, &i18n.Message{
+			ID:    "MustExitCommitSearchPrompt",
+			Other: "Command not available while searching commits. Stop searching?",
+		}, &i18n.Message{
+			ID:    "RemovePatchFromCommitAction",
+			Other: "remove patch from original commit",
+		}, &i18n.Message{
+			ID:    "MovePatchToSelectedCommitAction",
+			Other: "move patch to selected commit",
+		}, &i18n.Message{
+			ID:    "PullPatchIntoIndexAction",
+			Other: "pull patch out into index",
+		}, &i18n.Message{
+			ID:    "PullPatchIntoNewCommitAction",
+			Other: "pull patch into new commit",
+		}, &i18n.Message{
+			ID:    "ApplyPatchAction",
+			Other: "apply patch",
+		}, &i18n.Message{
+			ID:    "MixedResetAction",
+			Other: "mixed reset",
+		}, &i18n.Message{
+			ID:    "StashAction",
+			Other: "stash {{.method}}",
 		},
 	)
 }
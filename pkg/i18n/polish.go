@@ -50,9 +50,6 @@ func addPolish(i18nObject *i18n.Bundle) error {
 		}, &i18n.Message{
 			ID:    "CredentialsUsername",
 			Other: "Username",
-		}, &i18n.Message{
-			ID:    "CredentialsPassword",
-			Other: "Password",
 		}, &i18n.Message{
 			ID:    "PassUnameWrong",
 			Other: "Password and/or username wrong",
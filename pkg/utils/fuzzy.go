@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// how much each thing counts for when scoring a fuzzy match. We favour
+// characters that follow on from the previous match or start a word, so that
+// e.g. 'fb' ranks 'feature/bar' above 'fix-abbrev'
+const (
+	FUZZY_MATCH_SCORE       = 16
+	FUZZY_CONSECUTIVE_BONUS = 8
+	FUZZY_BOUNDARY_BONUS    = 8
+	FUZZY_GAP_PENALTY       = 1
+)
+
+// FuzzyMatch is an item that matched a fuzzy search
+type FuzzyMatch struct {
+	// Index is the item's index in the list we searched
+	Index int
+	// Positions are the indices of the matched runes in the item
+	Positions []int
+	Score     int
+}
+
+// FuzzySearch returns the items that contain the needle's characters in order,
+// best match first. Matching ignores case unless the needle has an upper case
+// character in it. An empty needle matches everything, in the original order
+func FuzzySearch(needle string, haystacks []string) []FuzzyMatch {
+	matches := []FuzzyMatch{}
+	for i, haystack := range haystacks {
+		if score, positions, ok := FuzzyMatchString(needle, haystack); ok {
+			matches = append(matches, FuzzyMatch{Index: i, Positions: positions, Score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Score > matches[j].Score
+	})
+
+	return matches
+}
+
+// FuzzyMatchString tells us whether the haystack contains the needle's
+// characters in order, and if so, how good a match it is and which runes of
+// the haystack matched
+func FuzzyMatchString(needle string, haystack string) (int, []int, bool) {
+	needleRunes := []rune(needle)
+	haystackRunes := []rune(haystack)
+	if len(needleRunes) == 0 {
+		return 0, nil, true
+	}
+
+	caseSensitive := strings.IndexFunc(needle, unicode.IsUpper) != -1
+	equal := func(a rune, b rune) bool {
+		if caseSensitive {
+			return a == b
+		}
+		return unicode.ToLower(a) == unicode.ToLower(b)
+	}
+
+	// we try starting from each place the first character matches and keep the
+	// best, given the first place isn't always the best e.g. 'bar' in 'abc/bar'
+	bestScore := 0
+	var bestPositions []int
+	for start := range haystackRunes {
+		if !equal(haystackRunes[start], needleRunes[0]) {
+			continue
+		}
+
+		positions := make([]int, 0, len(needleRunes))
+		positions = append(positions, start)
+		for i := start + 1; i < len(haystackRunes) && len(positions) < len(needleRunes); i++ {
+			if equal(haystackRunes[i], needleRunes[len(positions)]) {
+				positions = append(positions, i)
+			}
+		}
+		if len(positions) < len(needleRunes) {
+			// if we couldn't match it all from here we won't from anywhere later
+			break
+		}
+
+		score := fuzzyScore(haystackRunes, positions)
+		if bestPositions == nil || score > bestScore {
+			bestScore = score
+			bestPositions = positions
+		}
+	}
+
+	if bestPositions == nil {
+		return 0, nil, false
+	}
+
+	return bestScore, bestPositions, true
+}
+
+func fuzzyScore(haystackRunes []rune, positions []int) int {
+	score := 0
+	for i, position := range positions {
+		score += FUZZY_MATCH_SCORE
+		if isWordStart(haystackRunes, position) {
+			score += FUZZY_BOUNDARY_BONUS
+		}
+		if i > 0 {
+			gap := position - positions[i-1] - 1
+			if gap == 0 {
+				score += FUZZY_CONSECUTIVE_BONUS
+			} else {
+				score -= gap * FUZZY_GAP_PENALTY
+			}
+		}
+	}
+	return score
+}
+
+func isWordStart(runes []rune, index int) bool {
+	if index == 0 {
+		return true
+	}
+
+	prev := runes[index-1]
+	switch prev {
+	case '/', '-', '_', '.', ' ', ':':
+		return true
+	}
+
+	return unicode.IsLower(prev) && unicode.IsUpper(runes[index])
+}
+
+var sgrRegexp = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+var leadingSgrRegexp = regexp.MustCompile(`^\x1b\[[0-9;]*m`)
+
+// HighlightMatch is like HighlightRunes, but the positions count from the last
+// place the match appears in the coloured string. It returns false if the
+// match doesn't appear in the string
+func HighlightMatch(str string, match string, positions []int) (string, bool) {
+	plain := sgrRegexp.ReplaceAllString(str, "")
+	index := strings.LastIndex(plain, match)
+	if index == -1 {
+		return str, false
+	}
+
+	offset := utf8.RuneCountInString(plain[:index])
+	shiftedPositions := make([]int, len(positions))
+	for i, position := range positions {
+		shiftedPositions[i] = position + offset
+	}
+	return HighlightRunes(str, shiftedPositions), true
+}
+
+// HighlightRunes makes the given runes of a coloured string bold and
+// underlined, where the positions count the runes you'd see once the string is
+// decolorised. The string's own colours carry on after each highlighted run
+func HighlightRunes(str string, positions []int) string {
+	if len(positions) == 0 {
+		return str
+	}
+
+	highlighted := map[int]bool{}
+	for _, position := range positions {
+		highlighted[position] = true
+	}
+
+	var result strings.Builder
+	// activeStyle is every colour code since the last reset, which we write
+	// again after a highlighted run to get back to where we were
+	activeStyle := ""
+	inHighlight := false
+	visibleIndex := 0
+	for len(str) > 0 {
+		if loc := leadingSgrRegexp.FindStringIndex(str); loc != nil {
+			code := str[:loc[1]]
+			if code == "\x1b[0m" || code == "\x1b[m" {
+				activeStyle = ""
+			} else {
+				activeStyle += code
+			}
+			result.WriteString(code)
+			if inHighlight {
+				result.WriteString("\x1b[1;4m")
+			}
+			str = str[loc[1]:]
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(str)
+		if highlighted[visibleIndex] && !inHighlight {
+			result.WriteString("\x1b[1;4m")
+			inHighlight = true
+		} else if !highlighted[visibleIndex] && inHighlight {
+			result.WriteString("\x1b[0m" + activeStyle)
+			inHighlight = false
+		}
+		result.WriteRune(r)
+		str = str[size:]
+		visibleIndex++
+	}
+	if inHighlight {
+		result.WriteString("\x1b[0m" + activeStyle)
+	}
+
+	return result.String()
+}
@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFuzzyMatchString is a function.
+func TestFuzzyMatchString(t *testing.T) {
+	type scenario struct {
+		testName          string
+		needle            string
+		haystack          string
+		expectedMatch     bool
+		expectedPositions []int
+	}
+
+	scenarios := []scenario{
+		{
+			"Empty needle",
+			"",
+			"master",
+			true,
+			nil,
+		},
+		{
+			"Characters in order",
+			"mtr",
+			"master",
+			true,
+			[]int{0, 3, 5},
+		},
+		{
+			"Characters out of order",
+			"rm",
+			"master",
+			false,
+			nil,
+		},
+		{
+			"Lower case needle ignores case",
+			"readme",
+			"README.md",
+			true,
+			[]int{0, 1, 2, 3, 4, 5},
+		},
+		{
+			"Upper case needle is case sensitive",
+			"Readme",
+			"README.md",
+			false,
+			nil,
+		},
+		{
+			"Prefers the start of a word over the first occurrence",
+			"bar",
+			"abc/bar",
+			true,
+			[]int{4, 5, 6},
+		},
+		{
+			"Multibyte characters",
+			"ü",
+			"grün",
+			true,
+			[]int{2},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			_, positions, ok := FuzzyMatchString(s.needle, s.haystack)
+			assert.EqualValues(t, s.expectedMatch, ok)
+			assert.EqualValues(t, s.expectedPositions, positions)
+		})
+	}
+}
+
+// TestFuzzySearch is a function.
+func TestFuzzySearch(t *testing.T) {
+	type scenario struct {
+		testName        string
+		needle          string
+		haystacks       []string
+		expectedIndices []int
+	}
+
+	scenarios := []scenario{
+		{
+			"Empty needle keeps everything in order",
+			"",
+			[]string{"b", "a", "c"},
+			[]int{0, 1, 2},
+		},
+		{
+			"Ranks word starts above scattered matches",
+			"fb",
+			[]string{"fix-abbrev", "master", "feature/bar"},
+			[]int{2, 0},
+		},
+		{
+			"Ranks consecutive matches above gaps",
+			"fix",
+			[]string{"f-i-x", "fix"},
+			[]int{1, 0},
+		},
+		{
+			"Keeps the original order for equal scores",
+			"a",
+			[]string{"a/one", "a/two", "a/three"},
+			[]int{0, 1, 2},
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			indices := []int{}
+			for _, match := range FuzzySearch(s.needle, s.haystacks) {
+				indices = append(indices, match.Index)
+			}
+			assert.EqualValues(t, s.expectedIndices, indices)
+		})
+	}
+}
+
+// TestHighlightRunes is a function.
+func TestHighlightRunes(t *testing.T) {
+	type scenario struct {
+		testName  string
+		str       string
+		positions []int
+		expected  string
+	}
+
+	scenarios := []scenario{
+		{
+			"No positions",
+			"master",
+			nil,
+			"master",
+		},
+		{
+			"Plain string",
+			"master",
+			[]int{0, 1, 5},
+			"\x1b[1;4mma\x1b[0mste\x1b[1;4mr\x1b[0m",
+		},
+		{
+			"Coloured string gets its colour back after each run",
+			"\x1b[32mmaster\x1b[0m",
+			[]int{2},
+			"\x1b[32mma\x1b[1;4ms\x1b[0m\x1b[32mter\x1b[0m",
+		},
+		{
+			"Positions ignore colour codes",
+			"\x1b[33mab\x1b[0m \x1b[36mcd\x1b[0m",
+			[]int{3},
+			"\x1b[33mab\x1b[0m \x1b[36m\x1b[1;4mc\x1b[0m\x1b[36md\x1b[0m",
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result := HighlightRunes(s.str, s.positions)
+			assert.EqualValues(t, s.expected, result)
+			assert.EqualValues(t, Decolorise(s.str), Decolorise(result))
+		})
+	}
+}
+
+// TestHighlightMatch is a function.
+func TestHighlightMatch(t *testing.T) {
+	type scenario struct {
+		testName      string
+		str           string
+		match         string
+		positions     []int
+		expected      string
+		expectedFound bool
+	}
+
+	scenarios := []scenario{
+		{
+			"Match after other text",
+			"\x1b[36m* \x1b[0m\x1b[32mfeature/bar\x1b[0m",
+			"feature/bar",
+			[]int{0, 8},
+			"\x1b[36m* \x1b[0m\x1b[32m\x1b[1;4mf\x1b[0m\x1b[32meature/\x1b[1;4mb\x1b[0m\x1b[32mar\x1b[0m",
+			true,
+		},
+		{
+			"Uses the last place the match appears",
+			"ab ab",
+			"ab",
+			[]int{1},
+			"ab a\x1b[1;4mb\x1b[0m",
+			true,
+		},
+		{
+			"Match not in the string",
+			"master",
+			"develop",
+			[]int{0},
+			"master",
+			false,
+		},
+	}
+
+	for _, s := range scenarios {
+		t.Run(s.testName, func(t *testing.T) {
+			result, found := HighlightMatch(s.str, s.match, s.positions)
+			assert.EqualValues(t, s.expected, result)
+			assert.EqualValues(t, s.expectedFound, found)
+		})
+	}
+}